@@ -1,7 +1,6 @@
 package database
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"net/url"
@@ -110,55 +109,13 @@ func initializeSchemaVersionTable() error {
 	return nil
 }
 
-// getCurrentSchemaVersion returns the current schema version from the database
+// getCurrentSchemaVersion returns the highest registered migration version recorded in the database
 func getCurrentSchemaVersion() (string, error) {
-	if DB == nil {
-		return "", fmt.Errorf("database connection not initialized")
-	}
-
-	var version SchemaVersion
-	// Use GORM Raw to avoid prepared statement conflicts
-	// Raw respects PrepareStmt: false setting
-	result := DB.Raw(`
-		SELECT id, version, description, created_at 
-		FROM schema_versions 
-		ORDER BY created_at DESC 
-		LIMIT 1
-	`).Scan(&version)
-
-	if result.Error != nil {
-		// Check if it's a "no rows" error
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return "", nil // No version found, return empty string
-		}
-		return "", fmt.Errorf("failed to get schema version: %w", result.Error)
-	}
-
-	// Check if we got a result (version.ID will be 0 if no record found)
-	if version.ID == 0 {
-		return "", nil
-	}
-
-	return version.Version, nil
-}
-
-// updateSchemaVersion records a new schema version
-func updateSchemaVersion(version, description string) error {
-	if DB == nil {
-		return fmt.Errorf("database connection not initialized")
-	}
-
-	// Use GORM Exec to avoid prepared statement conflicts
-	result := DB.Exec(`
-		INSERT INTO schema_versions (version, description, created_at)
-		VALUES (?, ?, ?)
-		ON CONFLICT (version) DO NOTHING
-	`, version, description, time.Now())
-	if result.Error != nil {
-		return fmt.Errorf("failed to update schema version: %w", result.Error)
+	info, err := GetSchemaVersionInfo()
+	if err != nil {
+		return "", fmt.Errorf("failed to get schema version: %w", err)
 	}
-
-	return nil
+	return info.Current, nil
 }
 
 // tableExists checks if a table exists in the database
@@ -231,6 +188,13 @@ func getCurrentTables() ([]string, error) {
 	return tables, nil
 }
 
+// isDropUnknownEnabled reports whether Migrate may drop tables that have no model
+// Controlled by MIGRATE_DROP_UNKNOWN (default: false)
+func isDropUnknownEnabled() bool {
+	value := os.Getenv("MIGRATE_DROP_UNKNOWN")
+	return value == "true" || value == "1"
+}
+
 // dropTableSafely drops a table if it exists and is not a system table
 func dropTableSafely(tableName string) error {
 	if DB == nil {
@@ -276,6 +240,13 @@ func Migrate(models ...interface{}) error {
 		return fmt.Errorf("database connection not initialized")
 	}
 
+	// Serialize migrations across instances (e.g. rolling deploys) so concurrent DDL can't deadlock
+	release, err := acquireMigrationLock()
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Get current schema version
 	currentVersion, err := getCurrentSchemaVersion()
 	if err != nil {
@@ -293,6 +264,12 @@ func Migrate(models ...interface{}) error {
 	var companyInfoMigrated bool
 	// Track if we're migrating the fundamental_data table
 	var fundamentalDataMigrated bool
+	// Track if we're migrating the fundamental_line_items table
+	var fundamentalLineItemsMigrated bool
+	// Track if we're migrating the ingestion_runs table
+	var ingestionRunsMigrated bool
+	// Track if we're migrating the company_metrics_snapshots table
+	var companyMetricsSnapshotsMigrated bool
 
 	// Perform migrations for each model
 	for _, model := range models {
@@ -325,6 +302,21 @@ func Migrate(models ...interface{}) error {
 			fundamentalDataMigrated = true
 		}
 
+		// Check if this is the fundamental_line_items table
+		if tableName == "fundamental_line_items" {
+			fundamentalLineItemsMigrated = true
+		}
+
+		// Check if this is the ingestion_runs table
+		if tableName == "ingestion_runs" {
+			ingestionRunsMigrated = true
+		}
+
+		// Check if this is the company_metrics_snapshots table
+		if tableName == "company_metrics_snapshots" {
+			companyMetricsSnapshotsMigrated = true
+		}
+
 		// Check if table exists before migration
 		exists, err := tableExists(tableName)
 		if err != nil {
@@ -358,6 +350,9 @@ func Migrate(models ...interface{}) error {
 	}
 
 	// Find and drop tables that no longer exist in models
+	// Dropping is opt-in (MIGRATE_DROP_UNKNOWN=true); otherwise unknown tables are only logged,
+	// so a model accidentally left out of the Migrate call doesn't lose its data
+	dropUnknown := isDropUnknownEnabled()
 	for _, tableName := range currentTables {
 		// Skip system tables
 		if tableName == "schema_versions" {
@@ -366,6 +361,10 @@ func Migrate(models ...interface{}) error {
 
 		// If table is not in expected tables, it should be dropped
 		if !expectedTables[tableName] {
+			if !dropUnknown {
+				log.Printf("Table %s no longer exists in models, would drop (dry run; set MIGRATE_DROP_UNKNOWN=true to drop)", tableName)
+				continue
+			}
 			log.Printf("Table %s no longer exists in models, dropping...", tableName)
 			if err := dropTableSafely(tableName); err != nil {
 				log.Printf("Warning: Failed to drop table %s: %v", tableName, err)
@@ -374,8 +373,14 @@ func Migrate(models ...interface{}) error {
 		}
 	}
 
+	// RLS/Realtime setup relies on Supabase roles and publications; skip it for plain Postgres
+	skipRLS := isRLSSetupSkipped()
+	if skipRLS {
+		log.Println("Skipping RLS/Realtime setup (SKIP_RLS_SETUP=true)")
+	}
+
 	// Apply RLS policies and Realtime for screener table if it was migrated
-	if stocksMigrated {
+	if stocksMigrated && !skipRLS {
 		if err := setupScreenerPolicies(); err != nil {
 			log.Printf("Warning: Failed to setup screener policies: %v", err)
 			// Don't fail migration if policy setup fails, but log it
@@ -383,7 +388,7 @@ func Migrate(models ...interface{}) error {
 	}
 
 	// Apply RLS policies for historical table if it was migrated
-	if historicalMigrated {
+	if historicalMigrated && !skipRLS {
 		if err := setupHistoricalPolicies(); err != nil {
 			log.Printf("Warning: Failed to setup historical policies: %v", err)
 			// Don't fail migration if policy setup fails, but log it
@@ -391,7 +396,7 @@ func Migrate(models ...interface{}) error {
 	}
 
 	// Apply RLS policies for company_info table if it was migrated
-	if companyInfoMigrated {
+	if companyInfoMigrated && !skipRLS {
 		if err := setupCompanyInfoPolicies(); err != nil {
 			log.Printf("Warning: Failed to setup company_info policies: %v", err)
 			// Don't fail migration if policy setup fails, but log it
@@ -399,30 +404,61 @@ func Migrate(models ...interface{}) error {
 	}
 
 	// Apply RLS policies for fundamental_data table if it was migrated
-	if fundamentalDataMigrated {
+	if fundamentalDataMigrated && !skipRLS {
 		if err := setupFundamentalDataPolicies(); err != nil {
 			log.Printf("Warning: Failed to setup fundamental_data policies: %v", err)
 			// Don't fail migration if policy setup fails, but log it
 		}
 	}
 
+	// Apply RLS policies for fundamental_line_items table if it was migrated
+	if fundamentalLineItemsMigrated && !skipRLS {
+		if err := setupFundamentalLineItemPolicies(); err != nil {
+			log.Printf("Warning: Failed to setup fundamental_line_items policies: %v", err)
+			// Don't fail migration if policy setup fails, but log it
+		}
+	}
+
+	// Apply RLS policies for company_metrics_snapshots table if it was migrated
+	if companyMetricsSnapshotsMigrated && !skipRLS {
+		if err := setupCompanyMetricsSnapshotPolicies(); err != nil {
+			log.Printf("Warning: Failed to setup company_metrics_snapshots policies: %v", err)
+			// Don't fail migration if policy setup fails, but log it
+		}
+	}
+
+	// Lock down the ingestion_runs table (admin audit data, served only via /api/admin)
+	if ingestionRunsMigrated && !skipRLS {
+		if err := setupIngestionRunPolicies(); err != nil {
+			log.Printf("Warning: Failed to setup ingestion_runs policies: %v", err)
+			// Don't fail migration if policy setup fails, but log it
+		}
+	}
+
 	// Apply RLS policies for schema_versions table (system table)
-	if err := setupSchemaVersionPolicies(); err != nil {
-		log.Printf("Warning: Failed to setup schema_versions policies: %v", err)
-		// Don't fail migration if policy setup fails, but log it
+	if !skipRLS {
+		if err := setupSchemaVersionPolicies(); err != nil {
+			log.Printf("Warning: Failed to setup schema_versions policies: %v", err)
+			// Don't fail migration if policy setup fails, but log it
+		}
 	}
 
-	// Update schema version after successful migration
-	// Using current timestamp as version identifier for simplicity
-	// You can customize this to use semantic versioning if needed
-	newVersion := fmt.Sprintf("%d", time.Now().Unix())
-	description := "Migration completed"
-	if err := updateSchemaVersion(newVersion, description); err != nil {
-		// Log but don't fail migration if version tracking fails
-		log.Printf("Warning: Failed to update schema version: %v", err)
+	// Run versioned migrations that haven't been applied yet
+	// A version is only recorded when its migration actually runs
+	ran, err := applyPendingMigrations()
+	if err != nil {
+		return err
 	}
 
-	if currentVersion != "" {
+	newVersion, err := getCurrentSchemaVersion()
+	if err != nil {
+		// Log but don't fail migration if version lookup fails
+		log.Printf("Warning: Failed to read schema version: %v", err)
+	}
+
+	if ran == 0 {
+		log.Printf("Schema version %s is up to date", newVersion)
+	} else if currentVersion != "" {
 		log.Printf("Schema version updated from %s to %s", currentVersion, newVersion)
 	} else {
 		log.Printf("Schema version set to %s (first migration)", newVersion)
@@ -481,10 +517,9 @@ func setupScreenerPolicies() error {
 
 	// Add screener table to Supabase Realtime publication
 	// This enables real-time subscriptions for the table
-	if err := DB.Exec(`ALTER PUBLICATION supabase_realtime ADD TABLE screener`).Error; err != nil {
-		// This might fail if table is already in publication or publication doesn't exist
+	if err := addTableToRealtimePublication("screener"); err != nil {
 		// Log but don't fail - Realtime may need to be enabled in Supabase dashboard
-		log.Printf("Note: Could not add screener to Realtime publication (may already exist or Realtime not enabled): %v", err)
+		log.Printf("Note: Could not add screener to Realtime publication: %v", err)
 	}
 
 	log.Println("Successfully configured RLS policies and Realtime for screener table")
@@ -641,9 +676,9 @@ func setupCompanyInfoPolicies() error {
 	}
 
 	// Add company_info table to Supabase Realtime publication
-	if err := DB.Exec(`ALTER PUBLICATION supabase_realtime ADD TABLE company_info`).Error; err != nil {
-		// This might fail if table is already in publication or publication doesn't exist
-		log.Printf("Note: Could not add company_info to Realtime publication (may already exist or Realtime not enabled): %v", err)
+	if err := addTableToRealtimePublication("company_info"); err != nil {
+		// Log but don't fail - Realtime may need to be enabled in Supabase dashboard
+		log.Printf("Note: Could not add company_info to Realtime publication: %v", err)
 	}
 
 	log.Println("Successfully configured RLS policies and Realtime for company_info table")
@@ -702,15 +737,164 @@ func setupFundamentalDataPolicies() error {
 	}
 
 	// Add fundamental_data table to Supabase Realtime publication
-	if err := DB.Exec(`ALTER PUBLICATION supabase_realtime ADD TABLE fundamental_data`).Error; err != nil {
-		// This might fail if table is already in publication or publication doesn't exist
-		log.Printf("Note: Could not add fundamental_data to Realtime publication (may already exist or Realtime not enabled): %v", err)
+	if err := addTableToRealtimePublication("fundamental_data"); err != nil {
+		// Log but don't fail - Realtime may need to be enabled in Supabase dashboard
+		log.Printf("Note: Could not add fundamental_data to Realtime publication: %v", err)
 	}
 
 	log.Println("Successfully configured RLS policies and Realtime for fundamental_data table")
 	return nil
 }
 
+// setupFundamentalLineItemPolicies sets up RLS policies for the fundamental_line_items table (read-only)
+func setupFundamentalLineItemPolicies() error {
+	if DB == nil {
+		return fmt.Errorf("database connection not initialized")
+	}
+
+	// Enable Row Level Security on fundamental_line_items table
+	if err := DB.Exec(`ALTER TABLE IF EXISTS fundamental_line_items ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on fundamental_line_items table: %w", err)
+	}
+
+	// Revoke all privileges from anon and authenticated roles
+	if err := DB.Exec(`REVOKE ALL ON TABLE fundamental_line_items FROM anon, authenticated`).Error; err != nil {
+		// Log but don't fail - this might error if privileges don't exist
+		log.Printf("Note: Could not revoke privileges (may not exist): %v", err)
+	}
+
+	// Grant SELECT permission to both anon and authenticated users (read-only access for all)
+	if err := DB.Exec(`GRANT SELECT ON TABLE fundamental_line_items TO anon, authenticated`).Error; err != nil {
+		return fmt.Errorf("failed to grant SELECT permission: %w", err)
+	}
+
+	// Drop existing policy if it exists, then create the read-only policy
+	if err := DB.Exec(`
+		DROP POLICY IF EXISTS "Allow select on fundamental line items" ON fundamental_line_items;
+	`).Error; err != nil {
+		log.Printf("Note: Could not drop existing policies: %v", err)
+	}
+
+	if err := DB.Exec(`
+		CREATE POLICY "Allow select on fundamental line items"
+		ON fundamental_line_items
+		FOR SELECT
+		USING (true)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create read-only policy: %w", err)
+	}
+
+	log.Println("Successfully configured RLS policies for fundamental_line_items table")
+	return nil
+}
+
+// setupCompanyMetricsSnapshotPolicies sets up read-only Row Level Security for company_metrics_snapshots
+// Snapshots are written by the backend's company-info ingestion only
+func setupCompanyMetricsSnapshotPolicies() error {
+	if DB == nil {
+		return fmt.Errorf("database connection not initialized")
+	}
+
+	// Enable Row Level Security on company_metrics_snapshots table
+	if err := DB.Exec(`ALTER TABLE IF EXISTS company_metrics_snapshots ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on company_metrics_snapshots table: %w", err)
+	}
+
+	// Revoke all privileges from anon and authenticated roles
+	if err := DB.Exec(`REVOKE ALL ON TABLE company_metrics_snapshots FROM anon, authenticated`).Error; err != nil {
+		// Log but don't fail - this might error if privileges don't exist
+		log.Printf("Note: Could not revoke privileges (may not exist): %v", err)
+	}
+
+	// Grant SELECT permission to both anon and authenticated users (read-only access for all)
+	if err := DB.Exec(`GRANT SELECT ON TABLE company_metrics_snapshots TO anon, authenticated`).Error; err != nil {
+		return fmt.Errorf("failed to grant SELECT permission: %w", err)
+	}
+
+	// Drop existing policy if it exists, then create the read-only policy
+	if err := DB.Exec(`
+		DROP POLICY IF EXISTS "Allow select on company metrics snapshots" ON company_metrics_snapshots;
+	`).Error; err != nil {
+		log.Printf("Note: Could not drop existing policies: %v", err)
+	}
+
+	if err := DB.Exec(`
+		CREATE POLICY "Allow select on company metrics snapshots"
+		ON company_metrics_snapshots
+		FOR SELECT
+		USING (true)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create read-only policy: %w", err)
+	}
+
+	log.Println("Successfully configured RLS policies for company_metrics_snapshots table")
+	return nil
+}
+
+// setupIngestionRunPolicies enables RLS on the ingestion_runs table with no policies,
+// so anon and authenticated roles can't read or write it; the backend connects as the table owner
+func setupIngestionRunPolicies() error {
+	if DB == nil {
+		return fmt.Errorf("database connection not initialized")
+	}
+
+	// Enable Row Level Security on ingestion_runs table
+	if err := DB.Exec(`ALTER TABLE IF EXISTS ingestion_runs ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on ingestion_runs table: %w", err)
+	}
+
+	// Revoke all privileges from anon and authenticated roles
+	if err := DB.Exec(`REVOKE ALL ON TABLE ingestion_runs FROM anon, authenticated`).Error; err != nil {
+		// Log but don't fail - this might error if privileges don't exist
+		log.Printf("Note: Could not revoke privileges on ingestion_runs (may not exist): %v", err)
+	}
+
+	// No policies are created: in PostgreSQL RLS, operations without a policy are denied
+
+	log.Println("Successfully configured RLS for ingestion_runs table")
+	return nil
+}
+
+// realtimePublication is the Supabase publication that drives Realtime subscriptions
+const realtimePublication = "supabase_realtime"
+
+// isRLSSetupSkipped reports whether Migrate should skip RLS/Realtime setup
+// Controlled by SKIP_RLS_SETUP (default: false); use it for plain Postgres without Supabase roles
+func isRLSSetupSkipped() bool {
+	value := os.Getenv("SKIP_RLS_SETUP")
+	return value == "true" || value == "1"
+}
+
+// addTableToRealtimePublication adds a table to the Realtime publication unless it's already a member.
+// It's a no-op when the publication doesn't exist (e.g. Realtime disabled or non-Supabase Postgres).
+func addTableToRealtimePublication(tableName string) error {
+	var publications int64
+	if err := DB.Raw(`SELECT COUNT(*) FROM pg_publication WHERE pubname = ?`, realtimePublication).Scan(&publications).Error; err != nil {
+		return fmt.Errorf("failed to check publication %s: %w", realtimePublication, err)
+	}
+	if publications == 0 {
+		log.Printf("Note: Publication %s does not exist, skipping Realtime for %s", realtimePublication, tableName)
+		return nil
+	}
+
+	var members int64
+	if err := DB.Raw(`
+		SELECT COUNT(*) FROM pg_publication_tables
+		WHERE pubname = ? AND schemaname = 'public' AND tablename = ?
+	`, realtimePublication, tableName).Scan(&members).Error; err != nil {
+		return fmt.Errorf("failed to check publication membership for %s: %w", tableName, err)
+	}
+	if members > 0 {
+		return nil
+	}
+
+	// Identifiers can't be bound as parameters; tableName comes from the fixed set of model tables
+	if err := DB.Exec(fmt.Sprintf(`ALTER PUBLICATION %s ADD TABLE "%s"`, realtimePublication, tableName)).Error; err != nil {
+		return fmt.Errorf("failed to add %s to publication %s: %w", tableName, realtimePublication, err)
+	}
+	return nil
+}
+
 // GetDB returns the database instance
 func GetDB() *gorm.DB {
 	return DB
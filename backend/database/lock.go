@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// migrationLockKey is the Postgres advisory lock key held while Migrate runs
+// Any constant works as long as every instance uses the same one
+const migrationLockKey int64 = 7283910452
+
+// defaultMigrationLockTimeout bounds how long an instance waits for another migrator
+const defaultMigrationLockTimeout = 5 * time.Minute
+
+// migrationLockPollInterval is how often a waiting instance retries the lock
+const migrationLockPollInterval = time.Second
+
+// getMigrationLockTimeout reads MIGRATE_LOCK_TIMEOUT (Go duration, e.g. "2m")
+func getMigrationLockTimeout() time.Duration {
+	value := os.Getenv("MIGRATE_LOCK_TIMEOUT")
+	if value == "" {
+		return defaultMigrationLockTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		log.Printf("Warning: invalid MIGRATE_LOCK_TIMEOUT %q, using default %s", value, defaultMigrationLockTimeout)
+		return defaultMigrationLockTimeout
+	}
+	return timeout
+}
+
+// acquireMigrationLock takes a session-level advisory lock so only one instance migrates at a time.
+// The lock is held on a dedicated connection (advisory locks belong to the session that took them);
+// the returned func releases it and returns the connection to the pool.
+func acquireMigrationLock() (func(), error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database connection not initialized")
+	}
+
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	timeout := getMigrationLockTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for migration lock: %w", err)
+	}
+
+	waiting := false
+	for {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, migrationLockKey).Scan(&acquired); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+
+		if !waiting {
+			log.Printf("Another instance is running migrations, waiting up to %s for the lock...", timeout)
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return nil, fmt.Errorf("timed out after %s waiting for migration lock", timeout)
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+
+	log.Printf("Acquired migration lock")
+	return func() { releaseMigrationLock(conn) }, nil
+}
+
+// releaseMigrationLock unlocks the advisory lock and closes the dedicated connection
+func releaseMigrationLock(conn *sql.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+		log.Printf("Warning: Failed to release migration lock: %v", err)
+		return
+	}
+	log.Printf("Released migration lock")
+}
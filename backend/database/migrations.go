@@ -0,0 +1,181 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VersionedMigration is an explicit schema change identified by a semantic version.
+// Table creation and new columns are still handled by AutoMigrate; versioned migrations
+// cover changes AutoMigrate can't express (data fixes, index rewrites, renames).
+type VersionedMigration struct {
+	Version     string
+	Description string
+	Up          func(db *gorm.DB) error
+}
+
+// migrations is the ordered registry of versioned migrations.
+// Append new entries with a higher version; never edit or reorder applied ones.
+var migrations = []VersionedMigration{
+	{
+		Version:     "1.0.0",
+		Description: "Baseline schema managed by AutoMigrate",
+		Up:          func(db *gorm.DB) error { return nil },
+	},
+	{
+		Version:     "1.1.0",
+		Description: "Remove legacy unix-timestamp schema versions",
+		Up: func(db *gorm.DB) error {
+			// Earlier releases recorded time.Now().Unix() on every startup
+			return db.Exec(`DELETE FROM schema_versions WHERE version ~ '^[0-9]+$'`).Error
+		},
+	},
+	{
+		Version:     "1.2.0",
+		Description: "Enable pg_trgm and add trigram indexes on company_info(name) and screener(symbol)",
+		Up: func(db *gorm.DB) error {
+			// Managed Postgres may not let this role create extensions; skip (and retry next
+			// startup) rather than failing the whole migration
+			db.SavePoint("pg_trgm")
+			if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm`).Error; err != nil {
+				db.RollbackTo("pg_trgm")
+				log.Printf("Warning: Could not enable pg_trgm (insufficient privileges?): %v", err)
+				return errMigrationSkipped
+			}
+			if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_company_info_name_trgm ON company_info USING gin (name gin_trgm_ops)`).Error; err != nil {
+				return err
+			}
+			return db.Exec(`CREATE INDEX IF NOT EXISTS idx_screener_symbol_trgm ON screener USING gin (symbol gin_trgm_ops)`).Error
+		},
+	},
+	{
+		Version:     "1.3.0",
+		Description: "Add a trigram index on company_info(symbol) for fuzzy search",
+		Up: func(db *gorm.DB) error {
+			// Waits for 1.2.0 to enable pg_trgm
+			var count int64
+			if err := db.Raw(`SELECT COUNT(*) FROM pg_extension WHERE extname = 'pg_trgm'`).Scan(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errMigrationSkipped
+			}
+			return db.Exec(`CREATE INDEX IF NOT EXISTS idx_company_info_symbol_trgm ON company_info USING gin (symbol gin_trgm_ops)`).Error
+		},
+	},
+}
+
+// errMigrationSkipped is returned by a migration that can't run in this environment yet
+// The version isn't recorded, so the migration is retried on the next startup
+var errMigrationSkipped = errors.New("migration skipped")
+
+// AppliedMigration describes a registered migration and whether it has run
+type AppliedMigration struct {
+	Version     string     `json:"version"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+// SchemaVersionInfo reports the current schema version and all available migrations
+type SchemaVersionInfo struct {
+	Current    string             `json:"current"`
+	Latest     string             `json:"latest"`
+	Pending    int                `json:"pending"`
+	Migrations []AppliedMigration `json:"migrations"`
+}
+
+// getAppliedVersions returns the recorded versions from schema_versions keyed by version
+func getAppliedVersions() (map[string]SchemaVersion, error) {
+	if DB == nil {
+		return nil, fmt.Errorf("database connection not initialized")
+	}
+
+	var rows []SchemaVersion
+	if err := DB.Raw(`SELECT id, version, description, created_at FROM schema_versions`).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load schema versions: %w", err)
+	}
+
+	applied := make(map[string]SchemaVersion, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// applyPendingMigrations runs registered migrations that haven't been recorded yet, in order.
+// A version is only recorded after its migration succeeds, and each runs in its own transaction.
+func applyPendingMigrations() (int, error) {
+	applied, err := getAppliedVersions()
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		log.Printf("Applying schema migration %s: %s", m.Version, m.Description)
+		skipped := false
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				if errors.Is(err, errMigrationSkipped) {
+					skipped = true
+					return nil
+				}
+				return err
+			}
+			return tx.Exec(`
+				INSERT INTO schema_versions (version, description, created_at)
+				VALUES (?, ?, ?)
+				ON CONFLICT (version) DO NOTHING
+			`, m.Version, m.Description, time.Now()).Error
+		})
+		if err != nil {
+			return ran, fmt.Errorf("failed to apply schema migration %s: %w", m.Version, err)
+		}
+		if skipped {
+			log.Printf("Skipped schema migration %s; it will be retried on next startup", m.Version)
+			continue
+		}
+		ran++
+	}
+
+	return ran, nil
+}
+
+// GetSchemaVersionInfo returns the current schema version and the status of every registered migration
+func GetSchemaVersionInfo() (*SchemaVersionInfo, error) {
+	applied, err := getAppliedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SchemaVersionInfo{
+		Migrations: make([]AppliedMigration, 0, len(migrations)),
+	}
+	for _, m := range migrations {
+		entry := AppliedMigration{
+			Version:     m.Version,
+			Description: m.Description,
+		}
+		if row, ok := applied[m.Version]; ok {
+			createdAt := row.CreatedAt
+			entry.Applied = true
+			entry.AppliedAt = &createdAt
+			info.Current = m.Version
+		} else {
+			info.Pending++
+		}
+		info.Migrations = append(info.Migrations, entry)
+		info.Latest = m.Version
+	}
+
+	return info, nil
+}
@@ -0,0 +1,142 @@
+// Package format holds the shared monetary and percent formatting used across the API.
+// Upstream company info arrives as display strings ("2.91T", "-5.06%") while metrics and
+// screens work in floats; these helpers convert between the two so every response and every
+// parser agrees on one set of suffixes and precisions.
+package format
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// magnitudeSuffixes maps the K/M/B/T magnitude suffixes to their multipliers
+var magnitudeSuffixes = map[string]float64{
+	"K": 1e3,
+	"M": 1e6,
+	"B": 1e9,
+	"T": 1e12,
+}
+
+// magnitudes lists the suffixes largest first for formatting; the empty suffix formats amounts
+// under one thousand
+var magnitudes = []struct {
+	suffix string
+	value  float64
+}{
+	{"T", 1e12},
+	{"B", 1e9},
+	{"M", 1e6},
+	{"K", 1e3},
+	{"", 1},
+}
+
+// FormatMarketCap formats a dollar amount with a magnitude suffix and two decimals, e.g. 2.91e12 -> "2.91T".
+// A value that would round to 1000.00 of one suffix moves up to the next, so 999,999,999 is
+// "1.00B" rather than "1000.00M". Amounts under one thousand are formatted without a suffix.
+func FormatMarketCap(value float64) string {
+	abs := math.Abs(value)
+	for _, m := range magnitudes {
+		if abs >= m.value || m.value == 1 || math.Round(abs/(m.value/1e3)*100) >= 1e5 {
+			return strconv.FormatFloat(value/m.value, 'f', 2, 64) + m.suffix
+		}
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// FormatPercent formats a percent value with an explicit sign and two decimals, e.g. -5.06 -> "-5.06%"
+func FormatPercent(value float64) string {
+	if value >= 0 {
+		return fmt.Sprintf("+%.2f%%", value)
+	}
+	return fmt.Sprintf("%.2f%%", value)
+}
+
+// FormatPrice formats a price with two decimals, e.g. 182.5 -> "182.50"
+func FormatPrice(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// ParseNumber parses a display number such as "1,234.5", "(1,234.5)", "-$12.3M", "$-12.3M" or "4.1B".
+// Commas are thousands separators, parentheses or a sign (before or after "$") mark a negative
+// value and a K/M/B/T suffix scales the value. Placeholders like "*", "-", "" and "N/A" return an error.
+func ParseNumber(value string) (float64, error) {
+	str := strings.TrimSpace(value)
+	if str == "" || str == "*" || str == "-" || strings.EqualFold(str, "N/A") {
+		return 0, fmt.Errorf("non-numeric value: %q", value)
+	}
+
+	negative := false
+	if strings.HasPrefix(str, "(") && strings.HasSuffix(str, ")") {
+		negative = true
+		str = strings.TrimSpace(str[1 : len(str)-1])
+	}
+
+	str = strings.ReplaceAll(str, ",", "")
+	// A sign may precede the currency symbol ("-$12.3M"); one after it is left to ParseFloat
+	if rest, ok := strings.CutPrefix(str, "-"); ok && strings.HasPrefix(rest, "$") {
+		negative = !negative
+		str = rest
+	} else if rest, ok := strings.CutPrefix(str, "+"); ok && strings.HasPrefix(rest, "$") {
+		str = rest
+	}
+	str = strings.TrimPrefix(str, "$")
+
+	multiplier := 1.0
+	if n := len(str); n > 0 {
+		suffix := strings.ToUpper(str[n-1:])
+		if m, ok := magnitudeSuffixes[suffix]; ok {
+			multiplier = m
+			str = strings.TrimSpace(str[:n-1])
+		}
+	}
+
+	number, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, fmt.Errorf("non-numeric value: %q", value)
+	}
+
+	number *= multiplier
+	if negative {
+		number = -number
+	}
+	return number, nil
+}
+
+// ParseMarketCap parses a market cap such as "2.91T" or "$512.3M" into dollars; it is the inverse of FormatMarketCap
+func ParseMarketCap(value string) (float64, error) {
+	return ParseNumber(value)
+}
+
+// ParsePercent parses a percent such as "-5.06%" or "+0.01%"; it is the inverse of FormatPercent
+func ParsePercent(value string) (float64, error) {
+	str := strings.TrimSuffix(strings.TrimSpace(value), "%")
+	str = strings.TrimPrefix(str, "+")
+	return ParseNumber(str)
+}
+
+// ParsePrice parses a price such as "182.50" or "1,204.10"; it is the inverse of FormatPrice
+func ParsePrice(value string) (float64, error) {
+	return ParseNumber(value)
+}
+
+// NormalizePercent rewrites an upstream percent string in the FormatPercent style ("+0.1%" -> "+0.10%").
+// Values that don't parse are returned unchanged.
+func NormalizePercent(value string) string {
+	number, err := ParsePercent(value)
+	if err != nil {
+		return value
+	}
+	return FormatPercent(number)
+}
+
+// NormalizeMarketCap rewrites an upstream market cap string in the FormatMarketCap style ("2.9T" -> "2.90T").
+// Values that don't parse are returned unchanged.
+func NormalizeMarketCap(value string) string {
+	number, err := ParseMarketCap(value)
+	if err != nil {
+		return value
+	}
+	return FormatMarketCap(number)
+}
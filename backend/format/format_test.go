@@ -0,0 +1,145 @@
+package format
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatMarketCap(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "0.00"},
+		{999.99, "999.99"},
+		{999.999, "1.00K"},
+		{1000, "1.00K"},
+		{1234.5, "1.23K"},
+		{512.3e6, "512.30M"},
+		{999_999_999, "1.00B"},
+		{999_994_000, "999.99M"},
+		{2.91e12, "2.91T"},
+		{-12.3e6, "-12.30M"},
+		{-999_999_999, "-1.00B"},
+		{4.5e15, "4500.00T"},
+	}
+	for _, tt := range tests {
+		if got := FormatMarketCap(tt.value); got != tt.want {
+			t.Errorf("FormatMarketCap(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"1,234.5", 1234.5},
+		{"(1,234.5)", -1234.5},
+		{"4.1B", 4.1e9},
+		{"2.91t", 2.91e12},
+		{"$512.3M", 512.3e6},
+		{"-$12.3M", -12.3e6},
+		{"$-12.3M", -12.3e6},
+		{"+$5K", 5e3},
+		{"($1.5K)", -1.5e3},
+		{" 182.50 ", 182.5},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumber(tt.input)
+		if err != nil {
+			t.Errorf("ParseNumber(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-6*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("ParseNumber(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "*", "-", "N/A", "n/a", "abc", "$", "1.2X"} {
+		if _, err := ParseNumber(input); err == nil {
+			t.Errorf("ParseNumber(%q) expected an error", input)
+		}
+	}
+}
+
+func TestMarketCapRoundTrip(t *testing.T) {
+	values := []float64{0, 1, 999.99, 1000, 1234.5, 999_999, 1_000_000, 512.3e6, 999_999_999, 1e9, 2.91e12, -12.3e6, -999_999_999}
+	for _, value := range values {
+		formatted := FormatMarketCap(value)
+		parsed, err := ParseMarketCap(formatted)
+		if err != nil {
+			t.Errorf("ParseMarketCap(FormatMarketCap(%v) = %q) returned error: %v", value, formatted, err)
+			continue
+		}
+		// Two decimals at the chosen magnitude: within half a hundredth of it
+		if tolerance := 0.005 * magnitudeOf(formatted); math.Abs(parsed-value) > tolerance+1e-9 {
+			t.Errorf("round trip of %v via %q = %v, off by more than %v", value, formatted, parsed, tolerance)
+		}
+		if again := FormatMarketCap(parsed); again != formatted {
+			t.Errorf("FormatMarketCap(ParseMarketCap(%q)) = %q, want it unchanged", formatted, again)
+		}
+	}
+}
+
+func TestPercentRoundTrip(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "+0.00%"},
+		{0.01, "+0.01%"},
+		{-5.06, "-5.06%"},
+		{123.456, "+123.46%"},
+	}
+	for _, tt := range tests {
+		formatted := FormatPercent(tt.value)
+		if formatted != tt.want {
+			t.Errorf("FormatPercent(%v) = %q, want %q", tt.value, formatted, tt.want)
+		}
+		parsed, err := ParsePercent(formatted)
+		if err != nil {
+			t.Errorf("ParsePercent(%q) returned error: %v", formatted, err)
+			continue
+		}
+		if math.Abs(parsed-tt.value) > 0.005+1e-9 {
+			t.Errorf("ParsePercent(%q) = %v, want %v", formatted, parsed, tt.value)
+		}
+	}
+
+	if got := NormalizePercent("+0.1%"); got != "+0.10%" {
+		t.Errorf("NormalizePercent(%q) = %q, want %q", "+0.1%", got, "+0.10%")
+	}
+	if got := NormalizePercent("N/A"); got != "N/A" {
+		t.Errorf("NormalizePercent(%q) = %q, want it unchanged", "N/A", got)
+	}
+}
+
+func TestPriceRoundTrip(t *testing.T) {
+	for _, value := range []float64{0, 0.5, 182.5, 1204.1, 99999.99} {
+		formatted := FormatPrice(value)
+		parsed, err := ParsePrice(formatted)
+		if err != nil {
+			t.Errorf("ParsePrice(%q) returned error: %v", formatted, err)
+			continue
+		}
+		if math.Abs(parsed-value) > 0.005+1e-9 {
+			t.Errorf("ParsePrice(FormatPrice(%v) = %q) = %v", value, formatted, parsed)
+		}
+	}
+
+	if got, err := ParsePrice("1,204.10"); err != nil || got != 1204.1 {
+		t.Errorf("ParsePrice(%q) = %v, %v; want 1204.1", "1,204.10", got, err)
+	}
+}
+
+// magnitudeOf returns the multiplier of a formatted market cap's suffix
+func magnitudeOf(formatted string) float64 {
+	if n := len(formatted); n > 0 {
+		if m, ok := magnitudeSuffixes[formatted[n-1:]]; ok {
+			return m
+		}
+	}
+	return 1
+}
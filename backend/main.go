@@ -8,6 +8,7 @@ import (
 	"screener/backend/model"
 	"screener/backend/routes"
 	"screener/backend/service/caching"
+	indicatorsscreening "screener/backend/service/filtering/indicators/screening"
 	"screener/backend/supabase"
 	"strings"
 	"syscall"
@@ -32,6 +33,7 @@ func main() {
 
 	// Initialize Redis cache connection
 	var persister *caching.Persister
+	var stopSymbolRefresh func()
 	log.Println("🔌 Initializing Redis cache connection...")
 	if err := caching.InitRedis(); err != nil {
 		log.Printf("❌ Warning: Failed to initialize Redis cache: %v. Continuing without cache.", err)
@@ -63,6 +65,12 @@ func main() {
 			log.Printf("   Next persistence run: %s", nextRun.Format("2006-01-02 15:04:05 MST"))
 		}
 
+		// Start periodic symbol cache refresh if configured
+		if interval := caching.GetTTLConfig().SymbolsRefreshInterval; interval > 0 {
+			stopSymbolRefresh = symbolCache.StartPeriodicRefresh(interval)
+			log.Printf("🔁 Symbol cache refresh scheduled every %v", interval)
+		}
+
 		// Log cache statistics
 		if stats, err := caching.GetCacheStats(); err == nil {
 			log.Printf("📊 Redis Cache Statistics:")
@@ -71,12 +79,40 @@ func main() {
 		}
 	}
 
+	// Log effective cache TTLs (overridable via CACHE_TTL_* env vars)
+	caching.LogTTLConfig()
+
+	// Load default indicator lookbacks (overridable via DEFAULT_*_LOOKBACK env vars)
+	lookbacks, err := indicatorsscreening.LoadLookbackDefaults()
+	if err != nil {
+		log.Fatalf("Failed to load indicator lookback defaults: %v", err)
+	}
+	log.Printf("📐 Default lookbacks: ADR %d, ATR %d, RSI %d, CCI %d, MFI %d, Williams %%R %d, Volume %d, Keltner %d/%d, Stochastic %d/%d",
+		lookbacks.ADR, lookbacks.ATR, lookbacks.RSI, lookbacks.CCI, lookbacks.MFI, lookbacks.WilliamsR, lookbacks.Volume,
+		lookbacks.KeltnerEMA, lookbacks.KeltnerATR, lookbacks.StochasticK, lookbacks.StochasticD)
+
+	// Monitor Redis connectivity so caching recovers (or falls back to memory) without a restart
+	var stopRedisMonitor func()
+	if interval := caching.GetRedisHealthCheckInterval(); interval > 0 {
+		stopRedisMonitor = caching.StartRedisHealthMonitor(interval)
+	}
+
 	// Run database migrations
-	if err := database.Migrate(&model.Screener{}, &model.Historical{}, &model.Watchlist{}, &model.WatchlistItem{}, &model.CompanyInfo{}, &model.FundamentalData{}, &model.MarketStatistics{}, &model.ScreenerResult{}); err != nil {
+	if err := database.Migrate(&model.Screener{}, &model.Historical{}, &model.Watchlist{}, &model.WatchlistItem{}, &model.Favorite{}, &model.CompanyInfo{}, &model.CompanyMetricsSnapshot{}, &model.FundamentalData{}, &model.FundamentalLineItem{}, &model.MarketStatistics{}, &model.ScreenerResult{}, &model.IngestionRun{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 	log.Println("Database migrations completed")
 
+	// Warm frequently requested list caches in the background so startup isn't blocked
+	if caching.GetRedisClient() != nil && caching.IsCacheWarmingEnabled() {
+		go func() {
+			log.Println("🔥 Warming Redis cache...")
+			if err := caching.NewCacheWarmer().WarmAll(); err != nil {
+				log.Printf("⚠️  Warning: Cache warming failed: %v", err)
+			}
+		}()
+	}
+
 	// Initialize Supabase client (optional, for reference)
 	if err := supabase.InitClient(); err != nil {
 		log.Printf("Warning: Failed to initialize Supabase client: %v", err)
@@ -84,7 +120,9 @@ func main() {
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
-		AppName: "Screener Backend",
+		AppName:      "Screener Backend",
+		BodyLimit:    routes.BodyLimit(),
+		ErrorHandler: routes.ErrorHandler,
 	})
 
 	// Middleware
@@ -168,6 +206,16 @@ func main() {
 		log.Println("Background persistence worker stopped")
 	}
 
+	// Stop periodic symbol cache refresh
+	if stopSymbolRefresh != nil {
+		stopSymbolRefresh()
+	}
+
+	// Stop Redis health monitor
+	if stopRedisMonitor != nil {
+		stopRedisMonitor()
+	}
+
 	// Close Redis connection
 	if err := caching.CloseRedis(); err != nil {
 		log.Printf("Error closing Redis connection: %v", err)
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"screener/backend/format"
+)
+
+// CompanyMetricsSnapshot is a dated copy of a symbol's key company-info metrics, parsed to numbers.
+// CompanyInfo is overwritten on every ingestion; snapshots keep one row per symbol per day so
+// valuation trends can be charted. Written only when company-info ingestion opts in.
+type CompanyMetricsSnapshot struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	Symbol     string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_company_metrics_symbol_date,priority:1" json:"symbol"`
+	Date       time.Time `gorm:"type:date;not null;uniqueIndex:idx_company_metrics_symbol_date,priority:2" json:"date"`
+	Price      *float64  `gorm:"type:double precision" json:"price,omitempty"`
+	PE         *float64  `gorm:"type:double precision" json:"pe,omitempty"`
+	MarketCap  *float64  `gorm:"type:double precision" json:"market_cap,omitempty"`
+	Beta       *float64  `gorm:"type:double precision" json:"beta,omitempty"`
+	YtdReturn  *float64  `gorm:"type:double precision" json:"ytd_return,omitempty"`  // Percent
+	YearReturn *float64  `gorm:"type:double precision" json:"year_return,omitempty"` // Percent
+	Volume     int64     `gorm:"type:bigint" json:"volume,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for the CompanyMetricsSnapshot model
+func (CompanyMetricsSnapshot) TableName() string {
+	return "company_metrics_snapshots"
+}
+
+// NewCompanyMetricsSnapshot parses the numeric metrics out of a company-info record for the given day.
+// Unparseable metrics (e.g. "N/A" or a missing PE) are left nil.
+func NewCompanyMetricsSnapshot(info *CompanyInfo, date time.Time) CompanyMetricsSnapshot {
+	return CompanyMetricsSnapshot{
+		Symbol:     info.Symbol,
+		Date:       time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Price:      parseSnapshotMetric(info.Price, format.ParsePrice),
+		PE:         parseSnapshotMetric(info.PE, format.ParsePrice),
+		MarketCap:  parseSnapshotMetric(info.MarketCap, format.ParseMarketCap),
+		Beta:       parseSnapshotMetric(info.Beta, format.ParsePrice),
+		YtdReturn:  parseSnapshotMetric(info.YtdReturn, format.ParsePercent),
+		YearReturn: parseSnapshotMetric(info.YearReturn, format.ParsePercent),
+		Volume:     info.Volume,
+	}
+}
+
+// parseSnapshotMetric parses a company-info metric string such as "182.5", "2.9T" or "+12.4%" with
+// the parser for its kind; only the returns are percents, so a stray "%" elsewhere is rejected
+func parseSnapshotMetric(value string, parse func(string) (float64, error)) *float64 {
+	number, err := parse(value)
+	if err != nil {
+		return nil
+	}
+	return &number
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCompanyMetricsSnapshot(t *testing.T) {
+	info := &CompanyInfo{
+		Symbol:     "AAPL",
+		Price:      "1,204.10",
+		PE:         "N/A",
+		MarketCap:  "2.91T",
+		Beta:       "1.25%",
+		YtdReturn:  "+12.4%",
+		YearReturn: "-5.06%",
+		Volume:     1_000_000,
+	}
+	snapshot := NewCompanyMetricsSnapshot(info, time.Date(2025, 11, 28, 15, 30, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		got  *float64
+		want *float64
+	}{
+		{"price", snapshot.Price, float64Ptr(1204.1)},
+		{"pe", snapshot.PE, nil},
+		{"market_cap", snapshot.MarketCap, float64Ptr(2.91e12)},
+		{"beta", snapshot.Beta, nil}, // Not a percent, so the "%" is rejected
+		{"ytd_return", snapshot.YtdReturn, float64Ptr(12.4)},
+		{"year_return", snapshot.YearReturn, float64Ptr(-5.06)},
+	}
+	for _, tt := range tests {
+		switch {
+		case tt.want == nil && tt.got != nil:
+			t.Errorf("%s = %v, want nil", tt.name, *tt.got)
+		case tt.want != nil && tt.got == nil:
+			t.Errorf("%s = nil, want %v", tt.name, *tt.want)
+		case tt.want != nil && *tt.got != *tt.want:
+			t.Errorf("%s = %v, want %v", tt.name, *tt.got, *tt.want)
+		}
+	}
+	if want := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC); !snapshot.Date.Equal(want) {
+		t.Errorf("date = %v, want %v", snapshot.Date, want)
+	}
+}
+
+// float64Ptr returns a pointer to v
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Favorite is a symbol a user has starred, independent of their watchlists
+// Each user can favorite a symbol at most once
+type Favorite struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_favorites_user_symbol,priority:1" json:"user_id"`
+	Symbol    string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_favorites_user_symbol,priority:2" json:"symbol"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID if not set
+func (f *Favorite) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == uuid.Nil {
+		f.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the Favorite model
+func (Favorite) TableName() string {
+	return "favorites"
+}
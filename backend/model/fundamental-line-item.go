@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"screener/backend/format"
+
+	"gorm.io/gorm"
+)
+
+// FundamentalLineItem is one numeric value from a financial statement (e.g. "Total Revenue" on
+// 2024-09-30), normalized out of FundamentalData.Statement so line items can be filtered in SQL.
+// FundamentalData keeps the full statement blob; line items are rebuilt whenever it is upserted.
+type FundamentalLineItem struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Symbol        string    `gorm:"type:varchar(20);not null;index:idx_line_item_statement,priority:1" json:"symbol"`
+	StatementType string    `gorm:"type:varchar(50);not null;index:idx_line_item_statement,priority:2;index:idx_line_item_breakdown,priority:1" json:"statement_type"`
+	Frequency     string    `gorm:"type:varchar(20);not null;index:idx_line_item_statement,priority:3;index:idx_line_item_breakdown,priority:2" json:"frequency"`
+	Date          string    `gorm:"type:varchar(20);not null;index:idx_line_item_breakdown,priority:4" json:"date"`
+	Breakdown     string    `gorm:"type:varchar(255);not null;index:idx_line_item_breakdown,priority:3" json:"breakdown"`
+	Value         float64   `gorm:"type:double precision;not null" json:"value"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the FundamentalLineItem model
+func (FundamentalLineItem) TableName() string {
+	return "fundamental_line_items"
+}
+
+// BuildFundamentalLineItems extracts the numeric line items from a statement JSON blob.
+// The blob maps row keys to objects holding a "Breakdown" label and date -> value strings;
+// non-numeric values (e.g. "*" or "N/A") are skipped.
+func BuildFundamentalLineItems(data *FundamentalData) ([]FundamentalLineItem, error) {
+	var rows map[string]map[string]interface{}
+	if err := json.Unmarshal([]byte(data.Statement), &rows); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal statement JSON: %w", err)
+	}
+
+	items := make([]FundamentalLineItem, 0)
+	for _, row := range rows {
+		breakdown, _ := row["Breakdown"].(string)
+		if breakdown == "" {
+			continue
+		}
+		for date, raw := range row {
+			if date == "Breakdown" {
+				continue
+			}
+			str, ok := raw.(string)
+			if !ok {
+				continue
+			}
+			value, err := ParseStatementNumber(str)
+			if err != nil {
+				continue
+			}
+			items = append(items, FundamentalLineItem{
+				Symbol:        data.Symbol,
+				StatementType: data.StatementType,
+				Frequency:     data.Frequency,
+				Date:          date,
+				Breakdown:     breakdown,
+				Value:         value,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// ParseStatementNumber parses a statement value such as "1,234.5", "(1,234.5)", "-12.3M" or "4.1B".
+// See format.ParseNumber; placeholders like "*", "-", "" and "N/A" return an error.
+func ParseStatementNumber(value string) (float64, error) {
+	return format.ParseNumber(value)
+}
+
+// ReplaceFundamentalLineItems rebuilds the line items for a statement's symbol/type/frequency
+// Run it in the same transaction as the FundamentalData upsert so the two never disagree
+func ReplaceFundamentalLineItems(tx *gorm.DB, data *FundamentalData) error {
+	items, err := BuildFundamentalLineItems(data)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Where("symbol = ? AND statement_type = ? AND frequency = ?", data.Symbol, data.StatementType, data.Frequency).
+		Delete(&FundamentalLineItem{}).Error; err != nil {
+		return fmt.Errorf("failed to delete fundamental line items: %w", err)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	if err := tx.CreateInBatches(items, 500).Error; err != nil {
+		return fmt.Errorf("failed to insert fundamental line items: %w", err)
+	}
+	return nil
+}
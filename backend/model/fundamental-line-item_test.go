@@ -0,0 +1,61 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseStatementNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  float64
+	}{
+		{"1,234", 1234},
+		{"1,234.5", 1234.5},
+		{"(1,234.5)", -1234.5},
+		{"-12.3", -12.3},
+		{"12.5K", 12.5e3},
+		{"-12.3M", -12.3e6},
+		{"4.1B", 4.1e9},
+		{"1.2T", 1.2e12},
+		{"(2.5B)", -2.5e9},
+		{"0", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseStatementNumber(tt.input)
+		if err != nil {
+			t.Errorf("ParseStatementNumber(%q) returned error: %v", tt.input, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-6*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("ParseStatementNumber(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, placeholder := range []string{"", " ", "*", "-", "N/A", "n/a", "--"} {
+		if _, err := ParseStatementNumber(placeholder); err == nil {
+			t.Errorf("ParseStatementNumber(%q) expected an error for a placeholder", placeholder)
+		}
+	}
+}
+
+func TestBuildFundamentalLineItemsSkipsPlaceholders(t *testing.T) {
+	data := &FundamentalData{
+		Symbol:        "AAPL",
+		StatementType: "income",
+		Frequency:     "annual",
+		Statement:     `{"0": {"Breakdown": "Total Revenue", "2024-09-30": "391,035,000", "2023-09-30": "*", "2022-09-30": "(1.5B)"}}`,
+	}
+
+	items, err := BuildFundamentalLineItems(data)
+	if err != nil {
+		t.Fatalf("BuildFundamentalLineItems returned error: %v", err)
+	}
+	values := make(map[string]float64, len(items))
+	for _, item := range items {
+		values[item.Date] = item.Value
+	}
+	if len(values) != 2 || values["2024-09-30"] != 391035000 || values["2022-09-30"] != -1.5e9 {
+		t.Errorf("line items = %v, want 2024-09-30=391035000 and 2022-09-30=-1.5e9 with the placeholder skipped", values)
+	}
+}
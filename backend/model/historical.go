@@ -5,15 +5,20 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// HistoricalUniqueIndex is the unique index on (symbol, epoch, range, interval) that
+// historical upserts conflict on. It is created by AutoMigrate from the tags below.
+const HistoricalUniqueIndex = "uniq_hist_symbol_epoch_range_interval"
+
 // Historical represents historical stock price data in the system
 type Historical struct {
-    ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-    Symbol    string         `gorm:"type:varchar(20);not null;index;uniqueIndex:uniq_hist_symbol_epoch_range_interval" json:"symbol"`
-    Epoch     int64          `gorm:"type:bigint;not null;index;uniqueIndex:uniq_hist_symbol_epoch_range_interval" json:"epoch"`
-    Range     string         `gorm:"type:varchar(10);not null;column:range;uniqueIndex:uniq_hist_symbol_epoch_range_interval" json:"range"`
-    Interval  string         `gorm:"type:varchar(10);not null;column:interval;uniqueIndex:uniq_hist_symbol_epoch_range_interval" json:"interval"`
+	ID        uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Symbol    string         `gorm:"type:varchar(20);not null;index;uniqueIndex:uniq_hist_symbol_epoch_range_interval,priority:1" json:"symbol"`
+	Epoch     int64          `gorm:"type:bigint;not null;index;uniqueIndex:uniq_hist_symbol_epoch_range_interval,priority:2" json:"epoch"`
+	Range     string         `gorm:"type:varchar(10);not null;column:range;uniqueIndex:uniq_hist_symbol_epoch_range_interval,priority:3" json:"range"`
+	Interval  string         `gorm:"type:varchar(10);not null;column:interval;uniqueIndex:uniq_hist_symbol_epoch_range_interval,priority:4" json:"interval"`
 	Open      float64        `gorm:"type:decimal(15,4);not null" json:"open"`
 	High      float64        `gorm:"type:decimal(15,4);not null" json:"high"`
 	Low       float64        `gorm:"type:decimal(15,4);not null" json:"low"`
@@ -37,3 +42,24 @@ func (h *Historical) BeforeCreate(tx *gorm.DB) error {
 func (Historical) TableName() string {
 	return "historical"
 }
+
+// HistoricalUpsertClause returns the ON CONFLICT clause for upserting bars against HistoricalUniqueIndex.
+// The index also covers soft-deleted rows, so a conflicting upsert clears deleted_at to revive the bar.
+// A bar without an adjusted close keeps the stored one rather than nulling it.
+func HistoricalUpsertClause() clause.OnConflict {
+	return clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "symbol"}, {Name: "epoch"}, {Name: "range"}, {Name: "interval"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"open":       gorm.Expr("excluded.open"),
+			"high":       gorm.Expr("excluded.high"),
+			"low":        gorm.Expr("excluded.low"),
+			"close":      gorm.Expr("excluded.close"),
+			"adj_close":  gorm.Expr("COALESCE(excluded.adj_close, historical.adj_close)"),
+			"volume":     gorm.Expr("excluded.volume"),
+			"updated_at": gorm.Expr("NOW()"),
+			"deleted_at": nil,
+		}),
+	}
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+// newMockDB returns a Postgres-dialect gorm.DB backed by sqlmock
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard, SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return db, mock
+}
+
+func TestHistoricalUpsertClauseTargetsTheUniqueIndex(t *testing.T) {
+	s, err := schema.Parse(&Historical{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse Historical schema: %v", err)
+	}
+
+	var index *schema.Index
+	for _, idx := range s.ParseIndexes() {
+		if idx.Name == HistoricalUniqueIndex {
+			index = idx
+		}
+	}
+	if index == nil || index.Class != "UNIQUE" {
+		t.Fatalf("Historical has no unique index %s", HistoricalUniqueIndex)
+	}
+
+	indexColumns := make([]string, 0, len(index.Fields))
+	for _, f := range index.Fields {
+		indexColumns = append(indexColumns, f.DBName)
+	}
+	clauseColumns := make([]string, 0)
+	for _, c := range HistoricalUpsertClause().Columns {
+		clauseColumns = append(clauseColumns, c.Name)
+	}
+	// ON CONFLICT only infers the index if the column sets match exactly
+	if strings.Join(indexColumns, ",") != strings.Join(clauseColumns, ",") {
+		t.Errorf("unique index columns %v, upsert conflict columns %v; want them equal", indexColumns, clauseColumns)
+	}
+}
+
+// historicalUpsertSQL is the statement HistoricalUpsertClause builds for a Historical insert. The
+// second insert of a bar updates the stored row in place; the row's id, created_at and
+// unique-index columns are kept.
+const historicalUpsertSQL = `INSERT INTO "historical" ("symbol","epoch","range","interval","open","high","low","close","adj_close","volume","created_at","updated_at","deleted_at","id") ` +
+	`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) ` +
+	`ON CONFLICT ("symbol","epoch","range","interval") DO UPDATE SET ` +
+	`"adj_close"=COALESCE(excluded.adj_close, historical.adj_close),"close"=excluded.close,"deleted_at"=$15,"high"=excluded.high,` +
+	`"low"=excluded.low,"open"=excluded.open,"updated_at"=NOW(),"volume"=excluded.volume RETURNING "id"`
+
+func TestHistoricalUpsertSameBarTwice(t *testing.T) {
+	db, mock := newMockDB(t)
+	adjClose := 101.25
+
+	// The same bar fetched twice: the second fetch revises the close and has no adjusted close
+	bars := []Historical{
+		{Symbol: "AAPL", Epoch: 1700000000, Range: "1y", Interval: "1d", Open: 100, High: 102, Low: 99, Close: 101, AdjClose: &adjClose, Volume: 1000},
+		{Symbol: "AAPL", Epoch: 1700000000, Range: "1y", Interval: "1d", Open: 100, High: 102.5, Low: 99, Close: 101.5, Volume: 1200},
+	}
+	storedID := uuid.New()
+	for _, bar := range bars {
+		var adj interface{}
+		if bar.AdjClose != nil {
+			adj = *bar.AdjClose
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(historicalUpsertSQL)).
+			WithArgs("AAPL", int64(1700000000), "1y", "1d", bar.Open, bar.High, bar.Low, bar.Close, adj, bar.Volume,
+				sqlmock.AnyArg(), sqlmock.AnyArg(), nil, sqlmock.AnyArg(), nil).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(storedID))
+	}
+
+	for i := range bars {
+		// Each insert is a fresh row value with a new ID, as ingestion produces on every fetch
+		if err := db.Clauses(HistoricalUpsertClause()).Create(&bars[i]).Error; err != nil {
+			t.Fatalf("upsert %d returned error: %v", i+1, err)
+		}
+		if bars[i].ID != storedID {
+			t.Errorf("upsert %d ID = %s, want the stored row's %s", i+1, bars[i].ID, storedID)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet sqlmock expectations: %v", err)
+	}
+}
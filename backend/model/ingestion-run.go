@@ -0,0 +1,77 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IngestionRun records one run of an ingestion job (historicals, company info, fundamentals,
+// watchlist prices, market aggregation) for the admin audit trail
+type IngestionRun struct {
+	ID                uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	JobID             string             `gorm:"type:varchar(100);index" json:"job_id"`
+	Type              string             `gorm:"type:varchar(50);not null;index:idx_ingestion_runs_type_started,priority:1" json:"type"`
+	Status            string             `gorm:"type:varchar(20);not null" json:"status"` // "running", "completed", "failed", "cancelled"
+	TriggeredBy       string             `gorm:"type:varchar(50)" json:"triggered_by"`
+	StartedAt         time.Time          `gorm:"not null;index:idx_ingestion_runs_type_started,priority:2,sort:desc" json:"started_at"`
+	FinishedAt        *time.Time         `json:"finished_at,omitempty"`
+	DurationMs        int64              `json:"duration_ms"`
+	SymbolsAttempted  int                `json:"symbols_attempted"`
+	Succeeded         int                `json:"succeeded"`
+	Failed            int                `json:"failed"`
+	Error             string             `gorm:"type:text" json:"error,omitempty"`       // Error that ended the run, if any
+	TopErrors         IngestionRunErrors `gorm:"type:jsonb" json:"top_errors,omitempty"` // Most frequent per-symbol errors
+	UpstreamRequests  int                `json:"upstream_requests"`                      // Upstream fetches, including cache hits
+	UpstreamCacheHits int                `json:"upstream_cache_hits"`                    // Fetches served from the upstream response cache
+	UpstreamFailovers int                `json:"upstream_failovers"`                     // Requests served by a fallback endpoint
+	UpstreamLatencyMs int64              `json:"upstream_latency_ms"`                    // Total time spent in upstream requests
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// TableName specifies the table name for the IngestionRun model
+func (IngestionRun) TableName() string {
+	return "ingestion_runs"
+}
+
+// IngestionRunError is a distinct error message seen during a run, with how often it occurred
+type IngestionRunError struct {
+	Message string   `json:"message"`
+	Count   int      `json:"count"`
+	Symbols []string `json:"symbols,omitempty"` // A sample of affected symbols
+}
+
+// IngestionRunErrors is stored as a jsonb array
+type IngestionRunErrors []IngestionRunError
+
+// Value implements driver.Valuer
+func (e IngestionRunErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner
+func (e *IngestionRunErrors) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for IngestionRunErrors: %T", value)
+	}
+	return json.Unmarshal(data, e)
+}
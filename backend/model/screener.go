@@ -18,7 +18,7 @@ type Screener struct {
 	Volume    int64          `gorm:"type:bigint;not null" json:"volume"`
 	Logo      string         `gorm:"type:text" json:"logo,omitempty"`
 	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	UpdatedAt time.Time      `gorm:"index" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
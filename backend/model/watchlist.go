@@ -42,6 +42,9 @@ type WatchlistItem struct {
 	Change          *float64       `gorm:"type:decimal(15,4)" json:"change,omitempty"`
 	PercentChange   string         `gorm:"type:varchar(20)" json:"percentChange,omitempty"`
 	Logo            string         `gorm:"type:text" json:"logo,omitempty"`
+	Quantity        *float64       `gorm:"type:decimal(20,6)" json:"quantity,omitempty"`  // Optional position size
+	CostBasis       *float64       `gorm:"type:decimal(15,4)" json:"costBasis,omitempty"` // Optional per-share cost
+	UnrealizedPnL   *float64       `gorm:"-" json:"unrealizedPnl,omitempty"`              // Computed: (price - costBasis) * quantity
 	Starred         bool           `gorm:"type:boolean;default:false;index:idx_watchlist_items_starred" json:"starred"`
 	CreatedAt       time.Time      `json:"created_at"`
 	UpdatedAt       time.Time      `json:"updated_at"`
@@ -56,6 +59,23 @@ func (w *WatchlistItem) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// AfterFind hook to compute unrealized P&L for loaded items
+func (w *WatchlistItem) AfterFind(tx *gorm.DB) error {
+	w.ComputeUnrealizedPnL()
+	return nil
+}
+
+// ComputeUnrealizedPnL sets UnrealizedPnL from price, cost basis and quantity
+// It stays nil unless the item has all three, so plain watchlist items are unaffected
+func (w *WatchlistItem) ComputeUnrealizedPnL() {
+	w.UnrealizedPnL = nil
+	if w.Price == nil || w.CostBasis == nil || w.Quantity == nil {
+		return
+	}
+	pnl := (*w.Price - *w.CostBasis) * *w.Quantity
+	w.UnrealizedPnL = &pnl
+}
+
 // TableName specifies the table name for the WatchlistItem model
 func (WatchlistItem) TableName() string {
 	return "watchlist_items"
@@ -0,0 +1,60 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultBodyLimit caps request bodies at 4MB unless HTTP_BODY_LIMIT_BYTES overrides it
+const defaultBodyLimit = 4 * 1024 * 1024
+
+// BodyLimit returns the maximum request body size in bytes for the Fiber app config
+// (HTTP_BODY_LIMIT_BYTES, default 4MB). Larger bodies are rejected with 413 before parsing.
+func BodyLimit() int {
+	if value := os.Getenv("HTTP_BODY_LIMIT_BYTES"); value != "" {
+		if v, err := strconv.Atoi(value); err == nil && v > 0 {
+			return v
+		}
+		log.Printf("Warning: invalid HTTP_BODY_LIMIT_BYTES %q, using %d", value, defaultBodyLimit)
+	}
+	return defaultBodyLimit
+}
+
+// ErrorHandler renders oversized bodies in the API's error envelope; other framework
+// errors keep Fiber's default handling
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusRequestEntityTooLarge {
+		return payloadTooLarge(c, "Request body exceeds the size limit")
+	}
+	return fiber.DefaultErrorHandler(c, err)
+}
+
+// payloadTooLarge writes a 413 response in the error envelope
+func payloadTooLarge(c *fiber.Ctx, message string) error {
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+		"success": false,
+		"error":   "Payload Too Large",
+		"message": message,
+	})
+}
+
+// decodeStrictJSON decodes the JSON request body into dest, rejecting unknown fields and
+// trailing data so typos in write payloads fail loudly instead of being silently dropped
+func decodeStrictJSON(c *fiber.Ctx, dest interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dest); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after JSON body")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newBodyLimitApp returns an app configured like main.go, with a small body limit and an
+// endpoint that echoes a strictly decoded payload
+func newBodyLimitApp(t *testing.T, limit string) *fiber.App {
+	t.Helper()
+	t.Setenv("HTTP_BODY_LIMIT_BYTES", limit)
+	app := fiber.New(fiber.Config{BodyLimit: BodyLimit(), ErrorHandler: ErrorHandler, DisableStartupMessage: true})
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		var payload struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := decodeStrictJSON(c, &payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"success": false, "error": "Bad Request", "message": err.Error()})
+		}
+		return c.JSON(fiber.Map{"success": true, "data": payload})
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	return app
+}
+
+func TestOversizedBodyUsesTheErrorEnvelope(t *testing.T) {
+	app := newBodyLimitApp(t, "64")
+	// app.Test surfaces fasthttp's body-size error instead of the response, so serve for real
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	resp, err := http.Post("http://"+ln.Addr().String()+"/echo", "application/json",
+		strings.NewReader(`{"symbol":"`+strings.Repeat("A", 100)+`"}`))
+	if err != nil {
+		t.Fatalf("POST /echo: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", resp.StatusCode)
+	}
+	var body struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("413 body is not the JSON error envelope: %v", err)
+	}
+	if body.Success || body.Error != "Payload Too Large" || body.Message == "" {
+		t.Errorf("413 body = %+v, want success=false, error=Payload Too Large and a message", body)
+	}
+}
+
+func TestBodyWithinLimitIsDecoded(t *testing.T) {
+	app := newBodyLimitApp(t, "64")
+
+	tests := []struct {
+		body       string
+		wantStatus int
+	}{
+		{`{"symbol":"AAPL"}`, fiber.StatusOK},
+		{`{"symbl":"AAPL"}`, fiber.StatusBadRequest},          // Unknown field
+		{`{"symbol":"AAPL"} {"x":1}`, fiber.StatusBadRequest}, // Trailing data
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("POST", "/echo", strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("POST /echo %s: %v", tt.body, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.wantStatus {
+			t.Errorf("POST /echo %s: status = %d, want %d", tt.body, resp.StatusCode, tt.wantStatus)
+		}
+	}
+}
+
+func TestErrorHandlerKeepsFiberDefaultsForOtherErrors(t *testing.T) {
+	app := newBodyLimitApp(t, "64")
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+	if err != nil {
+		t.Fatalf("GET /missing: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404 from Fiber's default handler", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %q, want Fiber's plain-text default for non-413 errors", ct)
+	}
+}
+
+func TestBodyLimitFromEnv(t *testing.T) {
+	for value, want := range map[string]int{"": defaultBodyLimit, "1024": 1024, "0": defaultBodyLimit, "-5": defaultBodyLimit, "4MB": defaultBodyLimit} {
+		t.Setenv("HTTP_BODY_LIMIT_BYTES", value)
+		if got := BodyLimit(); got != want {
+			t.Errorf("HTTP_BODY_LIMIT_BYTES=%q gives %d, want %d", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// newCompressMiddleware creates the response compression middleware for the API groups
+// Encoding (gzip/deflate/brotli) is negotiated from the client's Accept-Encoding header.
+// Bodies under 200 bytes and responses that already set Content-Encoding are sent as is.
+// The level is configured via COMPRESS_LEVEL: disabled, default, best-speed, best-compression
+func newCompressMiddleware() fiber.Handler {
+	return compress.New(compress.Config{
+		Level: parseCompressLevel(os.Getenv("COMPRESS_LEVEL")),
+	})
+}
+
+// parseCompressLevel maps a COMPRESS_LEVEL value to a compression level (default: default)
+func parseCompressLevel(value string) compress.Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "disabled", "off", "-1":
+		return compress.LevelDisabled
+	case "best-speed", "speed", "1":
+		return compress.LevelBestSpeed
+	case "best-compression", "best", "2":
+		return compress.LevelBestCompression
+	default:
+		return compress.LevelDefault
+	}
+}
@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
+)
+
+// newCompressApp serves a large JSON body, a small one and an already-gzipped one behind the middleware
+func newCompressApp(t *testing.T, level string) (*fiber.App, string) {
+	t.Helper()
+	t.Setenv("COMPRESS_LEVEL", level)
+	large := `{"symbols":["` + strings.Repeat("AAPL", 100) + `"]}`
+
+	app := fiber.New()
+	app.Use(newCompressMiddleware())
+	app.Get("/large", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.SendString(large)
+	})
+	app.Get("/small", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true})
+	})
+	app.Get("/encoded", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		c.Set(fiber.HeaderContentEncoding, "gzip")
+		return c.Send(gzipBytes(t, large))
+	})
+	return app, large
+}
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		t.Fatalf("failed to gzip: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	app, large := newCompressApp(t, "")
+
+	tests := []struct {
+		path           string
+		acceptEncoding string
+		wantEncoding   string
+		wantVary       bool
+	}{
+		{"/large", "gzip", "gzip", true},
+		{"/large", "br", "br", true},
+		{"/large", "gzip, deflate, br", "br", true}, // Brotli preferred when offered
+		{"/large", "deflate", "deflate", true},
+		{"/large", "", "", false},
+		{"/large", "identity", "", false},
+		{"/small", "gzip", "", false}, // Under the 200-byte minimum
+		{"/encoded", "br", "gzip", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+		if tt.acceptEncoding != "" {
+			req.Header.Set(fiber.HeaderAcceptEncoding, tt.acceptEncoding)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("GET %s returned error: %v", tt.path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if got := resp.Header.Get(fiber.HeaderContentEncoding); got != tt.wantEncoding {
+			t.Errorf("GET %s (Accept-Encoding %q): Content-Encoding = %q, want %q", tt.path, tt.acceptEncoding, got, tt.wantEncoding)
+		}
+		if got := strings.Contains(resp.Header.Get(fiber.HeaderVary), fiber.HeaderAcceptEncoding); got != tt.wantVary {
+			t.Errorf("GET %s (Accept-Encoding %q): Vary = %q, want Accept-Encoding listed: %v", tt.path, tt.acceptEncoding, resp.Header.Get(fiber.HeaderVary), tt.wantVary)
+		}
+		if tt.path == "/large" && tt.wantEncoding == "gzip" {
+			zr, err := gzip.NewReader(bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("GET /large: body is not gzip: %v", err)
+			}
+			if plain, _ := io.ReadAll(zr); string(plain) != large {
+				t.Errorf("GET /large: gunzipped body differs from the handler's")
+			}
+		}
+		if tt.path == "/encoded" && !bytes.Equal(body, gzipBytes(t, large)) {
+			t.Error("GET /encoded: an already-encoded body was re-encoded")
+		}
+	}
+}
+
+func TestCompressMiddlewareDisabled(t *testing.T) {
+	app, _ := newCompressApp(t, "disabled")
+
+	req := httptest.NewRequest(fiber.MethodGet, "/large", nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("GET /large returned error: %v", err)
+	}
+	resp.Body.Close()
+	if got := resp.Header.Get(fiber.HeaderContentEncoding); got != "" {
+		t.Errorf("Content-Encoding = %q with COMPRESS_LEVEL=disabled, want none", got)
+	}
+}
+
+func TestParseCompressLevel(t *testing.T) {
+	tests := map[string]compress.Level{
+		"":                 compress.LevelDefault,
+		"default":          compress.LevelDefault,
+		"bogus":            compress.LevelDefault,
+		"disabled":         compress.LevelDisabled,
+		"OFF":              compress.LevelDisabled,
+		"-1":               compress.LevelDisabled,
+		"best-speed":       compress.LevelBestSpeed,
+		" 1 ":              compress.LevelBestSpeed,
+		"best-compression": compress.LevelBestCompression,
+		"best":             compress.LevelBestCompression,
+	}
+	for value, want := range tests {
+		if got := parseCompressLevel(value); got != want {
+			t.Errorf("parseCompressLevel(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
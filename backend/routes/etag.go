@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// etagMatches reports whether the request's If-None-Match header matches the given ETag
+// Uses weak comparison, so W/"x" and "x" are considered equal
+func etagMatches(c *fiber.Ctx, etag string) bool {
+	ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch)
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
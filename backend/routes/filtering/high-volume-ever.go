@@ -9,9 +9,9 @@ import (
 )
 
 // SetupHighVolumeEverRoutes registers all high-volume-ever related routes
-func SetupHighVolumeEverRoutes(router fiber.Router) {
-	// Admin endpoint to save high volume ever results (call via cron daily)
-	router.Post("/admin/screener/save-high-volume-ever", func(c *fiber.Ctx) error {
+func SetupHighVolumeEverRoutes(router fiber.Router, admin fiber.Router) {
+	// Admin endpoint (admin-only) to save high volume ever results (call via cron daily)
+	admin.Post("/screener/save-high-volume-ever", func(c *fiber.Ctx) error {
 		highVolumeEverService := filteringservice.NewHighVolumeEverService()
 		if err := highVolumeEverService.SaveHighVolumeEverResults(); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
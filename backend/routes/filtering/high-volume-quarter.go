@@ -9,9 +9,9 @@ import (
 )
 
 // SetupHighVolumeQuarterRoutes registers all high-volume-quarter related routes
-func SetupHighVolumeQuarterRoutes(router fiber.Router) {
-	// Admin endpoint to save high volume quarter results (call via cron daily)
-	router.Post("/admin/screener/save-high-volume-quarter", func(c *fiber.Ctx) error {
+func SetupHighVolumeQuarterRoutes(router fiber.Router, admin fiber.Router) {
+	// Admin endpoint (admin-only) to save high volume quarter results (call via cron daily)
+	admin.Post("/screener/save-high-volume-quarter", func(c *fiber.Ctx) error {
 		highVolumeQuarterService := filteringservice.NewHighVolumeQuarterService()
 		if err := highVolumeQuarterService.SaveHighVolumeQuarterResults(); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
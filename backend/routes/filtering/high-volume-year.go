@@ -9,9 +9,9 @@ import (
 )
 
 // SetupHighVolumeYearRoutes registers all high-volume-year related routes
-func SetupHighVolumeYearRoutes(router fiber.Router) {
-	// Admin endpoint to save high volume year results (call via cron daily)
-	router.Post("/admin/screener/save-high-volume-year", func(c *fiber.Ctx) error {
+func SetupHighVolumeYearRoutes(router fiber.Router, admin fiber.Router) {
+	// Admin endpoint (admin-only) to save high volume year results (call via cron daily)
+	admin.Post("/screener/save-high-volume-year", func(c *fiber.Ctx) error {
 		highVolumeYearService := filteringservice.NewHighVolumeYearService()
 		if err := highVolumeYearService.SaveHighVolumeYearResults(); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
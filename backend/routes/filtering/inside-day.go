@@ -9,9 +9,9 @@ import (
 )
 
 // SetupInsideDayRoutes registers all inside-day related routes
-func SetupInsideDayRoutes(router fiber.Router) {
-	// Admin endpoint to save inside day results (call via cron daily)
-	router.Post("/admin/screener/save-inside-day", func(c *fiber.Ctx) error {
+func SetupInsideDayRoutes(router fiber.Router, admin fiber.Router) {
+	// Admin endpoint (admin-only) to save inside day results (call via cron daily)
+	admin.Post("/screener/save-inside-day", func(c *fiber.Ctx) error {
 		insideDayService := filteringservice.NewInsideDayService()
 		if err := insideDayService.SaveInsideDayResults(); err != nil {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"screener/backend/supabase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ingestionTrigger describes who called an admin ingestion endpoint, for the ingestion run history
+// Cron jobs authenticate with the service_role key; admin users are recorded by user ID
+func ingestionTrigger(c *fiber.Ctx) string {
+	claims, ok := c.Locals("claims").(*supabase.UserClaims)
+	if !ok {
+		return "admin"
+	}
+	if claims.Role == "service_role" {
+		return "service_role"
+	}
+	return "admin:" + claims.UserID
+}
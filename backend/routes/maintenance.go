@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"strconv"
+	"strings"
+
+	"screener/backend/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readOnlyPostPaths are protected POST routes that only query data, so they stay available
+// in maintenance mode. Keys are lowercase without a trailing slash (see isReadOnlyPost).
+var readOnlyPostPaths = map[string]bool{
+	"/api/protected/screener/filter":  true,
+	"/api/protected/screener/symbols": true,
+}
+
+// isReadOnlyPost reports whether path is one of readOnlyPostPaths, matched the way the router
+// matches it: trailing slashes are ignored unless StrictRouting and case unless CaseSensitive
+func isReadOnlyPost(path string, config fiber.Config) bool {
+	if !config.StrictRouting && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	if !config.CaseSensitive {
+		path = strings.ToLower(path)
+	}
+	return readOnlyPostPaths[path]
+}
+
+// maintenanceMiddleware rejects mutating requests (POST, PUT, PATCH, DELETE) on the protected
+// group with 503 and a Retry-After header while maintenance mode is enabled. This covers the
+// watchlist and watchlist item mutations, adding and removing favorites, and the historical
+// create/update routes; GET routes, the read-only POST queries above, public routes and
+// /api/admin are never affected.
+func maintenanceMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		default:
+			return c.Next()
+		}
+		if isReadOnlyPost(c.Path(), c.App().Config()) {
+			return c.Next()
+		}
+
+		state := service.GetMaintenanceState()
+		if !state.Enabled {
+			return c.Next()
+		}
+
+		message := state.Message
+		if message == "" {
+			message = "The API is in maintenance mode; writes are temporarily disabled"
+		}
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(state.RetryAfterSeconds))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"success": false,
+			"error":   "Service Unavailable",
+			"message": message,
+		})
+	}
+}
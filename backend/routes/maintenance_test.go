@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIsReadOnlyPost(t *testing.T) {
+	tests := []struct {
+		path   string
+		config fiber.Config
+		want   bool
+	}{
+		{"/api/protected/screener/filter", fiber.Config{}, true},
+		{"/api/protected/screener/filter/", fiber.Config{}, true},
+		{"/api/protected/screener/filter//", fiber.Config{}, true},
+		{"/api/protected/Screener/Symbols", fiber.Config{}, true},
+		{"/api/protected/screener/filter/", fiber.Config{StrictRouting: true}, false},
+		{"/api/protected/Screener/Filter", fiber.Config{CaseSensitive: true}, false},
+		{"/api/protected/screener/filter", fiber.Config{StrictRouting: true, CaseSensitive: true}, true},
+		{"/api/protected/watchlists", fiber.Config{}, false},
+		{"/", fiber.Config{}, false},
+	}
+	for _, tt := range tests {
+		if got := isReadOnlyPost(tt.path, tt.config); got != tt.want {
+			t.Errorf("isReadOnlyPost(%q, strict=%v, case=%v) = %v, want %v", tt.path, tt.config.StrictRouting, tt.config.CaseSensitive, got, tt.want)
+		}
+	}
+}
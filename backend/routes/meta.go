@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"screener/backend/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// freshnessMeta builds the "meta" envelope describing how current the response data is
+// as_of is null when the data carries no timestamp (e.g. an empty list)
+func freshnessMeta(asOf time.Time) fiber.Map {
+	meta := fiber.Map{"as_of": nil}
+	if !asOf.IsZero() {
+		meta["as_of"] = asOf.UTC().Format(time.RFC3339)
+	}
+	return meta
+}
+
+// latestScreenerUpdate returns the most recent updated_at across screener rows
+func latestScreenerUpdate(screeners []model.Screener) time.Time {
+	var latest time.Time
+	for _, s := range screeners {
+		if s.UpdatedAt.After(latest) {
+			latest = s.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// latestCompanyInfoUpdate returns the most recent updated_at across company info rows
+func latestCompanyInfoUpdate(companies []model.CompanyInfo) time.Time {
+	var latest time.Time
+	for _, c := range companies {
+		if c.UpdatedAt.After(latest) {
+			latest = c.UpdatedAt
+		}
+	}
+	return latest
+}
+
+// latestBarTime returns the time of the most recent bar
+func latestBarTime(rows []model.Historical) time.Time {
+	var latest int64
+	for _, r := range rows {
+		if r.Epoch > latest {
+			latest = r.Epoch
+		}
+	}
+	if latest == 0 {
+		return time.Time{}
+	}
+	return time.Unix(latest, 0)
+}
@@ -0,0 +1,850 @@
+package routes
+
+// routeDocs annotates the registered routes for the OpenAPI document served at /openapi.json.
+// Keys are "METHOD path" with the full path as registered; path parameters are read from the
+// path itself. Routes missing here are still listed, with a generic summary, so add an entry
+// alongside each new handler.
+var routeDocs = map[string]routeDoc{
+	"POST /api/admin/screener/save-high-volume-ever": {
+		Summary: "Admin endpoint to save high volume ever results (call via cron daily)",
+	},
+	"GET /api/high-volume-ever": {
+		Summary: "Public endpoint to get current high volume ever symbols (real-time calculation)",
+	},
+	"POST /api/admin/screener/save-high-volume-quarter": {
+		Summary: "Admin endpoint to save high volume quarter results (call via cron daily)",
+	},
+	"GET /api/high-volume-quarter": {
+		Summary: "Public endpoint to get current high volume quarter symbols (real-time calculation)",
+	},
+	"POST /api/admin/screener/save-high-volume-year": {
+		Summary: "Admin endpoint to save high volume year results (call via cron daily)",
+	},
+	"GET /api/high-volume-year": {
+		Summary: "Public endpoint to get current high volume year symbols (real-time calculation)",
+	},
+	"POST /api/admin/screener/save-inside-day": {
+		Summary: "Admin endpoint to save inside day results (call via cron daily)",
+	},
+	"GET /api/inside-day": {
+		Summary: "Public endpoint to get current inside day symbols (real-time calculation)",
+	},
+	"GET /": {
+		Summary: "API index",
+	},
+	"GET /health": {
+		Summary: "Convenience health check at root level (redirects to /api/health)",
+	},
+	"GET /api/health": {
+		Summary: "Health check endpoint",
+	},
+	"GET /api/health/deep": {
+		Summary: "Deep health check: verifies database and Redis connectivity",
+	},
+	"GET /api/market-status": {
+		Summary:     "Current market session: pre_market, open, after_hours or closed",
+		Description: "Computed in the market timezone (America/New_York); weekends and exchange holidays are closed, early-close days end at 13:00",
+	},
+	"POST /api/admin/ingest/historicals": {
+		Summary:     "Admin ingestion endpoint: trigger screener+historicals fetch for all symbols",
+		Description: "Ingestion endpoints accept ?no_cache=true to skip the upstream response cache",
+		Query: []queryParam{
+			{Name: "concurrency", Type: "integer", Default: "8"},
+			{Name: "no_cache", Type: "boolean"},
+			{Name: "regular_hours", Type: "boolean", Description: "Aggregate screener daily OHLCV from 09:30-16:00 ET bars only (13:00 on early-close days)"},
+		},
+	},
+	"POST /api/admin/ingest/historicals/:symbol": {
+		Summary: "Single-symbol historicals refresh: re-run ingestion for one symbol",
+		Query: []queryParam{
+			{Name: "regular_hours", Type: "boolean", Description: "Aggregate screener daily OHLCV from 09:30-16:00 ET bars only (13:00 on early-close days)"},
+		},
+	},
+	"GET /api/admin/ingest/runs": {
+		Summary:     "Ingestion run history: newest first, optional ?type= filter",
+		Description: "(historicals, company_info, fundamental_data, watchlist_prices, market_aggregation)",
+		Query: []queryParam{
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer"},
+			{Name: "type", Type: "string"},
+		},
+	},
+	"POST /api/admin/watchlist/update-prices": {
+		Summary: "Watchlist price update endpoint: trigger price updates for all watchlist items",
+	},
+	"POST /api/admin/ingest/company-data": {
+		Summary: "Company info ingestion endpoint: trigger company info fetch for all screener symbols",
+		Query: []queryParam{
+			{Name: "no_cache", Type: "boolean"},
+			{Name: "snapshot", Type: "boolean"},
+		},
+	},
+	"POST /api/admin/ingest/company-data/:symbol": {
+		Summary: "Single-symbol company info refresh: re-fetch and upsert one symbol's company info",
+	},
+	"POST /api/admin/ingest/fundamental-data": {
+		Summary: "Fundamental data ingestion endpoint: trigger fundamental data fetch for all screener symbols",
+		Query: []queryParam{
+			{Name: "no_cache", Type: "boolean"},
+		},
+	},
+	"GET /api/admin/stats": {
+		Summary:     "Consolidated operations view: symbol count, latest run per ingestion type, cache stats, table rows and market aggregator state",
+		Description: "Table rows are planner estimates. Sections that fail to load are empty and listed under errors",
+	},
+	"POST /api/admin/market-statistics/aggregate": {
+		Summary:     "Market statistics aggregation endpoint: trigger market aggregation (call every 5 minutes via external cron)",
+		Description: "Skipped (200 with skipped=true and the reason) outside the regular session and after-hours, including weekends and holidays",
+		Query: []queryParam{
+			{Name: "force", Type: "boolean", Description: "Run even outside market hours"},
+			{Name: "mode", Type: "string", Default: "simple", Description: "simple or detailed. Detailed quotes also refresh company info (sector, market cap, year high/low) in the same pass, at several times the upstream payload and latency of simple quotes"},
+		},
+	},
+	"POST /api/admin/market-statistics/store-eod": {
+		Summary: "Market statistics end-of-day storage endpoint: trigger end-of-day storage (call at market close via external cron)",
+	},
+	"POST /api/admin/market-statistics/reset": {
+		Summary: "Market statistics reset endpoint: clear today's in-memory aggregation counts",
+	},
+	"POST /api/admin/market-statistics/seed": {
+		Summary: "Market statistics seed endpoint: load today's stored stats back into the in-memory aggregator",
+	},
+	"POST /api/admin/market-statistics/backfill": {
+		Summary: "Market statistics backfill endpoint: rebuild past daily breadth from stored daily bars",
+		Query: []queryParam{
+			{Name: "from", Type: "string"},
+			{Name: "to", Type: "string"},
+		},
+	},
+	"POST /api/admin/historical/dedupe": {
+		Summary:     "Historical dedupe endpoint: remove duplicate (symbol, epoch, range, interval) bars",
+		Description: "keeping the most recently updated row, and ensure the unique index exists",
+	},
+	"POST /api/admin/historical/prune": {
+		Summary:     "Historical prune endpoint: hard-delete bars older than their retention",
+		Description: "Intraday intervals have default policies (HISTORICAL_RETENTION overrides them); daily bars are only pruned with an explicit older_than",
+		Query: []queryParam{
+			{Name: "interval", Type: "string", Description: "Prune one interval (e.g. 30m); all intervals with a policy when omitted"},
+			{Name: "older_than", Type: "string", Description: "Age like 90d or 720h; requires interval"},
+		},
+	},
+	"GET /api/admin/reconciliation/close": {
+		Summary:     "Close reconciliation report: symbols whose 1m-aggregated close diverged from",
+		Description: "the upstream's reported daily close (requires RECONCILE_SCREENER_CLOSE)",
+	},
+	"GET /api/admin/maintenance": {
+		Summary: "Maintenance mode: when enabled, mutating protected routes return 503",
+	},
+	"PUT /api/admin/maintenance": {
+		Summary: "Enable or disable maintenance mode",
+		Body:    true,
+	},
+	"GET /api/admin/schema-version": {
+		Summary: "Schema version endpoint: current version and the status of each versioned migration",
+	},
+	"POST /api/admin/cache/persist": {
+		Summary: "Manual persistence trigger",
+	},
+	"POST /api/admin/cache/symbols/refresh": {
+		Summary: "Refresh symbols cache",
+	},
+	"GET /api/admin/cache/stats": {
+		Summary: "Cache statistics",
+	},
+	"GET /api/market-statistics": {
+		Summary: "Market statistics historical data endpoint: get historical market statistics for charting",
+		Query: []queryParam{
+			{Name: "startDate", Type: "string"},
+			{Name: "endDate", Type: "string"},
+		},
+	},
+	"GET /api/market-statistics/current": {
+		Summary: "Market statistics current day endpoint: get today's real-time aggregated stats",
+	},
+	"GET /api/market-statistics/live": {
+		Summary:     "Market statistics for frontend polling endpoint: returns advances, decliners, unchanged",
+		Description: "Frontend should poll this endpoint every 5 minutes to get real-time market statistics",
+	},
+	"GET /api/market-statistics/breadth": {
+		Summary:     "Market breadth comparison endpoint: whole-market vs sector or market-cap bucket breadth",
+		Description: "Query: group_by=sector|market_cap (default: sector), value=Technology (optional, single group)",
+		Query: []queryParam{
+			{Name: "group_by", Type: "string"},
+			{Name: "value", Type: "string"},
+		},
+	},
+	"GET /api/screener-results": {
+		Summary: "Get screener results with time period filtering",
+		Query: []queryParam{
+			{Name: "type", Type: "string", Required: true},
+			{Name: "period", Type: "string", Default: "all"},
+		},
+	},
+	"GET /api/adr-screen": {
+		Summary:     "ADR screening - filter stocks by ADR% with configurable lookback",
+		Description: "Optional min_price / min_dollar_volume exclude illiquid names using the latest bar (both off by default)",
+		Query: []queryParam{
+			{Name: "range", Type: "string"},
+			{Name: "interval", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "adr"},
+			{Name: "min_adr", Type: "number"},
+			{Name: "max_adr", Type: "number"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "explain", Type: "boolean", Description: "Also return each matching symbol's ADR%"},
+			{Name: "sort", Type: "string", Description: "Sort by computed value: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/atr-screen": {
+		Summary:     "ATR screening - filter stocks by ATR% with configurable lookback",
+		Description: "Optional min_price / min_dollar_volume exclude illiquid names using the latest bar (both off by default)",
+		Query: []queryParam{
+			{Name: "range", Type: "string"},
+			{Name: "interval", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "atr"},
+			{Name: "min_atr", Type: "number"},
+			{Name: "max_atr", Type: "number"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "explain", Type: "boolean", Description: "Also return each matching symbol's ATR%"},
+			{Name: "sort", Type: "string", Description: "Sort by computed value: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/adr": {
+		Summary: "Get ADR% for a specific stock",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "adr"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/adr-dollars": {
+		Summary:     "Get absolute ADR in dollars for a specific stock: /adr-dollars?symbol=AAPL&range=1y&interval=1d&lookback=14",
+		Description: "Returns SMA(high-low, lookback) in dollars alongside ADR% for convenience",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "adr"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/indicator/:name": {
+		Summary:     "Generic registry-backed indicator for a specific stock: /indicator/adr?symbol=AAPL&range=1y&interval=1d",
+		Description: "Lookback defaults to the indicator's own default",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/indicator/:name/screen": {
+		Summary:     "Generic registry-backed indicator screening: /indicator/atr/screen?range=1y&interval=1d&min=2&max=5",
+		Description: "Add async=true to run it as a background job (see /screen-jobs/:id)",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer"},
+			{Name: "min", Type: "number"},
+			{Name: "max", Type: "number"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+			{Name: "async", Type: "boolean"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "sort", Type: "string", Description: "Sort by computed value: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/screen-jobs/:id": {
+		Summary: "Get the status (and results, once completed) of an async screen job",
+	},
+	"POST /api/backtest": {
+		Summary:     "Backtest a threshold entry rule for a registered indicator",
+		Description: "Body: {\"symbols\": [\"AAPL\"], \"indicator\": \"rsi\", \"condition\": \"below\", \"threshold\": 30, \"holding_period\": 5} range/interval default to 10y/1d; lookback defaults to the indicator's default",
+		Body:        true,
+	},
+	"GET /api/indicators": {
+		Summary:     "Get a full indicator snapshot for a specific stock",
+		Description: "MA lookback defaults to 50 bars",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "atr_lookback", Type: "integer", Lookback: "atr"},
+			{Name: "adr_lookback", Type: "integer", Lookback: "adr"},
+			{Name: "volume_sma_lookback", Type: "integer", Lookback: "volume"},
+			{Name: "ma_lookback", Type: "integer", Default: "50"},
+		},
+	},
+	"POST /api/indicators/batch": {
+		Summary:     "Get indicator snapshots for multiple stocks sharing range/interval/lookbacks",
+		Description: "Symbols without data are reported under \"skipped\" instead of failing the request",
+		Body:        true,
+	},
+	"GET /api/atr": {
+		Summary: "Get ATR% for a specific stock",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "atr"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/keltner-screen": {
+		Summary:     "Keltner Channel screening: symbols closing above the upper or below the lower band",
+		Description: "Results are in symbol order: sort and limit are not supported because each result carries a close and three bands rather than one computed value",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "position", Type: "string"},
+			{Name: "ema_lookback", Type: "integer", Lookback: "keltner_ema"},
+			{Name: "atr_lookback", Type: "integer", Lookback: "keltner_atr"},
+			{Name: "multiplier", Type: "number", Default: "2"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+		},
+	},
+	"GET /api/stochastic-screen": {
+		Summary:     "Stochastic oscillator screening: overbought (%K > threshold, default 80)",
+		Description: "or oversold (%K < threshold, default 20) symbols",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "condition", Type: "string"},
+			{Name: "k_lookback", Type: "integer", Lookback: "stochastic_k"},
+			{Name: "d_smoothing", Type: "integer", Lookback: "stochastic_d"},
+			{Name: "threshold", Type: "number"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "sort", Type: "string", Description: "Sort by %K: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/cci-screen": {
+		Summary: "Commodity Channel Index screening",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "cci"},
+			{Name: "min", Type: "number"},
+			{Name: "max", Type: "number"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "sort", Type: "string", Description: "Sort by CCI: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/mfi-screen": {
+		Summary:     "Money Flow Index screening: overbought (MFI > threshold, default 80)",
+		Description: "or oversold (MFI < threshold, default 20) symbols",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "condition", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "mfi"},
+			{Name: "threshold", Type: "number"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "sort", Type: "string", Description: "Sort by MFI: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/williams-r-screen": {
+		Summary:     "Williams %R screening: overbought (%R > threshold, default -20)",
+		Description: "or oversold (%R < threshold, default -80) symbols",
+		Query: []queryParam{
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "condition", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "williams_r"},
+			{Name: "threshold", Type: "number"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "sort", Type: "string", Description: "Sort by %R: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/atr-stop": {
+		Summary:     "Get an ATR-based trailing stop for a specific stock",
+		Description: "Query: side=long|short (default: long), multiplier (default: 3)",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "atr"},
+			{Name: "multiplier", Type: "number", Default: "3"},
+			{Name: "side", Type: "string", Default: "long"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/pivot-points": {
+		Summary: "Get classic pivot point levels for a specific stock from its previous daily bar",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+		},
+	},
+	"GET /api/avg-volume-dollars-screen": {
+		Summary: "Average volume in dollars screening",
+		Query: []queryParam{
+			{Name: "range", Type: "string"},
+			{Name: "interval", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "volume"},
+			{Name: "min_vol_dollars_m", Type: "number"},
+			{Name: "max_vol_dollars_m", Type: "number"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "explain", Type: "boolean", Description: "Also return each matching symbol's average dollar volume ($M)"},
+			{Name: "sort", Type: "string", Description: "Sort by computed value: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/avg-volume-percent-screen": {
+		Summary: "Average volume in percent screening",
+		Query: []queryParam{
+			{Name: "range", Type: "string"},
+			{Name: "interval", Type: "string"},
+			{Name: "lookback", Type: "integer", Lookback: "volume"},
+			{Name: "min_vol_percent", Type: "number"},
+			{Name: "max_vol_percent", Type: "number"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+			{Name: "min_price", Type: "number", Description: "Exclude symbols whose latest close is below this price (off by default)"},
+			{Name: "min_dollar_volume", Type: "number", Description: "Exclude symbols whose latest close * volume is below this amount (off by default)"},
+			{Name: "explain", Type: "boolean", Description: "Also return each matching symbol's volume %"},
+			{Name: "sort", Type: "string", Description: "Sort by computed value: asc or desc (default: symbol order)"},
+			{Name: "limit", Type: "integer", Default: "0", Description: "Keep only the first N results after sorting (0 returns all)"},
+		},
+	},
+	"GET /api/avg-volume-dollars": {
+		Summary: "Get average volume in dollars for a specific stock",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "volume"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/avg-volume-percent": {
+		Summary: "Get average volume in percent for a specific stock",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "lookback", Type: "integer", Lookback: "volume"},
+			{Name: "strict", Type: "boolean", Default: "true"},
+		},
+	},
+	"GET /api/symbols": {
+		Summary:     "Symbol universe endpoint: full list, or only symbols changed since a timestamp",
+		Description: "Query: updated_since=RFC3339 timestamp or unix seconds (optional) Clients should pass the returned as_of value as updated_since on their next poll",
+		Query: []queryParam{
+			{Name: "updated_since", Type: "string"},
+		},
+	},
+	"GET /api/symbols/detailed": {
+		Summary:     "Symbol universe with metadata: screener symbols joined with company info, paginated and ordered by symbol",
+		Description: "Each symbol carries name, sector, industry, market cap and its bucket (mega, large, mid, small, micro or unknown); company fields are empty when company info hasn't been ingested",
+		Query: []queryParam{
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer", Default: "50", Description: "Capped at 200"},
+		},
+	},
+	"GET /api/company-info": {
+		Summary: "Get all company info",
+		Query: []queryParam{
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer"},
+			{Name: "fields", Type: "string", Description: "Comma-separated fields to return, e.g. symbol,price,marketCap"},
+		},
+	},
+	"POST /api/company-info/symbols": {
+		Summary: "Get company info by multiple symbols (POST with JSON body)",
+		Body:    true,
+	},
+	"GET /api/company-info/search": {
+		Summary: "Search company info by name, sector, industry, or symbol",
+		Query: []queryParam{
+			{Name: "q", Type: "string", Required: true},
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer"},
+		},
+	},
+	"GET /api/company-info/fuzzy": {
+		Summary: "Fuzzy search company info by symbol or name (typo tolerant, requires pg_trgm)",
+		Query: []queryParam{
+			{Name: "q", Type: "string", Required: true},
+			{Name: "limit", Type: "integer", Default: "10"},
+			{Name: "threshold", Type: "number"},
+		},
+	},
+	"GET /api/company-info/sectors": {
+		Summary:     "List distinct sectors with company counts",
+		Description: "Sorted by name; blank sectors are left out",
+	},
+	"GET /api/company-info/industries": {
+		Summary:     "List distinct industries with company counts",
+		Description: "Sorted by name; blank industries are left out",
+	},
+	"GET /api/company-info/sector/:sector": {
+		Summary: "Get company info by sector",
+	},
+	"GET /api/company-info/industry/:industry": {
+		Summary: "Get company info by industry",
+	},
+	"GET /api/quote/:symbol": {
+		Summary:     "Get a live simple quote for a symbol straight from the upstream (no DB read or write)",
+		Description: "Intended for lightweight previews, e.g. ticker search before adding to a watchlist",
+	},
+	"GET /api/company-info/:symbol/metrics-history": {
+		Summary:     "Get a symbol's dated metric snapshots (price, PE, market cap, ...) for trend charts",
+		Description: "Query params: from, to (YYYY-MM-DD; default the last 365 days)",
+		Query: []queryParam{
+			{Name: "to", Type: "string"},
+			{Name: "from", Type: "string"},
+		},
+	},
+	"GET /api/company-info/:symbol": {
+		Summary: "Get company info by symbol",
+	},
+	"GET /api/fundamental-data": {
+		Summary: "Get all fundamental data",
+	},
+	"GET /api/fundamental-data/symbol/:symbol": {
+		Summary: "Get fundamental data by symbol",
+	},
+	"GET /api/fundamental-data/symbol/:symbol/type/:statementType": {
+		Summary: "Get fundamental data by symbol and statement type",
+	},
+	"GET /api/fundamental-data/symbol/:symbol/type/:statementType/frequency/:frequency": {
+		Summary: "Get fundamental data by symbol, statement type, and frequency",
+	},
+	"GET /api/fundamental-data/type/:statementType": {
+		Summary: "Get fundamental data by statement type",
+	},
+	"GET /api/fundamental-data/frequency/:frequency": {
+		Summary: "Get fundamental data by frequency",
+	},
+	"GET /api/fundamental-data/search": {
+		Summary: "Search fundamental data by symbol",
+		Query: []queryParam{
+			{Name: "q", Type: "string", Required: true},
+		},
+	},
+	"GET /api/fundamental-data/metrics": {
+		Summary: "Get fundamental metrics for a symbol",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string"},
+			{Name: "statement_type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "annual"},
+		},
+	},
+	"GET /api/fundamental-data/statement": {
+		Summary: "Get a statement with rows in statement order and values in chronological order (for charts)",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string"},
+			{Name: "type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "annual"},
+		},
+	},
+	"POST /api/fundamental-data/metrics/batch": {
+		Summary: "Calculate metrics for multiple symbols in one call (POST with JSON body)",
+		Body:    true,
+	},
+	"GET /api/compare": {
+		Summary:     "Compare symbols side by side",
+		Description: "Screener data, company summary and fundamental metrics per symbol; symbols with no data are listed in missing",
+		Query: []queryParam{
+			{Name: "symbols", Type: "string", Required: true, Description: "Comma-separated, at most 5"},
+			{Name: "statement_type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "annual"},
+		},
+	},
+	"POST /api/fundamental-data/screen": {
+		Summary: "Screen stocks on combined revenue growth, EPS and margin criteria (POST with JSON body)",
+		Query: []queryParam{
+			{Name: "explain", Type: "boolean", Description: "Also return every evaluated value (growth, EPS, margins) per matching symbol"},
+		},
+		Body: true,
+	},
+	"GET /api/fundamental-data/revenue-growth": {
+		Summary: "Filter stocks by revenue growth (QoQ/YoY)",
+		Query: []queryParam{
+			{Name: "statement_type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "quarterly"},
+			{Name: "min_qoq_growth", Type: "number"},
+			{Name: "max_qoq_growth", Type: "number"},
+			{Name: "min_yoy_growth", Type: "number"},
+			{Name: "max_yoy_growth", Type: "number"},
+		},
+	},
+	"GET /api/fundamental-data/eps-filter": {
+		Summary: "Filter stocks by EPS range",
+		Query: []queryParam{
+			{Name: "statement_type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "annual"},
+			{Name: "date", Type: "string"},
+			{Name: "min_eps", Type: "number"},
+			{Name: "max_eps", Type: "number"},
+		},
+	},
+	"GET /api/fundamental-data/dividend-screen": {
+		Summary:     "Screen stocks by trailing dividend yield (annual dividends per share / price, %)",
+		Description: "Dividends paid come from cash flow statements, share counts from income statements and price from the screener close; sorted by yield, highest first",
+		Query: []queryParam{
+			{Name: "min_yield", Type: "number"},
+			{Name: "max_yield", Type: "number"},
+			{Name: "frequency", Type: "string", Default: "annual", Description: "annual uses the latest year; quarterly sums the latest four quarters"},
+			{Name: "include_non_payers", Type: "boolean", Description: "Include symbols with no dividends at a yield of 0"},
+		},
+	},
+	"GET /api/fundamental-data/valuation-screen": {
+		Summary:     "Screen stocks by price-to-book (price x shares / total equity) and price-to-sales (market cap / revenue)",
+		Description: "Equity and shares come from balance sheets, revenue from income statements, price and market cap from company info. A ratio with missing inputs or a non-positive denominator is null; such symbols are excluded only when that ratio is bounded",
+		Query: []queryParam{
+			{Name: "min_pb", Type: "number"},
+			{Name: "max_pb", Type: "number"},
+			{Name: "min_ps", Type: "number"},
+			{Name: "max_ps", Type: "number"},
+			{Name: "frequency", Type: "string", Default: "annual", Description: "annual uses the latest year; quarterly sums the latest four quarters of revenue"},
+		},
+	},
+	"GET /api/fundamental-data/margin-filter": {
+		Summary: "Filter stocks by margin range",
+		Query: []queryParam{
+			{Name: "margin_type", Type: "string", Default: "gross"},
+			{Name: "statement_type", Type: "string", Default: "income"},
+			{Name: "frequency", Type: "string", Default: "annual"},
+			{Name: "date", Type: "string"},
+			{Name: "min_margin", Type: "number"},
+			{Name: "max_margin", Type: "number"},
+		},
+	},
+	"GET /api/protected/screener": {
+		Summary: "Get all screener data (read-only)",
+		Query: []queryParam{
+			{Name: "enrich", Type: "boolean"},
+			{Name: "fields", Type: "string", Description: "Comma-separated fields to return, e.g. symbol,close,volume (not with enrich)"},
+		},
+	},
+	"GET /api/protected/screener/recent": {
+		Summary: "Get recently updated screener rows, newest first",
+		Query: []queryParam{
+			{Name: "limit", Type: "integer", Default: "50", Description: "Capped at 200"},
+		},
+	},
+	"GET /api/protected/screener/filter": {
+		Summary:     "Get screeners with advanced filtering, sorting, and pagination",
+		Description: "The symbol universe can be restricted via ?symbols=AAPL,MSFT or, on POST, a {\"symbols\": [...]} body",
+		Body:        true,
+		Query: []queryParam{
+			{Name: "min_price", Type: "number"},
+			{Name: "max_price", Type: "number"},
+			{Name: "min_volume", Type: "number"},
+			{Name: "max_volume", Type: "number"},
+			{Name: "min_open", Type: "number"},
+			{Name: "max_open", Type: "number"},
+			{Name: "min_high", Type: "number"},
+			{Name: "max_high", Type: "number"},
+			{Name: "min_low", Type: "number"},
+			{Name: "max_low", Type: "number"},
+			{Name: "min_close", Type: "number"},
+			{Name: "max_close", Type: "number"},
+			{Name: "min_dollar_volume", Type: "number"},
+			{Name: "max_dollar_volume", Type: "number"},
+			{Name: "symbols", Type: "string"},
+			{Name: "sort", Type: "string"},
+			{Name: "sort_field", Type: "string"},
+			{Name: "sort_direction", Type: "string", Default: "asc"},
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer", Default: "10", Description: "Capped at 100; invalid or non-positive values fall back to the default"},
+			{Name: "enrich", Type: "boolean"},
+		},
+	},
+	"POST /api/protected/screener/filter": {
+		Summary:     "Get screeners with advanced filtering, sorting, and pagination",
+		Description: "The symbol universe can be restricted via ?symbols=AAPL,MSFT or, on POST, a {\"symbols\": [...]} body",
+		Body:        true,
+		Query: []queryParam{
+			{Name: "min_price", Type: "number"},
+			{Name: "max_price", Type: "number"},
+			{Name: "min_volume", Type: "number"},
+			{Name: "max_volume", Type: "number"},
+			{Name: "min_open", Type: "number"},
+			{Name: "max_open", Type: "number"},
+			{Name: "min_high", Type: "number"},
+			{Name: "max_high", Type: "number"},
+			{Name: "min_low", Type: "number"},
+			{Name: "max_low", Type: "number"},
+			{Name: "min_close", Type: "number"},
+			{Name: "max_close", Type: "number"},
+			{Name: "min_dollar_volume", Type: "number"},
+			{Name: "max_dollar_volume", Type: "number"},
+			{Name: "symbols", Type: "string"},
+			{Name: "sort", Type: "string"},
+			{Name: "sort_field", Type: "string"},
+			{Name: "sort_direction", Type: "string", Default: "asc"},
+			{Name: "page", Type: "integer", Default: "1"},
+			{Name: "limit", Type: "integer", Default: "10", Description: "Capped at 100; invalid or non-positive values fall back to the default"},
+			{Name: "enrich", Type: "boolean"},
+		},
+	},
+	"GET /api/protected/screener/top-gainers": {
+		Summary: "Get top gainers",
+		Query: []queryParam{
+			{Name: "limit", Type: "integer", Default: "10"},
+			{Name: "enrich", Type: "boolean"},
+		},
+	},
+	"GET /api/protected/screener/most-active": {
+		Summary: "Get most active stocks",
+		Query: []queryParam{
+			{Name: "limit", Type: "integer", Default: "10"},
+			{Name: "enrich", Type: "boolean"},
+		},
+	},
+	"GET /api/protected/screener/count": {
+		Summary: "Get total count of screeners",
+	},
+	"GET /api/protected/screener/search": {
+		Summary: "Search screeners by symbol",
+		Query: []queryParam{
+			{Name: "q", Type: "string", Required: true},
+			{Name: "limit", Type: "integer", Default: "10"},
+		},
+	},
+	"GET /api/protected/screener/price-range": {
+		Summary: "Get screeners by price range",
+		Query: []queryParam{
+			{Name: "min", Type: "number"},
+			{Name: "max", Type: "number"},
+		},
+	},
+	"GET /api/protected/screener/volume-range": {
+		Summary: "Get screeners by volume range",
+		Query: []queryParam{
+			{Name: "min", Type: "number"},
+			{Name: "max", Type: "number"},
+		},
+	},
+	"GET /api/protected/screener/symbol/:symbol": {
+		Summary: "Get screener by symbol",
+	},
+	"GET /api/protected/screener/:id": {
+		Summary: "Get screener by ID",
+	},
+	"POST /api/protected/screener/symbols": {
+		Summary: "Get screeners by multiple symbols (POST with JSON body)",
+		Body:    true,
+	},
+	"GET /api/protected/historical/by-symbol": {
+		Summary: "Get historical records by symbol, range, and interval",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+			{Name: "resample", Type: "boolean"},
+		},
+	},
+	"GET /api/protected/historical/latest": {
+		Summary:     "Get the newest stored bar for a symbol, range, and interval",
+		Description: "Returns the latest epoch and its OHLCV bar without the rest of the series; 404 when no bars are stored",
+		Query: []queryParam{
+			{Name: "symbol", Type: "string", Required: true},
+			{Name: "range", Type: "string", Required: true},
+			{Name: "interval", Type: "string", Required: true},
+		},
+	},
+	"GET /api/protected/historical": {
+		Summary: "Get all historical records",
+	},
+	"POST /api/protected/historical": {
+		Summary: "Create historical record",
+		Body:    true,
+	},
+	"POST /api/protected/historical/batch": {
+		Summary: "Create historical records in batch",
+		Body:    true,
+	},
+	"PUT /api/protected/historical": {
+		Summary: "Upsert historical record",
+		Body:    true,
+	},
+	"PUT /api/protected/historical/batch": {
+		Summary: "Upsert historical records in batch",
+		Body:    true,
+	},
+	"PUT /api/protected/historical/:id": {
+		Summary: "Update historical record by ID",
+		Body:    true,
+	},
+	"GET /api/protected/historical/:id": {
+		Summary: "Get historical record by ID",
+	},
+	"GET /api/protected/watchlist": {
+		Summary: "Get all watchlists for the authenticated user",
+	},
+	"GET /api/protected/watchlist/:id": {
+		Summary: "Get a specific watchlist by ID",
+	},
+	"POST /api/protected/watchlist": {
+		Summary: "Create a new watchlist",
+		Body:    true,
+	},
+	"PUT /api/protected/watchlist/:id": {
+		Summary: "Update a watchlist",
+		Body:    true,
+	},
+	"DELETE /api/protected/watchlist/:id": {
+		Summary: "Delete a watchlist",
+	},
+	"GET /api/protected/watchlist/:id/items": {
+		Summary: "Get all items for a watchlist",
+	},
+	"GET /api/protected/watchlist/:id/performance": {
+		Summary: "Get aggregate daily performance for a watchlist owned by the authenticated user",
+	},
+	"GET /api/protected/watchlist/item/:id": {
+		Summary: "Get a specific item by ID",
+	},
+	"POST /api/protected/watchlist/:id/items": {
+		Summary: "Add an item to a watchlist",
+		Body:    true,
+	},
+	"PUT /api/protected/watchlist/item/:id": {
+		Summary: "Update a watchlist item",
+		Body:    true,
+	},
+	"DELETE /api/protected/watchlist/item/:id": {
+		Summary: "Delete a watchlist item",
+	},
+	"PATCH /api/protected/watchlist/item/:id/star": {
+		Summary: "Toggle starred status of an item",
+	},
+	"GET /api/protected/favorites": {
+		Summary: "Get the authenticated user's favorites with current screener and company info",
+	},
+	"POST /api/protected/favorites/:symbol": {
+		Summary:     "Favorite a symbol",
+		Description: "Idempotent: 201 when added, 200 when the symbol is already a favorite",
+	},
+	"DELETE /api/protected/favorites/:symbol": {
+		Summary: "Remove a symbol from favorites",
+	},
+	"GET /api/protected/watchlist/starred": {
+		Summary: "Get all starred items for the authenticated user",
+	},
+	"PUT /api/protected/watchlist/items/batch": {
+		Summary: "Batch update items (useful for price updates)",
+		Body:    true,
+	},
+}
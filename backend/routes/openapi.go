@@ -0,0 +1,310 @@
+package routes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	indicatorsscreening "screener/backend/service/filtering/indicators/screening"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// routeDoc describes one route for the OpenAPI document
+type routeDoc struct {
+	Summary     string
+	Description string
+	Query       []queryParam
+	Body        bool // Accepts a JSON request body
+}
+
+// queryParam describes a query string parameter
+type queryParam struct {
+	Name        string
+	Type        string // "string", "integer", "number" or "boolean"
+	Default     string
+	Lookback    string // Default is the active DEFAULT_*_LOOKBACK of this name (see activeLookbacks)
+	Required    bool
+	Description string
+}
+
+// activeLookbacks resolves queryParam.Lookback names, so documented defaults follow the
+// DEFAULT_*_LOOKBACK settings loaded at startup
+var activeLookbacks = map[string]func(indicatorsscreening.LookbackDefaults) int{
+	"adr":          func(l indicatorsscreening.LookbackDefaults) int { return l.ADR },
+	"atr":          func(l indicatorsscreening.LookbackDefaults) int { return l.ATR },
+	"cci":          func(l indicatorsscreening.LookbackDefaults) int { return l.CCI },
+	"mfi":          func(l indicatorsscreening.LookbackDefaults) int { return l.MFI },
+	"williams_r":   func(l indicatorsscreening.LookbackDefaults) int { return l.WilliamsR },
+	"volume":       func(l indicatorsscreening.LookbackDefaults) int { return l.Volume },
+	"keltner_ema":  func(l indicatorsscreening.LookbackDefaults) int { return l.KeltnerEMA },
+	"keltner_atr":  func(l indicatorsscreening.LookbackDefaults) int { return l.KeltnerATR },
+	"stochastic_k": func(l indicatorsscreening.LookbackDefaults) int { return l.StochasticK },
+	"stochastic_d": func(l indicatorsscreening.LookbackDefaults) int { return l.StochasticD },
+}
+
+var (
+	openAPISpecOnce sync.Once
+	openAPISpec     fiber.Map
+)
+
+// openAPIHandler serves the OpenAPI 3 document for every route registered on app.
+// The document is built on first request, once SetupRoutes has registered everything.
+func openAPIHandler(app *fiber.App) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		openAPISpecOnce.Do(func() {
+			openAPISpec = buildOpenAPISpec(app)
+		})
+		return c.JSON(openAPISpec)
+	}
+}
+
+// swaggerUIHandler serves a Swagger UI page that renders /openapi.json
+func swaggerUIHandler(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(swaggerUIPage)
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Screener Backend API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// buildOpenAPISpec builds the OpenAPI document from the app's route stack and routeDocs
+func buildOpenAPISpec(app *fiber.App) fiber.Map {
+	paths := fiber.Map{}
+	seen := make(map[string]bool)
+
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || route.Method == fiber.MethodOptions {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		if seen[key] || route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+		seen[key] = true
+
+		openAPIPath, pathParams := openAPIPathTemplate(route.Path)
+		item, ok := paths[openAPIPath].(fiber.Map)
+		if !ok {
+			item = fiber.Map{}
+			paths[openAPIPath] = item
+		}
+		item[strings.ToLower(route.Method)] = openAPIOperation(route.Method, route.Path, pathParams)
+	}
+
+	return fiber.Map{
+		"openapi": "3.0.3",
+		"info": fiber.Map{
+			"title":       "Screener Backend API",
+			"version":     "1.0.0",
+			"description": "All JSON responses use the {success, data, ...} envelope; errors set success=false with error and message.",
+		},
+		"paths": paths,
+		"components": fiber.Map{
+			"securitySchemes": fiber.Map{
+				"bearerAuth": fiber.Map{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+			"schemas": fiber.Map{
+				"SuccessEnvelope": fiber.Map{
+					"type":     "object",
+					"required": []string{"success"},
+					"properties": fiber.Map{
+						"success": fiber.Map{"type": "boolean", "example": true},
+						"data":    fiber.Map{"description": "Endpoint-specific payload"},
+						"meta":    fiber.Map{"type": "object", "description": "Freshness metadata, when the endpoint reports it"},
+					},
+				},
+				"ErrorEnvelope": fiber.Map{
+					"type":     "object",
+					"required": []string{"success", "error", "message"},
+					"properties": fiber.Map{
+						"success": fiber.Map{"type": "boolean", "example": false},
+						"error":   fiber.Map{"type": "string", "example": "Bad Request"},
+						"message": fiber.Map{"type": "string"},
+					},
+				},
+			},
+			"responses": fiber.Map{
+				"Success":      envelopeResponse("Successful response", "SuccessEnvelope"),
+				"BadRequest":   envelopeResponse("Invalid parameters", "ErrorEnvelope"),
+				"Unauthorized": envelopeResponse("Missing or invalid bearer token", "ErrorEnvelope"),
+				"NotFound":     envelopeResponse("Resource not found", "ErrorEnvelope"),
+				"ServerError":  envelopeResponse("Internal server error", "ErrorEnvelope"),
+			},
+		},
+	}
+}
+
+// openAPIOperation builds the operation object for one route
+func openAPIOperation(method, path string, pathParams []string) fiber.Map {
+	doc, documented := routeDocs[method+" "+path]
+	summary := doc.Summary
+	if !documented || summary == "" {
+		summary = method + " " + path
+	}
+
+	parameters := make([]fiber.Map, 0, len(pathParams)+len(doc.Query))
+	for _, name := range pathParams {
+		parameters = append(parameters, fiber.Map{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   fiber.Map{"type": "string"},
+		})
+	}
+	for _, q := range doc.Query {
+		schema := fiber.Map{"type": q.Type}
+		if lookback, ok := activeLookbacks[q.Lookback]; ok {
+			q.Default = strconv.Itoa(lookback(indicatorsscreening.Lookbacks()))
+		}
+		if q.Default != "" {
+			schema["default"] = typedDefault(q.Type, q.Default)
+		}
+		param := fiber.Map{
+			"name":     q.Name,
+			"in":       "query",
+			"required": q.Required,
+			"schema":   schema,
+		}
+		if q.Description != "" {
+			param["description"] = q.Description
+		}
+		parameters = append(parameters, param)
+	}
+
+	responses := fiber.Map{
+		"200": fiber.Map{"$ref": "#/components/responses/Success"},
+		"400": fiber.Map{"$ref": "#/components/responses/BadRequest"},
+		"500": fiber.Map{"$ref": "#/components/responses/ServerError"},
+	}
+	if len(pathParams) > 0 {
+		responses["404"] = fiber.Map{"$ref": "#/components/responses/NotFound"}
+	}
+
+	operation := fiber.Map{
+		"summary":    summary,
+		"tags":       []string{openAPITag(path)},
+		"parameters": parameters,
+		"responses":  responses,
+	}
+	if doc.Description != "" {
+		operation["description"] = doc.Description
+	}
+	if doc.Body {
+		operation["requestBody"] = fiber.Map{
+			"required": true,
+			"content": fiber.Map{
+				fiber.MIMEApplicationJSON: fiber.Map{"schema": fiber.Map{"type": "object"}},
+			},
+		}
+	}
+	if strings.HasPrefix(path, "/api/protected") || strings.HasPrefix(path, "/api/admin") {
+		operation["security"] = []fiber.Map{{"bearerAuth": []string{}}}
+		responses["401"] = fiber.Map{"$ref": "#/components/responses/Unauthorized"}
+	}
+	return operation
+}
+
+// openAPIPathTemplate converts a Fiber path ("/watchlist/:id") to an OpenAPI template
+// ("/watchlist/{id}") and returns the path parameter names in order
+func openAPIPathTemplate(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	params := make([]string, 0)
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			name := strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// openAPITag groups routes by the first path segment after /api (and /api/protected or /api/admin)
+func openAPITag(path string) string {
+	trimmed := strings.TrimPrefix(path, "/api")
+	group := ""
+	for _, prefix := range []string{"/protected", "/admin"} {
+		if strings.HasPrefix(trimmed, prefix+"/") {
+			group = strings.TrimPrefix(prefix, "/")
+			trimmed = strings.TrimPrefix(trimmed, prefix)
+			break
+		}
+	}
+	if group == "admin" {
+		return "admin"
+	}
+
+	segments := strings.FieldsFunc(trimmed, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 || strings.HasPrefix(segments[0], ":") {
+		return "general"
+	}
+	return segments[0]
+}
+
+// typedDefault converts a default to the parameter's schema type so the spec validates
+func typedDefault(paramType, value string) interface{} {
+	switch paramType {
+	case "integer":
+		if v, err := strconv.Atoi(value); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
+
+// envelopeResponse builds a JSON response object referencing an envelope schema
+func envelopeResponse(description, schema string) fiber.Map {
+	return fiber.Map{
+		"description": description,
+		"content": fiber.Map{
+			fiber.MIMEApplicationJSON: fiber.Map{
+				"schema": fiber.Map{"$ref": "#/components/schemas/" + schema},
+			},
+		},
+	}
+}
+
+// undocumentedRoutes lists registered routes with no routeDocs entry, sorted (used when logging at startup)
+func undocumentedRoutes(app *fiber.App) []string {
+	missing := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || route.Method == fiber.MethodOptions {
+			continue
+		}
+		key := route.Method + " " + route.Path
+		if seen[key] || route.Path == "/openapi.json" || route.Path == "/docs" {
+			continue
+		}
+		seen[key] = true
+		if _, ok := routeDocs[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,70 @@
+package routes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"screener/backend/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMaxPageLimit caps page sizes for endpoints without a larger, endpoint-specific cap
+const DefaultMaxPageLimit = 100
+
+// ParsePagination reads the page and limit query params for a paginated endpoint.
+// Validation is soft: a missing, non-numeric or non-positive page becomes 1, a missing,
+// non-numeric or non-positive limit becomes defaultLimit, and limit is clamped to maxLimit.
+func ParsePagination(c *fiber.Ctx, defaultLimit, maxLimit int) service.PaginationOptions {
+	pagination := service.PaginationOptions{Page: 1, Limit: defaultLimit}
+	if page, err := strconv.Atoi(c.Query("page")); err == nil && page > 0 {
+		pagination.Page = page
+	}
+	if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+		pagination.Limit = limit
+	}
+	if pagination.Limit > maxLimit {
+		pagination.Limit = maxLimit
+	}
+	return pagination
+}
+
+// setPaginationHeaders adds X-Total-Count, X-Page, X-Total-Pages and an RFC 5988
+// Link header (rel=first/prev/next/last) to a paginated response.
+// The pagination fields in the JSON body are left untouched for backward compatibility.
+func setPaginationHeaders(c *fiber.Ctx, page, limit int, total int64, totalPages int) {
+	c.Set("X-Total-Count", strconv.FormatInt(total, 10))
+	c.Set("X-Page", strconv.Itoa(page))
+	c.Set("X-Total-Pages", strconv.Itoa(totalPages))
+
+	if totalPages <= 0 {
+		return
+	}
+
+	links := []string{
+		fmt.Sprintf(`<%s>; rel="first"`, pageURL(c, 1, limit)),
+	}
+	if page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(c, page-1, limit)))
+	}
+	if page < totalPages {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(c, page+1, limit)))
+	}
+	links = append(links, fmt.Sprintf(`<%s>; rel="last"`, pageURL(c, totalPages, limit)))
+
+	c.Set(fiber.HeaderLink, strings.Join(links, ", "))
+}
+
+// pageURL builds the current request URL with the page and limit query params replaced
+func pageURL(c *fiber.Ctx, page, limit int) string {
+	args := fasthttp.AcquireArgs()
+	defer fasthttp.ReleaseArgs(args)
+
+	c.Request().URI().QueryArgs().CopyTo(args)
+	args.Set("page", strconv.Itoa(page))
+	args.Set("limit", strconv.Itoa(limit))
+
+	return fmt.Sprintf("%s%s?%s", c.BaseURL(), c.Path(), args.String())
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"screener/backend/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParsePagination(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.JSON(ParsePagination(c, 20, DefaultMaxPageLimit))
+	})
+
+	tests := []struct {
+		query     string
+		wantPage  int
+		wantLimit int
+	}{
+		{"", 1, 20},
+		{"?page=3&limit=50", 3, 50},
+		{"?page=-2&limit=-5", 1, 20},
+		{"?page=0&limit=0", 1, 20},
+		{"?page=abc&limit=ten", 1, 20},
+		{"?page=2.5&limit=1e2", 1, 20},
+		{"?limit=100", 1, 100},
+		{"?limit=101", 1, 100},
+		{"?page=7&limit=100000", 7, 100},
+		{"?page=99999999999999999999&limit=99999999999999999999", 1, 20}, // Overflows int: not a number
+		{"?page=%20&limit=", 1, 20},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+tt.query, nil))
+		if err != nil {
+			t.Fatalf("GET %s: %v", tt.query, err)
+		}
+		var got service.PaginationOptions
+		err = json.NewDecoder(resp.Body).Decode(&got)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("GET %s: decoding pagination: %v", tt.query, err)
+		}
+		if got.Page != tt.wantPage || got.Limit != tt.wantLimit {
+			t.Errorf("ParsePagination(%q) = page %d limit %d, want page %d limit %d", tt.query, got.Page, got.Limit, tt.wantPage, tt.wantLimit)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// defaultQuoteRateLimit is the default number of live quote requests allowed per client per minute
+const defaultQuoteRateLimit = 60
+
+// newQuoteRateLimiter limits live upstream quote lookups per client IP to QUOTE_RATE_LIMIT_PER_MINUTE
+// (default 60), so preview traffic can't exhaust the finance-query hosts. Over the limit gets 429.
+func newQuoteRateLimiter() fiber.Handler {
+	max := defaultQuoteRateLimit
+	if value := os.Getenv("QUOTE_RATE_LIMIT_PER_MINUTE"); value != "" {
+		if v, err := strconv.Atoi(value); err == nil && v > 0 {
+			max = v
+		} else {
+			log.Printf("Warning: invalid QUOTE_RATE_LIMIT_PER_MINUTE %q, using %d", value, defaultQuoteRateLimit)
+		}
+	}
+
+	return limiter.New(limiter.Config{
+		Max:        max,
+		Expiration: time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.IP()
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"success": false,
+				"error":   "Too Many Requests",
+				"message": "Quote rate limit exceeded, please retry shortly",
+			})
+		},
+	})
+}
@@ -2,14 +2,19 @@ package routes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"screener/backend/database"
 	"screener/backend/model"
 	"screener/backend/routes/filtering"
 	"screener/backend/service"
 	"screener/backend/service/caching"
+	indicatorscalculations "screener/backend/service/filtering/indicators/calculations"
 	indicatorsscreening "screener/backend/service/filtering/indicators/screening"
 	"screener/backend/supabase"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,8 +27,12 @@ func SetupRoutes(app *fiber.App) {
 	screenerService := service.NewScreenerService()
 	historicalService := service.NewHistoricalService()
 	watchlistService := service.NewWatchlistService()
+	favoriteService := service.NewFavoriteService()
 	companyInfoService := service.NewCompanyInfoService()
 	fundamentalDataService := service.NewFundamentalDataService()
+	compareService := service.NewCompareService()
+	// Default indicator lookbacks (DEFAULT_*_LOOKBACK, loaded at startup)
+	lookbacks := indicatorsscreening.Lookbacks()
 
 	// Root route
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -32,12 +41,17 @@ func SetupRoutes(app *fiber.App) {
 			"message": "Screener Backend API",
 			"version": "1.0.0",
 			"endpoints": fiber.Map{
-				"health": "/api/health",
-				"docs":   "See API documentation",
+				"health":  "/api/health",
+				"docs":    "/docs",
+				"openapi": "/openapi.json",
 			},
 		})
 	})
 
+	// OpenAPI 3 document for all routes (see routeDocs) and a Swagger UI rendering it
+	app.Get("/openapi.json", openAPIHandler(app))
+	app.Get("/docs", swaggerUIHandler)
+
 	// Convenience health check at root level (redirects to /api/health)
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -47,13 +61,27 @@ func SetupRoutes(app *fiber.App) {
 	})
 
 	// Public routes
+	// Shared concurrency cap for the expensive full-universe screening routes
+	screenLimit := newScreenLimiter()
+	// Per-client rate limit for live upstream quote lookups
+	quoteLimit := newQuoteRateLimiter()
+
 	public := app.Group("/api")
+
+	// Compress API responses; registered on the /api prefix so it also covers /api/protected
+	public.Use(newCompressMiddleware())
+
+	// Populate userID for authenticated callers without requiring auth on public routes
+	public.Use(supabase.OptionalJWT())
+
+	// Admin routes (/api/admin/*) require a token with admin claims (see supabase.UserClaims.IsAdmin)
+	admin := public.Group("/admin", supabase.AdminOnly())
 	{
 		// Register filtering routes (inside-day, high-volume-quarter, high-volume-year, high-volume-ever)
-		filtering.SetupInsideDayRoutes(public)
-		filtering.SetupHighVolumeQuarterRoutes(public)
-		filtering.SetupHighVolumeYearRoutes(public)
-		filtering.SetupHighVolumeEverRoutes(public)
+		filtering.SetupInsideDayRoutes(public, admin)
+		filtering.SetupHighVolumeQuarterRoutes(public, admin)
+		filtering.SetupHighVolumeYearRoutes(public, admin)
+		filtering.SetupHighVolumeEverRoutes(public, admin)
 		// Health check endpoint
 		public.Get("/health", func(c *fiber.Ctx) error {
 			return c.JSON(fiber.Map{
@@ -62,12 +90,51 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Admin ingestion endpoint (public): trigger screener+historicals fetch for all symbols
-		public.Post("/admin/ingest/historicals", func(c *fiber.Ctx) error {
+		// Deep health check: verifies database and Redis connectivity
+		public.Get("/health/deep", func(c *fiber.Ctx) error {
+			status := "ok"
+			dbStatus := fiber.Map{"connected": true}
+			if sqlDB, err := database.GetDB().DB(); err != nil {
+				dbStatus = fiber.Map{"connected": false, "error": err.Error()}
+			} else if err := sqlDB.PingContext(c.Context()); err != nil {
+				dbStatus = fiber.Map{"connected": false, "error": err.Error()}
+			}
+			if !dbStatus["connected"].(bool) {
+				status = "unhealthy"
+			}
+
+			// Redis being down is degraded rather than unhealthy: the in-memory fallback keeps serving
+			redisStatus := caching.GetRedisStatus()
+			if !redisStatus.Connected && status == "ok" {
+				status = "degraded"
+			}
+
+			code := fiber.StatusOK
+			if status == "unhealthy" {
+				code = fiber.StatusServiceUnavailable
+			}
+			return c.Status(code).JSON(fiber.Map{
+				"status":   status,
+				"database": dbStatus,
+				"redis":    redisStatus,
+			})
+		})
+
+		// Admin ingestion endpoint (admin-only): trigger screener+historicals fetch for all symbols
+		// Ingestion endpoints accept ?no_cache=true to skip the upstream response cache
+		admin.Post("/ingest/historicals", func(c *fiber.Ctx) error {
 			concurrency, _ := strconv.Atoi(c.Query("concurrency", "8"))
 			fetcher := service.NewFetcherService()
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
+			ctx = service.WithIngestionTrigger(ctx, ingestionTrigger(c))
+			if c.QueryBool("no_cache") {
+				ctx = service.WithoutUpstreamCache(ctx)
+			}
+			// Aggregate screener daily values from regular-session bars only
+			if c.QueryBool("regular_hours") {
+				ctx = service.WithRegularHoursOnly(ctx)
+			}
 
 			jobID, err := fetcher.RunIngestion(ctx, concurrency)
 			if err != nil {
@@ -81,6 +148,8 @@ func SetupRoutes(app *fiber.App) {
 			// Invalidate symbols cache since screener table may have been updated
 			invalidator := caching.NewInvalidationService()
 			_ = invalidator.InvalidateSymbols()
+			_ = invalidator.InvalidateScreenerRecent()
+			_ = invalidator.InvalidateSymbolsDetailed()
 
 			return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
 				"success":     true,
@@ -89,11 +158,78 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Watchlist price update endpoint (public): trigger price updates for all watchlist items
-		public.Post("/admin/watchlist/update-prices", func(c *fiber.Ctx) error {
+		// Single-symbol historicals refresh (admin-only): re-run ingestion for one symbol
+		admin.Post("/ingest/historicals/:symbol", func(c *fiber.Ctx) error {
+			symbol := c.Params("symbol")
+			fetcher := service.NewFetcherService()
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+			defer cancel()
+			if c.QueryBool("regular_hours") {
+				ctx = service.WithRegularHoursOnly(ctx)
+			}
+
+			counts, err := fetcher.RefreshSymbolHistoricals(ctx, symbol)
+			if err != nil {
+				switch err.Error() {
+				case "symbol is required":
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				case "refresh already in progress":
+					return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+						"success": false,
+						"error":   "Conflict",
+						"message": fmt.Sprintf("A refresh for %s is already in progress", symbol),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+					"data":    counts,
+				})
+			}
+
+			// Invalidate cached historical entries for this symbol (and the feed its screener row is in)
+			invalidator := caching.NewInvalidationService()
+			_ = invalidator.InvalidateHistorical(counts.Symbol)
+			_ = invalidator.InvalidateScreenerRecent()
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    counts,
+			})
+		})
+
+		// Ingestion run history (admin-only): newest first, optional ?type= filter
+		// (historicals, company_info, fundamental_data, watchlist_prices, market_aggregation)
+		admin.Get("/ingest/runs", func(c *fiber.Ctx) error {
+			pagination := ParsePagination(c, service.DefaultIngestionRunsLimit, service.MaxIngestionRunsLimit)
+
+			result, err := service.GetIngestionRuns(c.Query("type"), pagination)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    result,
+			})
+		})
+
+		// Watchlist price update endpoint (admin-only): trigger price updates for all watchlist items
+		admin.Post("/watchlist/update-prices", func(c *fiber.Ctx) error {
 			fetcher := service.NewFetcherService()
 			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 			defer cancel()
+			ctx = service.WithIngestionTrigger(ctx, ingestionTrigger(c))
 
 			jobID, err := fetcher.RunWatchlistPriceUpdate(ctx)
 			if err != nil {
@@ -111,11 +247,19 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Company info ingestion endpoint (public): trigger company info fetch for all screener symbols
-		public.Post("/admin/ingest/company-data", func(c *fiber.Ctx) error {
+		// Company info ingestion endpoint (admin-only): trigger company info fetch for all screener symbols
+		admin.Post("/ingest/company-data", func(c *fiber.Ctx) error {
 			fetcher := service.NewFetcherService()
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 			defer cancel()
+			ctx = service.WithIngestionTrigger(ctx, ingestionTrigger(c))
+			if c.QueryBool("no_cache") {
+				ctx = service.WithoutUpstreamCache(ctx)
+			}
+			// Opt in to dated metrics snapshots for trend analysis
+			if c.QueryBool("snapshot") {
+				ctx = service.WithMetricsSnapshots(ctx)
+			}
 
 			jobID, err := fetcher.RunCompanyInfoIngestion(ctx)
 			if err != nil {
@@ -129,6 +273,7 @@ func SetupRoutes(app *fiber.App) {
 			// Invalidate company info cache after ingestion
 			invalidator := caching.NewInvalidationService()
 			_ = invalidator.InvalidateAllCompanyInfo()
+			_ = invalidator.InvalidateSymbolsDetailed()
 
 			return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
 				"success":     true,
@@ -137,11 +282,56 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Fundamental data ingestion endpoint (public): trigger fundamental data fetch for all screener symbols
-		public.Post("/admin/ingest/fundamental-data", func(c *fiber.Ctx) error {
+		// Single-symbol company info refresh (admin-only): re-fetch and upsert one symbol's company info
+		admin.Post("/ingest/company-data/:symbol", func(c *fiber.Ctx) error {
+			symbol := c.Params("symbol")
+			fetcher := service.NewFetcherService()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			companyInfo, err := fetcher.RefreshCompanyInfo(ctx, symbol)
+			if err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": fmt.Sprintf("No quote returned for symbol %s", symbol),
+					})
+				}
+				if err.Error() == "symbol is required" {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			// Invalidate cached entries for this symbol
+			invalidator := caching.NewInvalidationService()
+			_ = invalidator.InvalidateCompanyInfo(companyInfo.Symbol)
+			_ = invalidator.InvalidateSymbolsDetailed()
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    companyInfo,
+			})
+		})
+
+		// Fundamental data ingestion endpoint (admin-only): trigger fundamental data fetch for all screener symbols
+		admin.Post("/ingest/fundamental-data", func(c *fiber.Ctx) error {
 			fetcher := service.NewFetcherService()
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 			defer cancel()
+			ctx = service.WithIngestionTrigger(ctx, ingestionTrigger(c))
+			if c.QueryBool("no_cache") {
+				ctx = service.WithoutUpstreamCache(ctx)
+			}
 
 			jobID, err := fetcher.RunFundamentalDataIngestion(ctx)
 			if err != nil {
@@ -163,16 +353,41 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Market statistics aggregation endpoint (public): trigger market aggregation (call every 5 minutes via external cron)
-		public.Post("/admin/market-statistics/aggregate", func(c *fiber.Ctx) error {
+		// Market statistics aggregation endpoint (admin-only): trigger market aggregation (call every 5 minutes via external cron)
+		// Outside the regular session and after-hours (weekends, holidays, overnight) the run is skipped
+		// unless ?force=true, so overnight cron fires don't spend upstream calls on stale quotes
+		admin.Post("/market-statistics/aggregate", func(c *fiber.Ctx) error {
+			calendar := service.DefaultMarketCalendar()
+			if ok, reason := calendar.AggregationWindow(time.Now()); !ok && !c.QueryBool("force") {
+				fmt.Printf("Skipping market aggregation: %s\n", reason)
+				return c.JSON(fiber.Map{
+					"success":       true,
+					"skipped":       true,
+					"reason":        reason,
+					"market_status": calendar.Status(time.Now()),
+					"message":       "Aggregation skipped outside market hours; pass force=true to run anyway",
+				})
+			}
+
+			mode := c.Query("mode", service.MarketAggregationModeSimple)
+			if !service.ValidMarketAggregationMode(mode) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "mode must be simple or detailed",
+				})
+			}
+
 			fetcher := service.NewFetcherService()
 			jobID := fmt.Sprintf("market-aggregation-%d", time.Now().UnixNano())
+			triggeredBy := ingestionTrigger(c)
 
 			// Start aggregation in background to avoid timeout
 			go func() {
 				ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
 				defer cancel()
-				_, err := fetcher.RunMarketAggregation(ctx)
+				ctx = service.WithIngestionTrigger(ctx, triggeredBy)
+				_, err := fetcher.RunMarketAggregation(ctx, mode)
 				if err != nil {
 					// Log error but don't block the response
 					fmt.Printf("Market aggregation error: %v\n", err)
@@ -180,6 +395,11 @@ func SetupRoutes(app *fiber.App) {
 					// Invalidate market statistics cache after aggregation
 					invalidator := caching.NewInvalidationService()
 					_ = invalidator.InvalidateMarketStatistics()
+					if mode == service.MarketAggregationModeDetailed {
+						// Detailed mode also refreshed company_info
+						_ = invalidator.InvalidateAllCompanyInfo()
+						_ = invalidator.InvalidateSymbolsDetailed()
+					}
 				}
 			}()
 
@@ -187,12 +407,13 @@ func SetupRoutes(app *fiber.App) {
 				"success":     true,
 				"job_id":      jobID,
 				"accepted_at": time.Now().UTC().Format(time.RFC3339),
+				"mode":        mode,
 				"message":     "Aggregation started in background",
 			})
 		})
 
-		// Market statistics end-of-day storage endpoint (public): trigger end-of-day storage (call at market close via external cron)
-		public.Post("/admin/market-statistics/store-eod", func(c *fiber.Ctx) error {
+		// Market statistics end-of-day storage endpoint (admin-only): trigger end-of-day storage (call at market close via external cron)
+		admin.Post("/market-statistics/store-eod", func(c *fiber.Ctx) error {
 			statsService := service.NewMarketStatisticsService()
 			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 			defer cancel()
@@ -217,31 +438,36 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
-		// Cache management endpoints (public admin)
-		// Manual persistence trigger
-		public.Post("/admin/cache/persist", func(c *fiber.Ctx) error {
-			persister := caching.NewPersister()
-			err := persister.TriggerManualPersistence()
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"success": false,
-					"error":   "Internal Server Error",
-					"message": err.Error(),
-				})
-			}
+		// Market statistics reset endpoint (admin-only): clear today's in-memory aggregation counts
+		admin.Post("/market-statistics/reset", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+			statsService.ResetCurrentDayStats()
+
+			invalidator := caching.NewInvalidationService()
+			_ = invalidator.InvalidateMarketStatistics()
 
 			return c.JSON(fiber.Map{
-				"success":      true,
-				"message":      "Persistence triggered successfully",
-				"triggered_at": time.Now().UTC().Format(time.RFC3339),
+				"success":     true,
+				"message":     "Daily market aggregator reset successfully",
+				"accepted_at": time.Now().UTC().Format(time.RFC3339),
 			})
 		})
 
-		// Refresh symbols cache
-		public.Post("/admin/cache/symbols/refresh", func(c *fiber.Ctx) error {
-			symbolCache := caching.NewSymbolCache()
-			err := symbolCache.RefreshSymbols()
+		// Market statistics seed endpoint (admin-only): load today's stored stats back into the in-memory aggregator
+		admin.Post("/market-statistics/seed", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			stats, err := statsService.SeedCurrentDayStats(ctx)
 			if err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "No stored market statistics for today",
+					})
+				}
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
 					"error":   "Internal Server Error",
@@ -249,107 +475,50 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			return c.JSON(fiber.Map{
-				"success":      true,
-				"message":      "Symbols cache refreshed successfully",
-				"refreshed_at": time.Now().UTC().Format(time.RFC3339),
-			})
-		})
-
-		// Cache statistics
-		public.Get("/admin/cache/stats", func(c *fiber.Ctx) error {
-			dataCache := caching.NewDataCache()
-
-			// Get key counts for each data type
-			historicalKeys, _ := dataCache.GetAllHistoricalKeys()
-			companyInfoKeys, _ := dataCache.GetAllCompanyInfoKeys()
-			fundamentalKeys, _ := dataCache.GetAllFundamentalDataKeys()
-			marketStatsKeys, _ := dataCache.GetAllMarketStatisticsKeys()
-
-			// Get symbol cache status
-			symbolCache := caching.NewSymbolCache()
-			symbols, err := symbolCache.GetAllSymbols()
-			symbolCount := 0
-			if err == nil {
-				symbolCount = len(symbols)
-			}
-
-			// Get persister status
-			persister := caching.NewPersister()
-			nextRunTime := persister.GetNextRunTime()
-			isRunning := persister.IsRunning()
+			invalidator := caching.NewInvalidationService()
+			_ = invalidator.InvalidateMarketStatistics()
 
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data": fiber.Map{
-					"symbols": fiber.Map{
-						"cached_count": symbolCount,
-					},
-					"historical_data": fiber.Map{
-						"cached_keys": len(historicalKeys),
-					},
-					"company_info": fiber.Map{
-						"cached_keys": len(companyInfoKeys),
-					},
-					"fundamental_data": fiber.Map{
-						"cached_keys": len(fundamentalKeys),
-					},
-					"market_statistics": fiber.Map{
-						"cached_keys": len(marketStatsKeys),
-					},
-					"persistence_worker": fiber.Map{
-						"running":       isRunning,
-						"next_run_time": nextRunTime.Format(time.RFC3339),
-					},
-					"total_cached_keys": len(historicalKeys) + len(companyInfoKeys) + len(fundamentalKeys) + len(marketStatsKeys),
-				},
+				"message": "Daily market aggregator seeded from stored statistics",
+				"data":    stats,
 			})
 		})
 
-		// Market statistics historical data endpoint (public): get historical market statistics for charting
-		public.Get("/market-statistics", func(c *fiber.Ctx) error {
-			statsService := service.NewMarketStatisticsService()
-			ctx := c.Context()
-
-			// Parse query parameters
-			startDateStr := c.Query("startDate")
-			endDateStr := c.Query("endDate")
-
-			// Default to last 30 days if not provided
-			startDate := time.Now().AddDate(0, 0, -30)
-			endDate := time.Now()
-
-			if startDateStr != "" {
-				if parsed, err := time.Parse("2006-01-02", startDateStr); err == nil {
-					startDate = parsed
-				}
+		// Market statistics backfill endpoint (admin-only): rebuild past daily breadth from stored daily bars
+		admin.Post("/market-statistics/backfill", func(c *fiber.Ctx) error {
+			from, err := time.Parse("2006-01-02", c.Query("from"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "from must be a date in YYYY-MM-DD format",
+				})
 			}
-			if endDateStr != "" {
-				if parsed, err := time.Parse("2006-01-02", endDateStr); err == nil {
-					endDate = parsed
+			to := time.Now()
+			if toStr := c.Query("to"); toStr != "" {
+				to, err = time.Parse("2006-01-02", toStr)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "to must be a date in YYYY-MM-DD format",
+					})
 				}
 			}
-
-			stats, err := statsService.GetHistoricalStats(ctx, startDate, endDate)
-			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			if to.Before(from) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
-					"error":   "Internal Server Error",
-					"message": err.Error(),
+					"error":   "Bad Request",
+					"message": "from must be on or before to",
 				})
 			}
 
-			return c.JSON(fiber.Map{
-				"success": true,
-				"data":    stats,
-			})
-		})
-
-		// Market statistics current day endpoint (public): get today's real-time aggregated stats
-		public.Get("/market-statistics/current", func(c *fiber.Ctx) error {
 			statsService := service.NewMarketStatisticsService()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
 
-			stats, err := statsService.GetCurrentDayStats()
+			stats, err := statsService.BackfillStats(ctx, from, to)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -358,18 +527,39 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			invalidator := caching.NewInvalidationService()
+			_ = invalidator.InvalidateMarketStatistics()
+
+			days := make([]fiber.Map, 0, len(stats))
+			for _, stat := range stats {
+				days = append(days, fiber.Map{
+					"date":            stat.Date.Format("2006-01-02"),
+					"stocksUp":        stat.StocksUp,
+					"stocksDown":      stat.StocksDown,
+					"stocksUnchanged": stat.StocksUnchanged,
+					"totalStocks":     stat.TotalStocks,
+				})
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data":    stats,
+				"data": fiber.Map{
+					"from":       from.Format("2006-01-02"),
+					"to":         to.Format("2006-01-02"),
+					"days":       days,
+					"days_count": len(days),
+				},
 			})
 		})
 
-		// Market statistics for frontend polling endpoint (public): returns advances, decliners, unchanged
-		// Frontend should poll this endpoint every 5 minutes to get real-time market statistics
-		public.Get("/market-statistics/live", func(c *fiber.Ctx) error {
-			statsService := service.NewMarketStatisticsService()
+		// Historical dedupe endpoint (admin-only): remove duplicate (symbol, epoch, range, interval) bars
+		// keeping the most recently updated row, and ensure the unique index exists
+		admin.Post("/historical/dedupe", func(c *fiber.Ctx) error {
+			historicalService := service.NewHistoricalService()
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
 
-			stats, err := statsService.GetMarketStatsForFrontend()
+			removed, err := historicalService.DedupeHistorical(ctx)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -378,9 +568,343 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if removed > 0 {
+				invalidator := caching.NewInvalidationService()
+				_ = invalidator.InvalidateAllHistorical()
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data":    stats,
+				"data": fiber.Map{
+					"duplicates_removed": removed,
+					"completed_at":       time.Now().UTC().Format(time.RFC3339),
+				},
+			})
+		})
+
+		// Historical prune endpoint (admin-only): hard-delete bars older than their retention
+		// ?interval= limits it to one interval; ?older_than= (e.g. 90d) overrides that interval's policy
+		admin.Post("/historical/prune", func(c *fiber.Ctx) error {
+			interval := c.Query("interval")
+			var olderThan time.Duration
+			if value := c.Query("older_than"); value != "" {
+				age, err := service.ParseRetentionAge(value)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				olderThan = age
+			}
+
+			historicalService := service.NewHistoricalService()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+
+			result, err := historicalService.PruneHistorical(ctx, interval, olderThan)
+			if result != nil && result.Removed > 0 {
+				invalidator := caching.NewInvalidationService()
+				_ = invalidator.InvalidateAllHistorical()
+			}
+			if err != nil {
+				if errors.Is(err, service.ErrNoRetentionPolicy) || err.Error() == "older_than requires an interval" {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+					"data":    result,
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    result,
+			})
+		})
+
+		// Close reconciliation report (admin-only): symbols whose 1m-aggregated close diverged from
+		// the upstream's reported daily close (requires RECONCILE_SCREENER_CLOSE)
+		admin.Get("/reconciliation/close", func(c *fiber.Ctx) error {
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    service.GetCloseReconciliationReport(),
+			})
+		})
+
+		// Maintenance mode (admin-only): when enabled, mutating protected routes return 503
+		admin.Get("/maintenance", func(c *fiber.Ctx) error {
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    service.GetMaintenanceState(),
+			})
+		})
+
+		admin.Put("/maintenance", func(c *fiber.Ctx) error {
+			var state service.MaintenanceState
+			if err := c.BodyParser(&state); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid request body",
+				})
+			}
+
+			updated, err := service.SetMaintenanceState(state)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    updated,
+			})
+		})
+
+		// Schema version endpoint (admin-only): current version and the status of each versioned migration
+		admin.Get("/schema-version", func(c *fiber.Ctx) error {
+			info, err := database.GetSchemaVersionInfo()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    info,
+			})
+		})
+
+		// Cache management endpoints (admin-only)
+		// Manual persistence trigger
+		admin.Post("/cache/persist", func(c *fiber.Ctx) error {
+			persister := caching.NewPersister()
+			err := persister.TriggerManualPersistence()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success":      true,
+				"message":      "Persistence triggered successfully",
+				"triggered_at": time.Now().UTC().Format(time.RFC3339),
+			})
+		})
+
+		// Refresh symbols cache
+		admin.Post("/cache/symbols/refresh", func(c *fiber.Ctx) error {
+			symbolCache := caching.NewSymbolCache()
+			err := symbolCache.RefreshSymbols()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success":      true,
+				"message":      "Symbols cache refreshed successfully",
+				"refreshed_at": time.Now().UTC().Format(time.RFC3339),
+			})
+		})
+
+		// Cache statistics
+		admin.Get("/cache/stats", func(c *fiber.Ctx) error {
+			dataCache := caching.NewDataCache()
+
+			// Get key counts for each data type
+			historicalKeys, _ := dataCache.GetAllHistoricalKeys()
+			companyInfoKeys, _ := dataCache.GetAllCompanyInfoKeys()
+			fundamentalKeys, _ := dataCache.GetAllFundamentalDataKeys()
+			marketStatsKeys, _ := dataCache.GetAllMarketStatisticsKeys()
+
+			// Get symbol cache status
+			symbolCache := caching.NewSymbolCache()
+			symbols, err := symbolCache.GetAllSymbols()
+			symbolCount := 0
+			if err == nil {
+				symbolCount = len(symbols)
+			}
+
+			// Get persister status
+			persister := caching.NewPersister()
+			nextRunTime := persister.GetNextRunTime()
+			isRunning := persister.IsRunning()
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbols": fiber.Map{
+						"cached_count": symbolCount,
+					},
+					"historical_data": fiber.Map{
+						"cached_keys": len(historicalKeys),
+					},
+					"company_info": fiber.Map{
+						"cached_keys": len(companyInfoKeys),
+					},
+					"fundamental_data": fiber.Map{
+						"cached_keys": len(fundamentalKeys),
+					},
+					"market_statistics": fiber.Map{
+						"cached_keys": len(marketStatsKeys),
+					},
+					"persistence_worker": fiber.Map{
+						"running":       isRunning,
+						"next_run_time": nextRunTime.Format(time.RFC3339),
+					},
+					"total_cached_keys": len(historicalKeys) + len(companyInfoKeys) + len(fundamentalKeys) + len(marketStatsKeys),
+				},
+			})
+		})
+
+		// Consolidated operations view (admin-only): symbols, latest ingestion runs, cache, table rows and aggregator state
+		// Sections load concurrently; one failing is reported under errors rather than failing the request
+		admin.Get("/stats", func(c *fiber.Ctx) error {
+			ctx, cancel := context.WithTimeout(c.Context(), 10*time.Second)
+			defer cancel()
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    service.GetAdminStats(ctx),
+			})
+		})
+
+		// Market status endpoint (public): current session (pre_market, open, after_hours, closed)
+		// from the market timezone and holiday calendar
+		public.Get("/market-status", func(c *fiber.Ctx) error {
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    service.DefaultMarketCalendar().Status(time.Now()),
+			})
+		})
+
+		// Market statistics historical data endpoint (public): get historical market statistics for charting
+		public.Get("/market-statistics", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+			ctx := c.Context()
+
+			// Parse query parameters
+			startDateStr := c.Query("startDate")
+			endDateStr := c.Query("endDate")
+
+			// Default to last 30 days if not provided
+			startDate := time.Now().AddDate(0, 0, -30)
+			endDate := time.Now()
+
+			if startDateStr != "" {
+				if parsed, err := time.Parse("2006-01-02", startDateStr); err == nil {
+					startDate = parsed
+				}
+			}
+			if endDateStr != "" {
+				if parsed, err := time.Parse("2006-01-02", endDateStr); err == nil {
+					endDate = parsed
+				}
+			}
+
+			stats, err := statsService.GetHistoricalStats(ctx, startDate, endDate)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    stats,
+			})
+		})
+
+		// Market statistics current day endpoint (public): get today's real-time aggregated stats
+		public.Get("/market-statistics/current", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+
+			stats, err := statsService.GetCurrentDayStats()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    stats,
+			})
+		})
+
+		// Market statistics for frontend polling endpoint (public): returns advances, decliners, unchanged
+		// Frontend should poll this endpoint every 5 minutes to get real-time market statistics
+		public.Get("/market-statistics/live", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+
+			stats, err := statsService.GetMarketStatsForFrontend()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    stats,
+			})
+		})
+
+		// Market breadth comparison endpoint (public): whole-market vs sector or market-cap bucket breadth
+		// Query: group_by=sector|market_cap (default: sector), value=Technology (optional, single group)
+		public.Get("/market-statistics/breadth", func(c *fiber.Ctx) error {
+			statsService := service.NewMarketStatisticsService()
+
+			groupBy := c.Query("group_by", service.BreadthGroupBySector)
+			value := strings.TrimSpace(c.Query("value"))
+
+			if groupBy != service.BreadthGroupBySector && groupBy != service.BreadthGroupByMarketCap {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "group_by must be one of: sector, market_cap",
+				})
+			}
+
+			breadth, err := statsService.GetBreadth(c.Context(), groupBy, value)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    breadth,
 			})
 		})
 
@@ -389,16 +913,861 @@ func SetupRoutes(app *fiber.App) {
 			resultType := c.Query("type")      // "inside_day", "high_volume_quarter", "high_volume_year", "high_volume_ever"
 			period := c.Query("period", "all") // "7d", "30d", "90d", "ytd", "all"
 
-			if resultType == "" {
+			if resultType == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "type query parameter is required",
+				})
+			}
+
+			historicalService := service.NewHistoricalService()
+			symbols, err := historicalService.GetScreenerResults(resultType, period)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbols": symbols,
+					"count":   len(symbols),
+					"type":    resultType,
+					"period":  period,
+				},
+			})
+		})
+
+		// ADR screening (public) - filter stocks by ADR% with configurable lookback
+		// Optional min_price / min_dollar_volume exclude illiquid names using the latest bar (both off by default)
+		public.Get("/adr-screen", screenLimit, func(c *fiber.Ctx) error {
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ADR))
+
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			var minADR, maxADR *float64
+			if minStr := c.Query("min_adr"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					minADR = &val
+				}
+			}
+			if maxStr := c.Query("max_adr"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					maxADR = &val
+				}
+			}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			adrService := indicatorsscreening.NewADRScreeningService()
+			results, err := adrService.ScreenADR(rangeParam, interval, lookback, minADR, maxADR, strict, liquidityFilterFromQuery(c), order)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			symbols := screenSymbols(results)
+			data := fiber.Map{
+				"symbols": symbols,
+				"count":   len(symbols),
+				"params": fiber.Map{
+					"range":    rangeParam,
+					"interval": interval,
+					"lookback": lookback,
+					"sort":     order.Sort,
+					"limit":    order.Limit,
+					"strict":   strict,
+					"min_adr":  minADR,
+					"max_adr":  maxADR,
+				},
+			}
+			// explain=true adds each matching symbol's computed ADR%
+			if c.QueryBool("explain") {
+				data["values"] = results
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    data,
+			})
+		})
+
+		// ATR screening (public) - filter stocks by ATR% with configurable lookback
+		// Optional min_price / min_dollar_volume exclude illiquid names using the latest bar (both off by default)
+		public.Get("/atr-screen", screenLimit, func(c *fiber.Ctx) error {
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ATR))
+
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			var minATR, maxATR *float64
+			if minStr := c.Query("min_atr"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					minATR = &val
+				}
+			}
+			if maxStr := c.Query("max_atr"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					maxATR = &val
+				}
+			}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			atrService := indicatorsscreening.NewATRScreeningService()
+			results, err := atrService.ScreenATR(rangeParam, interval, lookback, minATR, maxATR, strict, liquidityFilterFromQuery(c), order)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			symbols := screenSymbols(results)
+			data := fiber.Map{
+				"symbols": symbols,
+				"count":   len(symbols),
+				"params": fiber.Map{
+					"range":    rangeParam,
+					"interval": interval,
+					"lookback": lookback,
+					"sort":     order.Sort,
+					"limit":    order.Limit,
+					"strict":   strict,
+					"min_atr":  minATR,
+					"max_atr":  maxATR,
+				},
+			}
+			// explain=true adds each matching symbol's computed ATR%
+			if c.QueryBool("explain") {
+				data["values"] = results
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    data,
+			})
+		})
+
+		// Get ADR% for a specific stock (public)
+		public.Get("/adr", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ADR))
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval are required",
+				})
+			}
+
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			adrService := indicatorsscreening.NewADRScreeningService()
+			adrPercent, err := adrService.GetADRForSymbol(symbol, rangeParam, interval, lookback, strict)
+			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbol":      symbol,
+					"adr_percent": adrPercent,
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   strict,
+					},
+				},
+			})
+		})
+
+		// Get absolute ADR in dollars for a specific stock (public): /adr-dollars?symbol=AAPL&range=1y&interval=1d&lookback=14
+		// Returns SMA(high-low, lookback) in dollars alongside ADR% for convenience
+		public.Get("/adr-dollars", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ADR))
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval are required",
+				})
+			}
+
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			adrService := indicatorsscreening.NewADRScreeningService()
+			adr, err := adrService.GetADRDollarsForSymbol(symbol, rangeParam, interval, lookback, strict)
+			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbol":      symbol,
+					"adr_dollars": adr.Dollars,
+					"adr_percent": adr.Percent,
+					"last_close":  adr.LastClose,
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   strict,
+					},
+				},
+			})
+		})
+
+		// Generic registry-backed indicator for a specific stock (public): /indicator/adr?symbol=AAPL&range=1y&interval=1d
+		// Lookback defaults to the indicator's own default
+		public.Get("/indicator/:name", func(c *fiber.Ctx) error {
+			name := c.Params("name")
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+
+			indicator, err := indicatorsscreening.GetIndicator(name)
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"success": false,
+					"error":   "Not Found",
+					"message": err.Error(),
+				})
+			}
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval are required",
+				})
+			}
+
+			lookback, err := strconv.Atoi(c.Query("lookback", strconv.Itoa(indicator.DefaultLookback())))
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			params := indicatorsscreening.IndicatorParams{Lookback: lookback, Strict: c.QueryBool("strict", true)}
+			indicatorService := indicatorsscreening.NewIndicatorService()
+			value, err := indicatorService.ComputeForSymbol(name, symbol, rangeParam, interval, params)
+			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbol":    symbol,
+					"indicator": indicator.Name(),
+					"value":     value,
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   params.Strict,
+					},
+				},
+			})
+		})
+
+		// Generic registry-backed indicator screening (public): /indicator/atr/screen?range=1y&interval=1d&min=2&max=5
+		// Add async=true to run it as a background job (see /screen-jobs/:id)
+		public.Get("/indicator/:name/screen", screenLimit, func(c *fiber.Ctx) error {
+			name := c.Params("name")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+
+			indicator, err := indicatorsscreening.GetIndicator(name)
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"success": false,
+					"error":   "Not Found",
+					"message": err.Error(),
+				})
+			}
+
+			if rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "range and interval are required",
+				})
+			}
+
+			lookback, err := strconv.Atoi(c.Query("lookback", strconv.Itoa(indicator.DefaultLookback())))
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			var minValue, maxValue *float64
+			if minStr := c.Query("min"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					minValue = &val
+				}
+			}
+			if maxStr := c.Query("max"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					maxValue = &val
+				}
+			}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			params := indicatorsscreening.IndicatorParams{
+				Lookback:  lookback,
+				Strict:    c.QueryBool("strict", true),
+				Liquidity: liquidityFilterFromQuery(c),
+				Order:     order,
+			}
+
+			// async=true queues the scan; poll /screen-jobs/:id for the result. Identical screens
+			// within the job TTL return the existing job (with results once completed).
+			if c.QueryBool("async") {
+				job, err := indicatorsscreening.EnqueueScreenJob(indicatorsscreening.ScreenJobRequest{
+					Indicator: indicator.Name(),
+					Range:     rangeParam,
+					Interval:  interval,
+					Lookback:  lookback,
+					Strict:    params.Strict,
+					Min:       minValue,
+					Max:       maxValue,
+					Liquidity: params.Liquidity,
+					Order:     params.Order,
+				})
+				if err != nil {
+					if errors.Is(err, indicatorsscreening.ErrScreenQueueFull) {
+						c.Set(fiber.HeaderRetryAfter, "5")
+						return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+							"success": false,
+							"error":   "Service Unavailable",
+							"message": err.Error(),
+						})
+					}
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"success": false,
+						"error":   "Internal Server Error",
+						"message": err.Error(),
+					})
+				}
+
+				status := fiber.StatusAccepted
+				if job.Status == indicatorsscreening.ScreenJobCompleted {
+					status = fiber.StatusOK
+				}
+				return c.Status(status).JSON(fiber.Map{
+					"success": true,
+					"data":    job,
+				})
+			}
+
+			indicatorService := indicatorsscreening.NewIndicatorService()
+			results, err := indicatorService.Screen(name, rangeParam, interval, params, minValue, maxValue)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"indicator": indicator.Name(),
+					"results":   results,
+					"count":     len(results),
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   params.Strict,
+						"min":      minValue,
+						"max":      maxValue,
+						"sort":     order.Sort,
+						"limit":    order.Limit,
+					},
+				},
+			})
+		})
+
+		// Get the status (and results, once completed) of an async screen job (public)
+		public.Get("/screen-jobs/:id", func(c *fiber.Ctx) error {
+			job, err := indicatorsscreening.GetScreenJob(c.Params("id"))
+			if err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "Screen job not found or expired",
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    job,
+			})
+		})
+
+		// Backtest a threshold entry rule for a registered indicator (public)
+		// Body: {"symbols": ["AAPL"], "indicator": "rsi", "condition": "below", "threshold": 30, "holding_period": 5}
+		// range/interval default to 10y/1d; lookback defaults to the indicator's default
+		public.Post("/backtest", func(c *fiber.Ctx) error {
+			var req indicatorsscreening.BacktestRequest
+			if err := c.BodyParser(&req); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid request body",
+				})
+			}
+			if req.Range == "" {
+				req.Range = "10y"
+			}
+			if req.Interval == "" {
+				req.Interval = "1d"
+			}
+
+			if _, err := indicatorsscreening.GetIndicator(req.Indicator); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": fmt.Sprintf("%s (available: %s)", err.Error(), strings.Join(indicatorsscreening.IndicatorNames(), ", ")),
+				})
+			}
+
+			var validationErr string
+			switch {
+			case len(req.Symbols) == 0:
+				validationErr = "at least one symbol is required"
+			case len(req.Symbols) > indicatorsscreening.MaxBacktestSymbols:
+				validationErr = fmt.Sprintf("too many symbols: maximum is %d", indicatorsscreening.MaxBacktestSymbols)
+			case req.Condition != "above" && req.Condition != "below":
+				validationErr = "condition must be above or below"
+			case req.HoldingPeriod <= 0:
+				validationErr = "holding_period must be a positive integer"
+			case req.Lookback < 0:
+				validationErr = "lookback must be a positive integer"
+			}
+			if validationErr != "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": validationErr,
+				})
+			}
+
+			indicatorService := indicatorsscreening.NewIndicatorService()
+			result, err := indicatorService.Backtest(req)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    result,
+			})
+		})
+
+		// Get a full indicator snapshot for a specific stock (public)
+		// ATR, ADR and volume SMA lookbacks default to DEFAULT_ATR/ADR/VOLUME_LOOKBACK; MA to 50 bars
+		public.Get("/indicators", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval are required",
+				})
+			}
+
+			lookbacks := indicatorsscreening.SnapshotLookbacks()
+			lookbackParams := []struct {
+				name string
+				dest *int
+			}{
+				{"atr_lookback", &lookbacks.ATR},
+				{"adr_lookback", &lookbacks.ADR},
+				{"volume_sma_lookback", &lookbacks.VolumeSMA},
+				{"ma_lookback", &lookbacks.MA},
+			}
+			for _, param := range lookbackParams {
+				if val := c.Query(param.name); val != "" {
+					lookback, err := strconv.Atoi(val)
+					if err != nil || lookback <= 0 {
+						return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+							"success": false,
+							"error":   "Bad Request",
+							"message": fmt.Sprintf("%s must be a positive integer", param.name),
+						})
+					}
+					*param.dest = lookback
+				}
+			}
+
+			calcService := indicatorscalculations.NewIndicatorCalculationService()
+			snapshot, err := calcService.ComputeIndicators(symbol, rangeParam, interval, lookbacks)
+			if err != nil {
+				if err.Error() == "no historical data" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": fmt.Sprintf("No historical data for %s (%s/%s)", symbol, rangeParam, interval),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"snapshot": snapshot,
+					"params": fiber.Map{
+						"range":               rangeParam,
+						"interval":            interval,
+						"atr_lookback":        lookbacks.ATR,
+						"adr_lookback":        lookbacks.ADR,
+						"volume_sma_lookback": lookbacks.VolumeSMA,
+						"ma_lookback":         lookbacks.MA,
+					},
+				},
+			})
+		})
+
+		// Get indicator snapshots for multiple stocks sharing range/interval/lookbacks (public)
+		// Symbols without data are reported under "skipped" instead of failing the request
+		public.Post("/indicators/batch", func(c *fiber.Ctx) error {
+			var request struct {
+				Symbols           []string `json:"symbols"`
+				Range             string   `json:"range"`
+				Interval          string   `json:"interval"`
+				ATRLookback       *int     `json:"atr_lookback"`
+				ADRLookback       *int     `json:"adr_lookback"`
+				VolumeSMALookback *int     `json:"volume_sma_lookback"`
+				MALookback        *int     `json:"ma_lookback"`
+			}
+
+			if err := c.BodyParser(&request); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid request body",
+				})
+			}
+
+			if len(request.Symbols) == 0 || request.Range == "" || request.Interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbols, range, and interval are required",
+				})
+			}
+
+			symbols, err := service.NormalizeFilterSymbols(request.Symbols)
+			if err == nil && len(symbols) > 100 {
+				err = fmt.Errorf("too many symbols: %d (max 100)", len(symbols))
+			}
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			lookbacks := indicatorsscreening.SnapshotLookbacks()
+			lookbackParams := []struct {
+				name  string
+				value *int
+				dest  *int
+			}{
+				{"atr_lookback", request.ATRLookback, &lookbacks.ATR},
+				{"adr_lookback", request.ADRLookback, &lookbacks.ADR},
+				{"volume_sma_lookback", request.VolumeSMALookback, &lookbacks.VolumeSMA},
+				{"ma_lookback", request.MALookback, &lookbacks.MA},
+			}
+			for _, param := range lookbackParams {
+				if param.value == nil {
+					continue
+				}
+				if *param.value <= 0 {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": fmt.Sprintf("%s must be a positive integer", param.name),
+					})
+				}
+				*param.dest = *param.value
+			}
+
+			calcService := indicatorscalculations.NewIndicatorCalculationService()
+			snapshots, skipped, err := calcService.ComputeIndicatorsBatch(symbols, request.Range, request.Interval, lookbacks)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"snapshots": snapshots,
+					"skipped":   skipped,
+					"count":     len(snapshots),
+					"params": fiber.Map{
+						"range":               request.Range,
+						"interval":            request.Interval,
+						"atr_lookback":        lookbacks.ATR,
+						"adr_lookback":        lookbacks.ADR,
+						"volume_sma_lookback": lookbacks.VolumeSMA,
+						"ma_lookback":         lookbacks.MA,
+					},
+				},
+			})
+		})
+
+		// Get ATR% for a specific stock (public)
+		public.Get("/atr", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ATR))
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval are required",
+				})
+			}
+
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			atrService := indicatorsscreening.NewATRScreeningService()
+			atrPercent, err := atrService.GetATRForSymbol(symbol, rangeParam, interval, lookback, strict)
+			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbol":      symbol,
+					"atr_percent": atrPercent,
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   strict,
+					},
+				},
+			})
+		})
+
+		// Keltner Channel screening (public): symbols closing above the upper or below the lower band
+		public.Get("/keltner-screen", screenLimit, func(c *fiber.Ctx) error {
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			position := c.Query("position")
+
+			if rangeParam == "" || interval == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "type query parameter is required",
+					"message": "range and interval are required",
 				})
 			}
 
-			historicalService := service.NewHistoricalService()
-			symbols, err := historicalService.GetScreenerResults(resultType, period)
+			if position != "above" && position != "below" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "position must be above or below",
+				})
+			}
+
+			emaLookback, err := strconv.Atoi(c.Query("ema_lookback", strconv.Itoa(lookbacks.KeltnerEMA)))
+			if err != nil || emaLookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "ema_lookback must be a positive integer",
+				})
+			}
+
+			atrLookback, err := strconv.Atoi(c.Query("atr_lookback", strconv.Itoa(lookbacks.KeltnerATR)))
+			if err != nil || atrLookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "atr_lookback must be a positive integer",
+				})
+			}
+
+			multiplier, err := strconv.ParseFloat(c.Query("multiplier", "2"), 64)
+			if err != nil || multiplier <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "multiplier must be a positive number",
+				})
+			}
+
+			keltnerService := indicatorsscreening.NewKeltnerScreeningService()
+			results, err := keltnerService.GetSymbolsByKeltner(rangeParam, interval, emaLookback, atrLookback, multiplier, position, liquidityFilterFromQuery(c))
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -410,19 +1779,126 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data": fiber.Map{
-					"symbols": symbols,
-					"count":   len(symbols),
-					"type":    resultType,
-					"period":  period,
+					"results": results,
+					"count":   len(results),
+					"params": fiber.Map{
+						"range":        rangeParam,
+						"interval":     interval,
+						"position":     position,
+						"ema_lookback": emaLookback,
+						"atr_lookback": atrLookback,
+						"multiplier":   multiplier,
+					},
 				},
 			})
 		})
 
-		// ADR screening (public) - filter stocks by ADR% with configurable lookback
-		public.Get("/adr-screen", func(c *fiber.Ctx) error {
+		// Stochastic oscillator screening (public): overbought (%K > threshold, default 80)
+		// or oversold (%K < threshold, default 20) symbols
+		public.Get("/stochastic-screen", screenLimit, func(c *fiber.Ctx) error {
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+			condition := c.Query("condition")
+
+			if rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "range and interval are required",
+				})
+			}
+
+			defaultThreshold := "80"
+			switch condition {
+			case "overbought":
+			case "oversold":
+				defaultThreshold = "20"
+			default:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "condition must be overbought or oversold",
+				})
+			}
+
+			kLookback, err := strconv.Atoi(c.Query("k_lookback", strconv.Itoa(lookbacks.StochasticK)))
+			if err != nil || kLookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "k_lookback must be a positive integer",
+				})
+			}
+
+			dSmoothing, err := strconv.Atoi(c.Query("d_smoothing", strconv.Itoa(lookbacks.StochasticD)))
+			if err != nil || dSmoothing <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "d_smoothing must be a positive integer",
+				})
+			}
+
+			threshold, err := strconv.ParseFloat(c.Query("threshold", defaultThreshold), 64)
+			if err != nil || threshold < 0 || threshold > 100 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "threshold must be a number between 0 and 100",
+				})
+			}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			stochasticService := indicatorsscreening.NewStochasticScreeningService()
+			results, err := stochasticService.GetSymbolsByStochastic(rangeParam, interval, kLookback, dSmoothing, condition, threshold, liquidityFilterFromQuery(c), order)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"results": results,
+					"count":   len(results),
+					"params": fiber.Map{
+						"range":       rangeParam,
+						"interval":    interval,
+						"condition":   condition,
+						"k_lookback":  kLookback,
+						"d_smoothing": dSmoothing,
+						"threshold":   threshold,
+						"sort":        order.Sort,
+						"limit":       order.Limit,
+					},
+				},
+			})
+		})
+
+		// Commodity Channel Index screening (public)
+		public.Get("/cci-screen", screenLimit, func(c *fiber.Ctx) error {
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "14") // default 14 days
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.CCI))
+
+			if rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "range and interval are required",
+				})
+			}
 
 			lookback, err := strconv.Atoi(lookbackStr)
 			if err != nil || lookback <= 0 {
@@ -433,20 +1909,29 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			var minADR, maxADR *float64
-			if minStr := c.Query("min_adr"); minStr != "" {
+			var minCCI, maxCCI *float64
+			if minStr := c.Query("min"); minStr != "" {
 				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
-					minADR = &val
+					minCCI = &val
 				}
 			}
-			if maxStr := c.Query("max_adr"); maxStr != "" {
+			if maxStr := c.Query("max"); maxStr != "" {
 				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
-					maxADR = &val
+					maxCCI = &val
 				}
 			}
 
-			adrService := indicatorsscreening.NewADRScreeningService()
-			symbols, err := adrService.GetSymbolsByADR(rangeParam, interval, lookback, minADR, maxADR)
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			cciService := indicatorsscreening.NewCCIScreeningService()
+			results, err := cciService.GetSymbolsByCCI(rangeParam, interval, lookback, minCCI, maxCCI, liquidityFilterFromQuery(c), order)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -458,24 +1943,49 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data": fiber.Map{
-					"symbols": symbols,
-					"count":   len(symbols),
+					"results": results,
+					"count":   len(results),
 					"params": fiber.Map{
 						"range":    rangeParam,
 						"interval": interval,
 						"lookback": lookback,
-						"min_adr":  minADR,
-						"max_adr":  maxADR,
+						"min":      minCCI,
+						"max":      maxCCI,
+						"sort":     order.Sort,
+						"limit":    order.Limit,
 					},
 				},
 			})
 		})
 
-		// ATR screening (public) - filter stocks by ATR% with configurable lookback
-		public.Get("/atr-screen", func(c *fiber.Ctx) error {
+		// Money Flow Index screening (public): overbought (MFI > threshold, default 80)
+		// or oversold (MFI < threshold, default 20) symbols
+		public.Get("/mfi-screen", screenLimit, func(c *fiber.Ctx) error {
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "14") // default 14 days
+			condition := c.Query("condition")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.MFI))
+
+			if rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "range and interval are required",
+				})
+			}
+
+			defaultThreshold := "80"
+			switch condition {
+			case "overbought":
+			case "oversold":
+				defaultThreshold = "20"
+			default:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "condition must be overbought or oversold",
+				})
+			}
 
 			lookback, err := strconv.Atoi(lookbackStr)
 			if err != nil || lookback <= 0 {
@@ -486,20 +1996,26 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			var minATR, maxATR *float64
-			if minStr := c.Query("min_atr"); minStr != "" {
-				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
-					minATR = &val
-				}
+			threshold, err := strconv.ParseFloat(c.Query("threshold", defaultThreshold), 64)
+			if err != nil || threshold < 0 || threshold > 100 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "threshold must be a number between 0 and 100",
+				})
 			}
-			if maxStr := c.Query("max_atr"); maxStr != "" {
-				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
-					maxATR = &val
-				}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
 			}
 
-			atrService := indicatorsscreening.NewATRScreeningService()
-			symbols, err := atrService.GetSymbolsByATR(rangeParam, interval, lookback, minATR, maxATR)
+			mfiService := indicatorsscreening.NewMFIScreeningService()
+			results, err := mfiService.GetSymbolsByMFI(rangeParam, interval, lookback, condition, threshold, liquidityFilterFromQuery(c), order)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -511,31 +2027,47 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data": fiber.Map{
-					"symbols": symbols,
-					"count":   len(symbols),
+					"results": results,
+					"count":   len(results),
 					"params": fiber.Map{
-						"range":    rangeParam,
-						"interval": interval,
-						"lookback": lookback,
-						"min_atr":  minATR,
-						"max_atr":  maxATR,
+						"range":     rangeParam,
+						"interval":  interval,
+						"condition": condition,
+						"lookback":  lookback,
+						"threshold": threshold,
+						"sort":      order.Sort,
+						"limit":     order.Limit,
 					},
 				},
 			})
 		})
 
-		// Get ADR% for a specific stock (public)
-		public.Get("/adr", func(c *fiber.Ctx) error {
-			symbol := c.Query("symbol")
+		// Williams %R screening (public): overbought (%R > threshold, default -20)
+		// or oversold (%R < threshold, default -80) symbols
+		public.Get("/williams-r-screen", screenLimit, func(c *fiber.Ctx) error {
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "14")
+			condition := c.Query("condition")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.WilliamsR))
 
-			if symbol == "" || rangeParam == "" || interval == "" {
+			if rangeParam == "" || interval == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "symbol, range, and interval are required",
+					"message": "range and interval are required",
+				})
+			}
+
+			defaultThreshold := "-20"
+			switch condition {
+			case "overbought":
+			case "oversold":
+				defaultThreshold = "-80"
+			default:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "condition must be overbought or oversold",
 				})
 			}
 
@@ -547,9 +2079,30 @@ func SetupRoutes(app *fiber.App) {
 					"message": "lookback must be a positive integer",
 				})
 			}
+			if lookback > indicatorsscreening.MaxWilliamsRLookback {
+				lookback = indicatorsscreening.MaxWilliamsRLookback
+			}
 
-			adrService := indicatorsscreening.NewADRScreeningService()
-			adrPercent, err := adrService.GetADRForSymbol(symbol, rangeParam, interval, lookback)
+			threshold, err := strconv.ParseFloat(c.Query("threshold", defaultThreshold), 64)
+			if err != nil || threshold < -100 || threshold > 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "threshold must be a number between -100 and 0",
+				})
+			}
+
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			williamsService := indicatorsscreening.NewWilliamsRScreeningService()
+			results, err := williamsService.GetSymbolsByWilliamsR(rangeParam, interval, lookback, condition, threshold, liquidityFilterFromQuery(c), order)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -561,23 +2114,30 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data": fiber.Map{
-					"symbol":      symbol,
-					"adr_percent": adrPercent,
+					"results": results,
+					"count":   len(results),
 					"params": fiber.Map{
-						"range":    rangeParam,
-						"interval": interval,
-						"lookback": lookback,
+						"range":     rangeParam,
+						"interval":  interval,
+						"condition": condition,
+						"lookback":  lookback,
+						"threshold": threshold,
+						"sort":      order.Sort,
+						"limit":     order.Limit,
 					},
 				},
 			})
 		})
 
-		// Get ATR% for a specific stock (public)
-		public.Get("/atr", func(c *fiber.Ctx) error {
+		// Get an ATR-based trailing stop for a specific stock (public)
+		// Query: side=long|short (default: long), multiplier (default: 3)
+		public.Get("/atr-stop", func(c *fiber.Ctx) error {
 			symbol := c.Query("symbol")
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "14")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.ATR))
+			multiplierStr := c.Query("multiplier", "3")
+			side := c.Query("side", "long")
 
 			if symbol == "" || rangeParam == "" || interval == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -587,18 +2147,90 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			lookback, err := strconv.Atoi(lookbackStr)
-			if err != nil || lookback <= 0 {
+			lookback, err := strconv.Atoi(lookbackStr)
+			if err != nil || lookback <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "lookback must be a positive integer",
+				})
+			}
+
+			multiplier, err := strconv.ParseFloat(multiplierStr, 64)
+			if err != nil || multiplier <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "multiplier must be a positive number",
+				})
+			}
+
+			if side != "long" && side != "short" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "side must be long or short",
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
+			atrService := indicatorsscreening.NewATRScreeningService()
+			atrStop, err := atrService.GetATRStopForSymbol(symbol, rangeParam, interval, lookback, multiplier, side, strict)
+			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbol":     atrStop.Symbol,
+					"side":       atrStop.Side,
+					"close":      atrStop.Close,
+					"atr":        atrStop.ATR,
+					"multiplier": atrStop.Multiplier,
+					"stop":       atrStop.Stop,
+					"params": fiber.Map{
+						"range":    rangeParam,
+						"interval": interval,
+						"lookback": lookback,
+						"strict":   strict,
+					},
+				},
+			})
+		})
+
+		// Get classic pivot point levels for a specific stock from its previous daily bar (public)
+		public.Get("/pivot-points", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			if symbol == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "lookback must be a positive integer",
+					"message": "symbol is required",
 				})
 			}
 
-			atrService := indicatorsscreening.NewATRScreeningService()
-			atrPercent, err := atrService.GetATRForSymbol(symbol, rangeParam, interval, lookback)
+			pivotService := indicatorsscreening.NewPivotPointsService()
+			levels, err := pivotService.GetPivotPointsForSymbol(symbol)
 			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
 					"error":   "Internal Server Error",
@@ -608,23 +2240,15 @@ func SetupRoutes(app *fiber.App) {
 
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data": fiber.Map{
-					"symbol":      symbol,
-					"atr_percent": atrPercent,
-					"params": fiber.Map{
-						"range":    rangeParam,
-						"interval": interval,
-						"lookback": lookback,
-					},
-				},
+				"data":    levels,
 			})
 		})
 
 		// Average volume in dollars screening (public)
-		public.Get("/avg-volume-dollars-screen", func(c *fiber.Ctx) error {
+		public.Get("/avg-volume-dollars-screen", screenLimit, func(c *fiber.Ctx) error {
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "50")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.Volume))
 
 			lookback, err := strconv.Atoi(lookbackStr)
 			if err != nil || lookback <= 0 {
@@ -647,8 +2271,18 @@ func SetupRoutes(app *fiber.App) {
 				}
 			}
 
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
 			volumeService := indicatorsscreening.NewVolumeScreeningService()
-			symbols, err := volumeService.GetSymbolsByAvgVolumeDollars(rangeParam, interval, lookback, minVolDollarsM, maxVolDollarsM)
+			results, err := volumeService.ScreenAvgVolumeDollars(rangeParam, interval, lookback, minVolDollarsM, maxVolDollarsM, strict, liquidityFilterFromQuery(c), order)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -657,27 +2291,37 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			symbols := screenSymbols(results)
+			data := fiber.Map{
+				"symbols": symbols,
+				"count":   len(symbols),
+				"params": fiber.Map{
+					"range":             rangeParam,
+					"interval":          interval,
+					"lookback":          lookback,
+					"sort":              order.Sort,
+					"limit":             order.Limit,
+					"min_vol_dollars_m": minVolDollarsM,
+					"max_vol_dollars_m": maxVolDollarsM,
+					"strict":            strict,
+				},
+			}
+			// explain=true adds each matching symbol's computed average dollar volume ($M)
+			if c.QueryBool("explain") {
+				data["values"] = results
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data": fiber.Map{
-					"symbols": symbols,
-					"count":   len(symbols),
-					"params": fiber.Map{
-						"range":             rangeParam,
-						"interval":          interval,
-						"lookback":          lookback,
-						"min_vol_dollars_m": minVolDollarsM,
-						"max_vol_dollars_m": maxVolDollarsM,
-					},
-				},
+				"data":    data,
 			})
 		})
 
 		// Average volume in percent screening (public)
-		public.Get("/avg-volume-percent-screen", func(c *fiber.Ctx) error {
+		public.Get("/avg-volume-percent-screen", screenLimit, func(c *fiber.Ctx) error {
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "50")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.Volume))
 
 			lookback, err := strconv.Atoi(lookbackStr)
 			if err != nil || lookback <= 0 {
@@ -700,8 +2344,18 @@ func SetupRoutes(app *fiber.App) {
 				}
 			}
 
+			order, err := resultOrderFromQuery(c)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			strict := c.QueryBool("strict", true)
 			volumeService := indicatorsscreening.NewVolumeScreeningService()
-			symbols, err := volumeService.GetSymbolsByAvgVolumePercent(rangeParam, interval, lookback, minVolPercent, maxVolPercent)
+			results, err := volumeService.ScreenAvgVolumePercent(rangeParam, interval, lookback, minVolPercent, maxVolPercent, strict, liquidityFilterFromQuery(c), order)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -710,19 +2364,29 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			symbols := screenSymbols(results)
+			data := fiber.Map{
+				"symbols": symbols,
+				"count":   len(symbols),
+				"params": fiber.Map{
+					"range":           rangeParam,
+					"interval":        interval,
+					"lookback":        lookback,
+					"sort":            order.Sort,
+					"limit":           order.Limit,
+					"min_vol_percent": minVolPercent,
+					"max_vol_percent": maxVolPercent,
+					"strict":          strict,
+				},
+			}
+			// explain=true adds each matching symbol's computed volume %
+			if c.QueryBool("explain") {
+				data["values"] = results
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data": fiber.Map{
-					"symbols": symbols,
-					"count":   len(symbols),
-					"params": fiber.Map{
-						"range":           rangeParam,
-						"interval":        interval,
-						"lookback":        lookback,
-						"min_vol_percent": minVolPercent,
-						"max_vol_percent": maxVolPercent,
-					},
-				},
+				"data":    data,
 			})
 		})
 
@@ -731,7 +2395,7 @@ func SetupRoutes(app *fiber.App) {
 			symbol := c.Query("symbol")
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "50")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.Volume))
 
 			if symbol == "" || rangeParam == "" || interval == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -750,9 +2414,17 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			strict := c.QueryBool("strict", true)
 			volumeService := indicatorsscreening.NewVolumeScreeningService()
-			avgVolDollarsM, err := volumeService.GetAvgVolumeDollarsForSymbol(symbol, rangeParam, interval, lookback)
+			avgVolDollarsM, err := volumeService.GetAvgVolumeDollarsForSymbol(symbol, rangeParam, interval, lookback, strict)
 			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
 					"error":   "Internal Server Error",
@@ -769,6 +2441,7 @@ func SetupRoutes(app *fiber.App) {
 						"range":    rangeParam,
 						"interval": interval,
 						"lookback": lookback,
+						"strict":   strict,
 					},
 				},
 			})
@@ -779,7 +2452,7 @@ func SetupRoutes(app *fiber.App) {
 			symbol := c.Query("symbol")
 			rangeParam := c.Query("range")
 			interval := c.Query("interval")
-			lookbackStr := c.Query("lookback", "50")
+			lookbackStr := c.Query("lookback", strconv.Itoa(lookbacks.Volume))
 
 			if symbol == "" || rangeParam == "" || interval == "" {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -798,9 +2471,17 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			strict := c.QueryBool("strict", true)
 			volumeService := indicatorsscreening.NewVolumeScreeningService()
-			volPercent, err := volumeService.GetAvgVolumePercentForSymbol(symbol, rangeParam, interval, lookback)
+			volPercent, err := volumeService.GetAvgVolumePercentForSymbol(symbol, rangeParam, interval, lookback, strict)
 			if err != nil {
+				if errors.Is(err, indicatorscalculations.ErrInsufficientData) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
 					"error":   "Internal Server Error",
@@ -817,7 +2498,89 @@ func SetupRoutes(app *fiber.App) {
 						"range":    rangeParam,
 						"interval": interval,
 						"lookback": lookback,
+						"strict":   strict,
+					},
+				},
+			})
+		})
+
+		// Symbol universe endpoint (public): paginated screener symbols with sector, industry and market-cap bucket
+		public.Get("/symbols/detailed", func(c *fiber.Ctx) error {
+			pagination := ParsePagination(c, service.DefaultCompanyInfoPageLimit, service.MaxCompanyInfoPageLimit)
+
+			result, err := screenerService.GetSymbolsDetailed(&pagination)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+			setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    result,
+			})
+		})
+
+		// Symbol universe endpoint (public): full list, or only symbols changed since a timestamp
+		// Query: updated_since=RFC3339 timestamp or unix seconds (optional)
+		// Clients should pass the returned as_of value as updated_since on their next poll
+		public.Get("/symbols", func(c *fiber.Ctx) error {
+			symbolCache := caching.NewSymbolCache()
+			asOf := time.Now().UTC()
+
+			updatedSinceStr := c.Query("updated_since")
+			if updatedSinceStr == "" {
+				symbols, err := symbolCache.GetAllSymbols()
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"success": false,
+						"error":   "Internal Server Error",
+						"message": err.Error(),
+					})
+				}
+
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data": fiber.Map{
+						"symbols": symbols,
+						"count":   len(symbols),
+						"as_of":   asOf.Format(time.RFC3339),
 					},
+				})
+			}
+
+			var updatedSince time.Time
+			if parsed, err := time.Parse(time.RFC3339, updatedSinceStr); err == nil {
+				updatedSince = parsed
+			} else if seconds, err := strconv.ParseInt(updatedSinceStr, 10, 64); err == nil {
+				updatedSince = time.Unix(seconds, 0)
+			} else {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "updated_since must be an RFC3339 timestamp or unix seconds",
+				})
+			}
+
+			symbols, err := symbolCache.GetSymbolsUpdatedSince(updatedSince)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbols":       symbols,
+					"count":         len(symbols),
+					"updated_since": updatedSince.UTC().Format(time.RFC3339),
+					"as_of":         asOf.Format(time.RFC3339),
 				},
 			})
 		})
@@ -825,7 +2588,48 @@ func SetupRoutes(app *fiber.App) {
 		// Company Info routes (public, read-only)
 		// Get all company info
 		public.Get("/company-info", func(c *fiber.Ctx) error {
-			companyInfo, err := companyInfoService.GetAllCompanyInfo()
+			pagination := ParsePagination(c, service.DefaultCompanyInfoPageLimit, service.MaxCompanyInfoPageLimit)
+
+			// Optional ?fields=symbol,price,marketCap projection (no ETag for projected pages)
+			fields, err := service.ParseFields(c.Query("fields"), service.CompanyInfoFields)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+			if len(fields) > 0 {
+				result, err := companyInfoService.GetAllCompanyInfoFields(&pagination, fields)
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"success": false,
+						"error":   "Internal Server Error",
+						"message": err.Error(),
+					})
+				}
+				setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data": fiber.Map{
+						"data":        service.ProjectFields(result.Data, fields),
+						"page":        result.Page,
+						"limit":       result.Limit,
+						"total":       result.Total,
+						"total_pages": result.TotalPages,
+					},
+					"meta": freshnessMeta(latestCompanyInfoUpdate(result.Data)),
+				})
+			}
+
+			// Short-circuit with 304 if the client already has the cached payload
+			if etag, found := companyInfoService.GetAllCompanyInfoETag(&pagination); found && etagMatches(c, etag) {
+				c.Set(fiber.HeaderETag, etag)
+				return c.SendStatus(fiber.StatusNotModified)
+			}
+
+			result, err := companyInfoService.GetAllCompanyInfo(&pagination)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -834,9 +2638,15 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if etag, found := companyInfoService.GetAllCompanyInfoETag(&pagination); found {
+				c.Set(fiber.HeaderETag, etag)
+			}
+			setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data":    companyInfo,
+				"data":    result,
+				"meta":    freshnessMeta(latestCompanyInfoUpdate(result.Data)),
 			})
 		})
 
@@ -874,6 +2684,7 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    companyInfo,
+				"meta":    freshnessMeta(latestCompanyInfoUpdate(companyInfo)),
 			})
 		})
 
@@ -888,7 +2699,9 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			companyInfo, err := companyInfoService.SearchCompanyInfo(searchTerm)
+			pagination := ParsePagination(c, service.DefaultCompanyInfoPageLimit, service.MaxCompanyInfoPageLimit)
+
+			result, err := companyInfoService.SearchCompanyInfo(searchTerm, &pagination)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -897,9 +2710,109 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data":    companyInfo,
+				"data":    result,
+				"meta":    freshnessMeta(latestCompanyInfoUpdate(result.Data)),
+			})
+		})
+
+		// Fuzzy search company info by symbol or name (typo tolerant, requires pg_trgm) - must come before /:symbol route
+		public.Get("/company-info/fuzzy", func(c *fiber.Ctx) error {
+			query := strings.TrimSpace(c.Query("q"))
+			if query == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Search term (q) is required",
+				})
+			}
+
+			limit := c.QueryInt("limit", 10)
+			if limit <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "limit must be a positive integer",
+				})
+			}
+			if limit > 50 {
+				limit = 50
+			}
+
+			threshold := service.DefaultFuzzySimilarity
+			if v := c.Query("threshold"); v != "" {
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil || parsed < 0 || parsed > 1 {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "threshold must be a number between 0 and 1",
+					})
+				}
+				threshold = parsed
+			}
+
+			matches, err := companyInfoService.FuzzySearchCompanyInfo(query, limit, threshold)
+			if err != nil {
+				if errors.Is(err, service.ErrFuzzySearchUnavailable) {
+					return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+						"success": false,
+						"error":   "Service Unavailable",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    matches,
+				"params": fiber.Map{
+					"q":         query,
+					"limit":     limit,
+					"threshold": threshold,
+				},
+			})
+		})
+
+		// List distinct sectors with company counts (for filter dropdowns) - must come before /:symbol route
+		public.Get("/company-info/sectors", func(c *fiber.Ctx) error {
+			sectors, err := companyInfoService.GetSectors()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    sectors,
+			})
+		})
+
+		// List distinct industries with company counts (for filter dropdowns) - must come before /:symbol route
+		public.Get("/company-info/industries", func(c *fiber.Ctx) error {
+			industries, err := companyInfoService.GetIndustries()
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    industries,
 			})
 		})
 
@@ -926,6 +2839,7 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    companyInfo,
+				"meta":    freshnessMeta(latestCompanyInfoUpdate(companyInfo)),
 			})
 		})
 
@@ -936,11 +2850,92 @@ func SetupRoutes(app *fiber.App) {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "Industry is required",
+					"message": "Industry is required",
+				})
+			}
+
+			companyInfo, err := companyInfoService.GetCompanyInfoByIndustry(industry)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    companyInfo,
+				"meta":    freshnessMeta(latestCompanyInfoUpdate(companyInfo)),
+			})
+		})
+
+		// Get a live simple quote for a symbol straight from the upstream (no DB read or write)
+		// Intended for lightweight previews, e.g. ticker search before adding to a watchlist
+		public.Get("/quote/:symbol", quoteLimit, func(c *fiber.Ctx) error {
+			fetcher := service.NewFetcherService()
+			ctx, cancel := context.WithTimeout(c.Context(), 15*time.Second)
+			defer cancel()
+
+			quote, err := fetcher.GetQuote(ctx, c.Params("symbol"))
+			if err != nil {
+				if errors.Is(err, service.ErrQuoteNotFound) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "No quote found for symbol",
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    quote,
+			})
+		})
+
+		// Get a symbol's dated metric snapshots (price, PE, market cap, ...) for trend charts
+		// Query params: from, to (YYYY-MM-DD; default the last 365 days)
+		public.Get("/company-info/:symbol/metrics-history", func(c *fiber.Ctx) error {
+			symbol := c.Params("symbol")
+			to := time.Now().UTC()
+			if toStr := c.Query("to"); toStr != "" {
+				parsed, err := time.Parse("2006-01-02", toStr)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "to must be a date in YYYY-MM-DD format",
+					})
+				}
+				to = parsed
+			}
+			from := to.AddDate(-1, 0, 0)
+			if fromStr := c.Query("from"); fromStr != "" {
+				parsed, err := time.Parse("2006-01-02", fromStr)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "from must be a date in YYYY-MM-DD format",
+					})
+				}
+				from = parsed
+			}
+			if to.Before(from) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "from must be on or before to",
 				})
 			}
 
-			companyInfo, err := companyInfoService.GetCompanyInfoByIndustry(industry)
+			snapshots, err := companyInfoService.GetCompanyMetricsHistory(symbol, from, to)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -951,7 +2946,8 @@ func SetupRoutes(app *fiber.App) {
 
 			return c.JSON(fiber.Map{
 				"success": true,
-				"data":    companyInfo,
+				"data":    snapshots,
+				"count":   len(snapshots),
 			})
 		})
 
@@ -985,6 +2981,7 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    companyInfo,
+				"meta":    freshnessMeta(companyInfo.UpdatedAt),
 			})
 		})
 
@@ -1215,8 +3212,205 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
+		// Get a statement with rows in statement order and values in chronological order (for charts)
+		public.Get("/fundamental-data/statement", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			statementType := c.Query("type", "income")
+			frequency := c.Query("frequency", "annual")
+
+			if symbol == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Symbol is required",
+				})
+			}
+
+			statement, err := fundamentalDataService.GetOrderedStatement(symbol, statementType, frequency)
+			if err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "Fundamental data not found",
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    statement,
+			})
+		})
+
+		// Calculate metrics for multiple symbols in one call (POST with JSON body)
+		public.Post("/fundamental-data/metrics/batch", func(c *fiber.Ctx) error {
+			var request struct {
+				Symbols       []string `json:"symbols"`
+				StatementType string   `json:"statement_type"`
+				Frequency     string   `json:"frequency"`
+			}
+
+			if err := c.BodyParser(&request); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid request body",
+				})
+			}
+
+			if len(request.Symbols) == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Symbols array is required",
+				})
+			}
+			if len(request.Symbols) > service.MaxMetricsBatchSymbols {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": fmt.Sprintf("too many symbols: maximum is %d", service.MaxMetricsBatchSymbols),
+				})
+			}
+			if request.StatementType == "" {
+				request.StatementType = "income"
+			}
+			if request.Frequency == "" {
+				request.Frequency = "annual"
+			}
+
+			batch, err := fundamentalDataService.GetFundamentalMetricsBatch(request.Symbols, request.StatementType, request.Frequency)
+			if err != nil {
+				if strings.HasPrefix(err.Error(), "invalid symbol") || strings.HasPrefix(err.Error(), "too many symbols") {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"metrics": batch.Metrics,
+					"missing": batch.Missing,
+					"errors":  batch.Errors,
+					"count":   len(batch.Metrics),
+					"params": fiber.Map{
+						"statement_type": request.StatementType,
+						"frequency":      request.Frequency,
+					},
+				},
+			})
+		})
+
+		// Compare symbols side by side: screener data, company summary and fundamental metrics
+		// e.g. /compare?symbols=AAPL,MSFT&statement_type=income&frequency=annual
+		public.Get("/compare", func(c *fiber.Ctx) error {
+			if c.Query("symbols") == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbols is required (e.g. symbols=AAPL,MSFT)",
+				})
+			}
+			statementType := c.Query("statement_type", "income")
+			frequency := c.Query("frequency", "annual")
+
+			comparison, err := compareService.CompareSymbols(strings.Split(c.Query("symbols"), ","), statementType, frequency)
+			if err != nil {
+				if strings.HasPrefix(err.Error(), "invalid symbol") || strings.HasPrefix(err.Error(), "too many symbols") ||
+					strings.HasPrefix(err.Error(), "at least one symbol") {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"symbols": comparison.Symbols,
+					"missing": comparison.Missing,
+					"count":   len(comparison.Symbols),
+					"params": fiber.Map{
+						"statement_type": statementType,
+						"frequency":      frequency,
+					},
+				},
+			})
+		})
+
+		// Screen stocks on combined revenue growth, EPS and margin criteria (POST with JSON body)
+		public.Post("/fundamental-data/screen", screenLimit, func(c *fiber.Ctx) error {
+			var filter service.FundamentalScreenFilter
+			if err := c.BodyParser(&filter); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid request body",
+				})
+			}
+			if filter.StatementType == "" {
+				filter.StatementType = "income"
+			}
+			if filter.Frequency == "" {
+				filter.Frequency = "annual"
+			}
+			if err := filter.Validate(); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			results, err := fundamentalDataService.ScreenFundamentals(filter)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			data := fiber.Map{
+				"stocks": results,
+				"count":  len(results),
+				"params": filter,
+			}
+			// explain=true adds every evaluated value (growth, EPS and each margin) per matching symbol
+			if c.QueryBool("explain") {
+				data["values"] = service.ExplainFundamentalScreen(filter, results)
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    data,
+			})
+		})
+
 		// Filter stocks by revenue growth (QoQ/YoY)
-		public.Get("/fundamental-data/revenue-growth", func(c *fiber.Ctx) error {
+		public.Get("/fundamental-data/revenue-growth", screenLimit, func(c *fiber.Ctx) error {
 			statementType := c.Query("statement_type", "income")
 			frequency := c.Query("frequency", "quarterly")
 
@@ -1272,7 +3466,7 @@ func SetupRoutes(app *fiber.App) {
 		})
 
 		// Filter stocks by EPS range
-		public.Get("/fundamental-data/eps-filter", func(c *fiber.Ctx) error {
+		public.Get("/fundamental-data/eps-filter", screenLimit, func(c *fiber.Ctx) error {
 			statementType := c.Query("statement_type", "income")
 			frequency := c.Query("frequency", "annual")
 			date := c.Query("date") // Optional: specific date, or latest if empty
@@ -1317,8 +3511,101 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
+		// Screen stocks by trailing dividend yield (annual dividends per share / price)
+		public.Get("/fundamental-data/dividend-screen", screenLimit, func(c *fiber.Ctx) error {
+			var minYield, maxYield *float64
+
+			if minStr := c.Query("min_yield"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					minYield = &val
+				}
+			}
+			if maxStr := c.Query("max_yield"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					maxYield = &val
+				}
+			}
+
+			filter := service.DividendYieldFilter{
+				MinYield:         minYield,
+				MaxYield:         maxYield,
+				Frequency:        c.Query("frequency", "annual"),
+				IncludeNonPayers: c.QueryBool("include_non_payers"),
+			}
+
+			results, err := fundamentalDataService.GetStocksByDividendYield(filter)
+			if err != nil {
+				status, label := fiber.StatusInternalServerError, "Internal Server Error"
+				if err.Error() == "frequency must be annual or quarterly" {
+					status, label = fiber.StatusBadRequest, "Bad Request"
+				}
+				return c.Status(status).JSON(fiber.Map{
+					"success": false,
+					"error":   label,
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"stocks": results,
+					"count":  len(results),
+					"params": filter,
+				},
+			})
+		})
+
+		// Screen stocks by price-to-book and price-to-sales ranges
+		public.Get("/fundamental-data/valuation-screen", screenLimit, func(c *fiber.Ctx) error {
+			filter := service.ValuationFilter{Frequency: c.Query("frequency", "annual")}
+
+			if minStr := c.Query("min_pb"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					filter.MinPB = &val
+				}
+			}
+			if maxStr := c.Query("max_pb"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					filter.MaxPB = &val
+				}
+			}
+			if minStr := c.Query("min_ps"); minStr != "" {
+				if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+					filter.MinPS = &val
+				}
+			}
+			if maxStr := c.Query("max_ps"); maxStr != "" {
+				if val, err := strconv.ParseFloat(maxStr, 64); err == nil {
+					filter.MaxPS = &val
+				}
+			}
+
+			results, err := fundamentalDataService.GetStocksByValuation(filter)
+			if err != nil {
+				status, label := fiber.StatusInternalServerError, "Internal Server Error"
+				if err.Error() == "frequency must be annual or quarterly" {
+					status, label = fiber.StatusBadRequest, "Bad Request"
+				}
+				return c.Status(status).JSON(fiber.Map{
+					"success": false,
+					"error":   label,
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"stocks": results,
+					"count":  len(results),
+					"params": filter,
+				},
+			})
+		})
+
 		// Filter stocks by margin range
-		public.Get("/fundamental-data/margin-filter", func(c *fiber.Ctx) error {
+		public.Get("/fundamental-data/margin-filter", screenLimit, func(c *fiber.Ctx) error {
 			marginType := c.Query("margin_type", "gross") // gross, operating, net
 			statementType := c.Query("statement_type", "income")
 			frequency := c.Query("frequency", "annual")
@@ -1370,10 +3657,56 @@ func SetupRoutes(app *fiber.App) {
 	protected := app.Group("/api/protected")
 	// Apply JWT middleware to all protected routes
 	protected.Use(supabase.JWTAuthMiddleware())
+	// Reject writes while maintenance mode is enabled (reads stay available)
+	protected.Use(maintenanceMiddleware())
 	{
 
 		// Get all screener data (read-only)
 		protected.Get("/screener", func(c *fiber.Ctx) error {
+			enrich := c.QueryBool("enrich", false)
+
+			// Optional ?fields=symbol,close,volume projection (no ETag for projected lists)
+			fields, err := service.ParseFields(c.Query("fields"), service.ScreenerFields)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+			if len(fields) > 0 {
+				if enrich {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "fields cannot be combined with enrich",
+					})
+				}
+				screeners, err := screenerService.GetAllScreenersFields(fields)
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"success": false,
+						"error":   "Internal Server Error",
+						"message": err.Error(),
+					})
+				}
+
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data":    service.ProjectFields(screeners, fields),
+					"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
+				})
+			}
+
+			// Short-circuit with 304 if the client already has the cached payload
+			// (the ETag describes the raw shape, so it isn't used for enriched responses)
+			if !enrich {
+				if etag, found := screenerService.GetAllScreenersETag(); found && etagMatches(c, etag) {
+					c.Set(fiber.HeaderETag, etag)
+					return c.SendStatus(fiber.StatusNotModified)
+				}
+			}
+
 			screeners, err := screenerService.GetAllScreeners()
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -1383,14 +3716,28 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if enrich {
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data":    service.EnrichScreeners(screeners),
+					"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
+				})
+			}
+
+			if etag, found := screenerService.GetAllScreenersETag(); found {
+				c.Set(fiber.HeaderETag, etag)
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    screeners,
+				"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
 			})
 		})
 
 		// Get screeners with advanced filtering, sorting, and pagination
-		protected.Get("/screener/filter", func(c *fiber.Ctx) error {
+		// The symbol universe can be restricted via ?symbols=AAPL,MSFT or, on POST, a {"symbols": [...]} body
+		screenerFilterHandler := func(c *fiber.Ctx) error {
 			// Parse filter options from query parameters
 			var filters *service.FilterOptions
 			if c.Query("min_price") != "" || c.Query("max_price") != "" ||
@@ -1398,7 +3745,8 @@ func SetupRoutes(app *fiber.App) {
 				c.Query("min_open") != "" || c.Query("max_open") != "" ||
 				c.Query("min_high") != "" || c.Query("max_high") != "" ||
 				c.Query("min_low") != "" || c.Query("max_low") != "" ||
-				c.Query("min_close") != "" || c.Query("max_close") != "" {
+				c.Query("min_close") != "" || c.Query("max_close") != "" ||
+				c.Query("min_dollar_volume") != "" || c.Query("max_dollar_volume") != "" {
 				filters = &service.FilterOptions{}
 				if val := c.Query("min_price"); val != "" {
 					if price, err := strconv.ParseFloat(val, 64); err == nil {
@@ -1455,34 +3803,80 @@ func SetupRoutes(app *fiber.App) {
 						filters.MinClose = &close
 					}
 				}
-				if val := c.Query("max_close"); val != "" {
-					if close, err := strconv.ParseFloat(val, 64); err == nil {
-						filters.MaxClose = &close
-					}
+				if val := c.Query("max_close"); val != "" {
+					if close, err := strconv.ParseFloat(val, 64); err == nil {
+						filters.MaxClose = &close
+					}
+				}
+				if val := c.Query("min_dollar_volume"); val != "" {
+					if dollarVolume, err := strconv.ParseFloat(val, 64); err == nil {
+						filters.MinDollarVolume = &dollarVolume
+					}
+				}
+				if val := c.Query("max_dollar_volume"); val != "" {
+					if dollarVolume, err := strconv.ParseFloat(val, 64); err == nil {
+						filters.MaxDollarVolume = &dollarVolume
+					}
+				}
+			}
+
+			// Parse the optional symbol universe from the query string and/or POST body
+			var symbols []string
+			if val := c.Query("symbols"); val != "" {
+				symbols = append(symbols, strings.Split(val, ",")...)
+			}
+			if c.Method() == fiber.MethodPost && len(c.Body()) > 0 {
+				var request struct {
+					Symbols []string `json:"symbols"`
+				}
+				if err := c.BodyParser(&request); err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": "Invalid request body",
+					})
+				}
+				symbols = append(symbols, request.Symbols...)
+			}
+			if len(symbols) > 0 {
+				normalized, err := service.NormalizeFilterSymbols(symbols)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				if filters == nil {
+					filters = &service.FilterOptions{}
 				}
+				filters.Symbols = normalized
 			}
 
-			// Parse sort options
-			var sort *service.SortOptions
-			if c.Query("sort_field") != "" {
-				sort = &service.SortOptions{
+			// Parse sort options: multi-field "sort=volume:desc,symbol:asc",
+			// or the single-field sort_field/sort_direction params
+			var sorts []service.SortOptions
+			if sortParam := c.Query("sort"); sortParam != "" {
+				parsed, err := service.ParseSortOptions(sortParam)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+				sorts = parsed
+			} else if c.Query("sort_field") != "" {
+				sorts = []service.SortOptions{{
 					Field:     c.Query("sort_field"),
 					Direction: c.Query("sort_direction", "asc"),
-				}
+				}}
 			}
 
 			// Parse pagination options
-			var pagination *service.PaginationOptions
-			page, _ := strconv.Atoi(c.Query("page", "1"))
-			limit, _ := strconv.Atoi(c.Query("limit", "10"))
-			if page > 0 || limit > 0 {
-				pagination = &service.PaginationOptions{
-					Page:  page,
-					Limit: limit,
-				}
-			}
+			pagination := ParsePagination(c, 10, DefaultMaxPageLimit)
 
-			result, err := screenerService.GetScreenersWithFilters(filters, sort, pagination)
+			result, err := screenerService.GetScreenersWithFilters(filters, sorts, &pagination)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -1491,11 +3885,24 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			setPaginationHeaders(c, result.Page, result.Limit, result.Total, result.TotalPages)
+
+			if c.QueryBool("enrich", false) {
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data":    service.EnrichQueryResult(result),
+					"meta":    freshnessMeta(latestScreenerUpdate(result.Data)),
+				})
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    result,
+				"meta":    freshnessMeta(latestScreenerUpdate(result.Data)),
 			})
-		})
+		}
+		protected.Get("/screener/filter", screenerFilterHandler)
+		protected.Post("/screener/filter", screenerFilterHandler)
 
 		// Get top gainers (must come before /:id route)
 		protected.Get("/screener/top-gainers", func(c *fiber.Ctx) error {
@@ -1509,9 +3916,36 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if c.QueryBool("enrich", false) {
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data":    service.EnrichScreeners(screeners),
+					"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    screeners,
+				"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
+			})
+		})
+
+		// Get recently updated screener rows, newest first (must come before /:id route)
+		protected.Get("/screener/recent", func(c *fiber.Ctx) error {
+			screeners, err := screenerService.GetRecentlyUpdated(c.QueryInt("limit", service.DefaultScreenerRecentLimit))
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    screeners,
+				"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
 			})
 		})
 
@@ -1527,9 +3961,18 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if c.QueryBool("enrich", false) {
+				return c.JSON(fiber.Map{
+					"success": true,
+					"data":    service.EnrichScreeners(screeners),
+					"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
+				})
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    screeners,
+				"meta":    freshnessMeta(latestScreenerUpdate(screeners)),
 			})
 		})
 
@@ -1672,6 +4115,7 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    screener,
+				"meta":    freshnessMeta(screener.UpdatedAt),
 			})
 		})
 
@@ -1698,6 +4142,7 @@ func SetupRoutes(app *fiber.App) {
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    screener,
+				"meta":    freshnessMeta(screener.UpdatedAt),
 			})
 		})
 
@@ -1753,7 +4198,22 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
-			historical, err := historicalService.GetHistoricalBySymbolRangeInterval(symbol, rangeParam, interval)
+			// resample=true builds 1wk/1mo bars from stored daily bars
+			resample := c.QueryBool("resample", false)
+			if resample && !service.IsResampleInterval(interval) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "resample is only supported for interval=1wk or interval=1mo",
+				})
+			}
+
+			sourceInterval := interval
+			if resample {
+				sourceInterval = "1d"
+			}
+
+			historical, err := historicalService.GetHistoricalBySymbolRangeInterval(symbol, rangeParam, sourceInterval)
 			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"success": false,
@@ -1762,9 +4222,61 @@ func SetupRoutes(app *fiber.App) {
 				})
 			}
 
+			if resample {
+				historical, err = service.ResampleHistorical(historical, interval)
+				if err != nil {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+			}
+
 			return c.JSON(fiber.Map{
 				"success": true,
 				"data":    historical,
+				"meta":    freshnessMeta(latestBarTime(historical)),
+			})
+		})
+
+		// Get the newest stored bar for a symbol, range, and interval (must be before /historical/:id)
+		protected.Get("/historical/latest", func(c *fiber.Ctx) error {
+			symbol := c.Query("symbol")
+			rangeParam := c.Query("range")
+			interval := c.Query("interval")
+
+			if symbol == "" || rangeParam == "" || interval == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "symbol, range, and interval query parameters are required",
+				})
+			}
+
+			latest, err := historicalService.GetLatestHistorical(symbol, rangeParam, interval)
+			if err != nil {
+				if err.Error() == "no historical data" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": fmt.Sprintf("No historical data for %s (%s/%s)", symbol, rangeParam, interval),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data": fiber.Map{
+					"epoch": latest.Epoch,
+					"bar":   latest,
+				},
+				"meta": freshnessMeta(time.Unix(latest.Epoch, 0)),
 			})
 		})
 
@@ -1813,13 +4325,16 @@ func SetupRoutes(app *fiber.App) {
 		// Create historical records in batch
 		protected.Post("/historical/batch", func(c *fiber.Ctx) error {
 			var historical []model.Historical
-			if err := c.BodyParser(&historical); err != nil {
+			if err := decodeStrictJSON(c, &historical); err != nil {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "Invalid request body",
+					"message": "Invalid request body: " + err.Error(),
 				})
 			}
+			if len(historical) > service.MaxHistoricalBatchSize {
+				return payloadTooLarge(c, fmt.Sprintf("At most %d historical records per batch", service.MaxHistoricalBatchSize))
+			}
 
 			if err := historicalService.CreateHistoricalBatch(historical); err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -1864,13 +4379,16 @@ func SetupRoutes(app *fiber.App) {
 		// Upsert historical records in batch
 		protected.Put("/historical/batch", func(c *fiber.Ctx) error {
 			var historical []model.Historical
-			if err := c.BodyParser(&historical); err != nil {
+			if err := decodeStrictJSON(c, &historical); err != nil {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "Invalid request body",
+					"message": "Invalid request body: " + err.Error(),
 				})
 			}
+			if len(historical) > service.MaxHistoricalBatchSize {
+				return payloadTooLarge(c, fmt.Sprintf("At most %d historical records per batch", service.MaxHistoricalBatchSize))
+			}
 
 			if err := historicalService.UpsertHistoricalBatch(historical); err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -2135,6 +4653,57 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
+		// Get aggregate daily performance for a watchlist owned by the authenticated user
+		protected.Get("/watchlist/:id/performance", func(c *fiber.Ctx) error {
+			userIDStr, ok := c.Locals("userID").(string)
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"error":   "Unauthorized",
+					"message": "User ID not found in token",
+				})
+			}
+
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid user ID format",
+				})
+			}
+
+			watchlistID, err := uuid.Parse(c.Params("id"))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid watchlist ID format",
+				})
+			}
+
+			performance, err := watchlistService.GetWatchlistPerformance(watchlistID, userID)
+			if err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "Watchlist not found",
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    performance,
+			})
+		})
+
 		// Get a specific item by ID
 		protected.Get("/watchlist/item/:id", func(c *fiber.Ctx) error {
 			id := c.Params("id")
@@ -2182,6 +4751,13 @@ func SetupRoutes(app *fiber.App) {
 			}
 
 			if err := watchlistService.AddItemToWatchlist(watchlistID, &item); err != nil {
+				if errors.Is(err, service.ErrInvalidPosition) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
 				if err.Error() == "watchlist not found" {
 					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"success": false,
@@ -2222,6 +4798,13 @@ func SetupRoutes(app *fiber.App) {
 			}
 
 			if err := watchlistService.UpdateWatchlistItem(id, &item); err != nil {
+				if errors.Is(err, service.ErrInvalidPosition) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
 				if err.Error() == "record not found" {
 					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"success": false,
@@ -2326,16 +4909,149 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 
+		// Favorites: a per-user set of starred symbols, independent of watchlists
+		// Get the authenticated user's favorites with current screener and company info
+		protected.Get("/favorites", func(c *fiber.Ctx) error {
+			userIDStr, ok := c.Locals("userID").(string)
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"error":   "Unauthorized",
+					"message": "User ID not found in token",
+				})
+			}
+
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid user ID format",
+				})
+			}
+
+			favorites, err := favoriteService.GetFavorites(userID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"data":    favorites,
+			})
+		})
+
+		// Favorite a symbol (idempotent: 201 when added, 200 when already a favorite)
+		protected.Post("/favorites/:symbol", func(c *fiber.Ctx) error {
+			userIDStr, ok := c.Locals("userID").(string)
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"error":   "Unauthorized",
+					"message": "User ID not found in token",
+				})
+			}
+
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid user ID format",
+				})
+			}
+
+			favorite, created, err := favoriteService.AddFavorite(userID, c.Params("symbol"))
+			if err != nil {
+				switch err.Error() {
+				case "symbol is required":
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"success": false,
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				case "symbol not found":
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": fmt.Sprintf("Symbol %s not found", strings.ToUpper(c.Params("symbol"))),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			status := fiber.StatusOK
+			if created {
+				status = fiber.StatusCreated
+			}
+			return c.Status(status).JSON(fiber.Map{
+				"success": true,
+				"data":    favorite,
+			})
+		})
+
+		// Remove a symbol from favorites
+		protected.Delete("/favorites/:symbol", func(c *fiber.Ctx) error {
+			userIDStr, ok := c.Locals("userID").(string)
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"success": false,
+					"error":   "Unauthorized",
+					"message": "User ID not found in token",
+				})
+			}
+
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"error":   "Bad Request",
+					"message": "Invalid user ID format",
+				})
+			}
+
+			if err := favoriteService.RemoveFavorite(userID, c.Params("symbol")); err != nil {
+				if err.Error() == "record not found" {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"success": false,
+						"error":   "Not Found",
+						"message": "Favorite not found",
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"success": false,
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return c.JSON(fiber.Map{
+				"success": true,
+				"message": "Favorite removed successfully",
+			})
+		})
+
 		// Batch update items (useful for price updates)
 		protected.Put("/watchlist/items/batch", func(c *fiber.Ctx) error {
 			var items []model.WatchlistItem
-			if err := c.BodyParser(&items); err != nil {
+			if err := decodeStrictJSON(c, &items); err != nil {
 				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 					"success": false,
 					"error":   "Bad Request",
-					"message": "Invalid request body",
+					"message": "Invalid request body: " + err.Error(),
 				})
 			}
+			if len(items) > service.MaxWatchlistItemBatchSize {
+				return payloadTooLarge(c, fmt.Sprintf("At most %d watchlist items per batch", service.MaxWatchlistItemBatchSize))
+			}
 
 			if err := watchlistService.BatchUpdateItems(items); err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -2352,4 +5068,9 @@ func SetupRoutes(app *fiber.App) {
 			})
 		})
 	}
+
+	// Flag handlers registered without an OpenAPI entry so the spec doesn't drift
+	if missing := undocumentedRoutes(app); len(missing) > 0 {
+		log.Printf("Warning: %d routes have no OpenAPI docs: %s", len(missing), strings.Join(missing, ", "))
+	}
 }
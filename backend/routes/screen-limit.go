@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Defaults for the screening concurrency limiter
+const (
+	defaultScreenMaxConcurrent = 4
+	defaultScreenQueueTimeout  = 2 * time.Second
+)
+
+// newScreenLimiter caps how many full-universe screens run at once. A request waits up to
+// SCREEN_QUEUE_TIMEOUT for a free slot, then gets 503 with Retry-After if scans are still saturated.
+// The cap is SCREEN_MAX_CONCURRENT (default 4); cheap per-symbol endpoints don't use this limiter.
+func newScreenLimiter() fiber.Handler {
+	maxConcurrent := defaultScreenMaxConcurrent
+	if value := os.Getenv("SCREEN_MAX_CONCURRENT"); value != "" {
+		if v, err := strconv.Atoi(value); err == nil && v > 0 {
+			maxConcurrent = v
+		} else {
+			log.Printf("Warning: invalid SCREEN_MAX_CONCURRENT %q, using %d", value, defaultScreenMaxConcurrent)
+		}
+	}
+
+	queueTimeout := defaultScreenQueueTimeout
+	if value := os.Getenv("SCREEN_QUEUE_TIMEOUT"); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+			queueTimeout = d
+		} else {
+			log.Printf("Warning: invalid SCREEN_QUEUE_TIMEOUT %q, using %v", value, defaultScreenQueueTimeout)
+		}
+	}
+
+	slots := make(chan struct{}, maxConcurrent)
+	return func(c *fiber.Ctx) error {
+		timer := time.NewTimer(queueTimeout)
+		defer timer.Stop()
+
+		select {
+		case slots <- struct{}{}:
+		case <-timer.C:
+			c.Set(fiber.HeaderRetryAfter, "5")
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"success": false,
+				"error":   "Service Unavailable",
+				"message": "Too many screening requests in progress, please retry shortly",
+			})
+		}
+		defer func() { <-slots }()
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"errors"
+	"strconv"
+
+	indicatorsscreening "screener/backend/service/filtering/indicators/screening"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// liquidityFilterFromQuery reads the optional min_price and min_dollar_volume screen guards.
+// Both are off unless set; malformed values are ignored like the other screen thresholds.
+func liquidityFilterFromQuery(c *fiber.Ctx) indicatorsscreening.LiquidityFilter {
+	var filter indicatorsscreening.LiquidityFilter
+	if minStr := c.Query("min_price"); minStr != "" {
+		if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+			filter.MinPrice = &val
+		}
+	}
+	if minStr := c.Query("min_dollar_volume"); minStr != "" {
+		if val, err := strconv.ParseFloat(minStr, 64); err == nil {
+			filter.MinDollarVolume = &val
+		}
+	}
+	return filter
+}
+
+// resultOrderFromQuery reads the optional sort (asc|desc by computed value) and limit screen params
+// Without sort, results are in symbol order; limit=0 (the default) returns every match
+func resultOrderFromQuery(c *fiber.Ctx) (indicatorsscreening.ResultOrder, error) {
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		val, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return indicatorsscreening.ResultOrder{}, errors.New("limit must be a non-negative integer")
+		}
+		limit = val
+	}
+	return indicatorsscreening.ParseResultOrder(c.Query("sort"), limit)
+}
+
+// screenSymbols extracts the matching symbols from indicator screen results
+func screenSymbols(results []indicatorsscreening.IndicatorResult) []string {
+	symbols := make([]string, 0, len(results))
+	for _, r := range results {
+		symbols = append(symbols, r.Symbol)
+	}
+	return symbols
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/caching"
+)
+
+// AdminStats is the consolidated operations view behind GET /api/admin/stats.
+// Sections that fail to load are left empty and their error is reported in Errors.
+type AdminStats struct {
+	GeneratedAt      time.Time                      `json:"generated_at"`
+	SymbolCount      int                            `json:"symbol_count"`
+	LastIngestions   map[string]*model.IngestionRun `json:"last_ingestions"` // Latest run per ingestion type
+	Cache            *AdminCacheStats               `json:"cache"`
+	TableRows        map[string]int64               `json:"table_rows"` // Planner estimates (pg_stat_user_tables), not exact counts
+	MarketAggregator map[string]interface{}         `json:"market_aggregator"`
+	MarketStatus     MarketStatus                   `json:"market_status"`
+	Errors           map[string]string              `json:"errors,omitempty"`
+}
+
+// AdminCacheStats combines Redis connectivity with key and memory usage
+type AdminCacheStats struct {
+	Redis       caching.RedisStatus `json:"redis"`
+	TotalKeys   int64               `json:"total_keys"`
+	MemoryUsage string              `json:"memory_usage"`
+}
+
+// GetAdminStats gathers symbol, ingestion, cache, table and aggregator stats concurrently
+func GetAdminStats(ctx context.Context) *AdminStats {
+	stats := &AdminStats{
+		GeneratedAt:  time.Now().UTC(),
+		MarketStatus: DefaultMarketCalendar().Status(time.Now()),
+	}
+
+	var mu sync.Mutex
+	errs := make(map[string]string)
+	wg := sync.WaitGroup{}
+	section := func(name string, load func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := load(); err != nil {
+				mu.Lock()
+				errs[name] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+
+	section("symbols", func() error {
+		symbols, err := caching.NewSymbolCache().GetAllSymbols()
+		stats.SymbolCount = len(symbols)
+		return err
+	})
+	section("last_ingestions", func() error {
+		runs, err := latestIngestionRuns(ctx)
+		stats.LastIngestions = runs
+		return err
+	})
+	section("cache", func() error {
+		cache := &AdminCacheStats{Redis: caching.GetRedisStatus()}
+		stats.Cache = cache
+		if !cache.Redis.Connected {
+			return nil // In-memory fallback; there is no Redis to size
+		}
+		redisStats, err := caching.GetCacheStats()
+		if err != nil {
+			return err
+		}
+		cache.TotalKeys = redisStats.TotalKeys
+		cache.MemoryUsage = redisStats.MemoryUsage
+		return nil
+	})
+	section("table_rows", func() error {
+		rows, err := estimatedTableRows(ctx)
+		stats.TableRows = rows
+		return err
+	})
+	section("market_aggregator", func() error {
+		aggregator, err := NewMarketStatisticsService().GetMarketStatsForFrontend()
+		stats.MarketAggregator = aggregator
+		return err
+	})
+
+	wg.Wait()
+	if len(errs) > 0 {
+		stats.Errors = errs
+	}
+	return stats
+}
+
+// latestIngestionRuns returns the most recent recorded run of each ingestion type
+func latestIngestionRuns(ctx context.Context) (map[string]*model.IngestionRun, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("database connection not initialized")
+	}
+
+	var runs []model.IngestionRun
+	if err := db.WithContext(ctx).
+		Raw("SELECT DISTINCT ON (type) * FROM ingestion_runs ORDER BY type, started_at DESC").
+		Scan(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch latest ingestion runs: %w", err)
+	}
+
+	latest := make(map[string]*model.IngestionRun, len(runs))
+	for i := range runs {
+		latest[runs[i].Type] = &runs[i]
+	}
+	return latest, nil
+}
+
+// estimatedTableRows returns live row estimates for the public tables; exact COUNT(*) over
+// historical would scan millions of rows on every dashboard refresh
+func estimatedTableRows(ctx context.Context) (map[string]int64, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("database connection not initialized")
+	}
+
+	var rows []struct {
+		Table string
+		Rows  int64
+	}
+	if err := db.WithContext(ctx).
+		Raw("SELECT relname AS \"table\", n_live_tup AS rows FROM pg_stat_user_tables WHERE schemaname = 'public' ORDER BY relname").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch table row estimates: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Table] = row.Rows
+	}
+	return counts, nil
+}
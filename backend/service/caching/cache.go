@@ -11,26 +11,29 @@ import (
 )
 
 // CacheService provides caching operations
+// The Redis client is resolved on every call, so operations transparently use a bounded
+// in-memory LRU while Redis is unavailable and switch back once it recovers
 type CacheService struct {
-	client *redis.Client
-	ctx    context.Context
+	ctx   context.Context
+	local *localCache
 }
 
 // NewCacheService creates a new cache service instance
 func NewCacheService() *CacheService {
 	return &CacheService{
-		client: GetRedisClient(),
-		ctx:    GetRedisContext(),
+		ctx:   GetRedisContext(),
+		local: getFallbackCache(),
 	}
 }
 
 // Get retrieves a value from cache by key
 func (c *CacheService) Get(key string) ([]byte, error) {
-	if c.client == nil {
-		return nil, fmt.Errorf("redis client not initialized")
+	client := GetRedisClient()
+	if client == nil {
+		return c.local.get(key), nil
 	}
 
-	val, err := c.client.Get(c.ctx, key).Bytes()
+	val, err := client.Get(c.ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil // Cache miss, not an error
 	}
@@ -63,17 +66,19 @@ func (c *CacheService) GetJSON(key string, dest interface{}) (bool, error) {
 // Set stores a value in cache with TTL
 // If ttl is 0, the key is stored permanently (no expiration)
 func (c *CacheService) Set(key string, value []byte, ttl time.Duration) error {
-	if c.client == nil {
-		return fmt.Errorf("redis client not initialized")
-	}
-
 	// If TTL is 0, store permanently (no expiration)
 	// If TTL is negative, don't cache
 	if ttl < 0 {
 		return nil
 	}
 
-	err := c.client.Set(c.ctx, key, value, ttl).Err()
+	client := GetRedisClient()
+	if client == nil {
+		c.local.set(key, value, ttl)
+		return nil
+	}
+
+	err := client.Set(c.ctx, key, value, ttl).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set cache: %w", err)
 	}
@@ -95,13 +100,56 @@ func (c *CacheService) SetJSON(key string, value interface{}, ttl time.Duration)
 	return err
 }
 
+// SetNX stores a value only if the key does not already exist
+// Returns true if the value was stored
+func (c *CacheService) SetNX(key string, value []byte, ttl time.Duration) (bool, error) {
+	client := GetRedisClient()
+	if client == nil {
+		return c.local.setNX(key, value, ttl), nil
+	}
+
+	ok, err := client.SetNX(c.ctx, key, value, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set cache: %w", err)
+	}
+
+	return ok, nil
+}
+
+// compareAndDeleteScript deletes KEYS[1] only while it still holds ARGV[1]
+var compareAndDeleteScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// DeleteIfEquals removes a key only if it still holds value, atomically
+// Returns true if the key was removed; used to release locks taken with SetNX without
+// deleting a lock another holder acquired after ours expired
+func (c *CacheService) DeleteIfEquals(key string, value []byte) (bool, error) {
+	client := GetRedisClient()
+	if client == nil {
+		return c.local.deleteIfEquals(key, value), nil
+	}
+
+	deleted, err := compareAndDeleteScript.Run(c.ctx, client, []string{key}, value).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete from cache: %w", err)
+	}
+
+	return deleted == 1, nil
+}
+
 // Delete removes a key from cache
 func (c *CacheService) Delete(key string) error {
-	if c.client == nil {
-		return fmt.Errorf("redis client not initialized")
+	client := GetRedisClient()
+	if client == nil {
+		c.local.delete(key)
+		return nil
 	}
 
-	err := c.client.Del(c.ctx, key).Err()
+	err := client.Del(c.ctx, key).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete from cache: %w", err)
 	}
@@ -111,12 +159,14 @@ func (c *CacheService) Delete(key string) error {
 
 // DeletePattern deletes all keys matching a pattern
 func (c *CacheService) DeletePattern(pattern string) error {
-	if c.client == nil {
-		return fmt.Errorf("redis client not initialized")
+	client := GetRedisClient()
+	if client == nil {
+		c.local.delete(c.local.keys(pattern)...)
+		return nil
 	}
 
 	// Use SCAN to find all keys matching the pattern
-	iter := c.client.Scan(c.ctx, 0, pattern, 0).Iterator()
+	iter := client.Scan(c.ctx, 0, pattern, 0).Iterator()
 	var keys []string
 
 	for iter.Next(c.ctx) {
@@ -128,7 +178,7 @@ func (c *CacheService) DeletePattern(pattern string) error {
 	}
 
 	if len(keys) > 0 {
-		err := c.client.Del(c.ctx, keys...).Err()
+		err := client.Del(c.ctx, keys...).Err()
 		if err != nil {
 			return fmt.Errorf("failed to delete cache keys: %w", err)
 		}
@@ -139,11 +189,12 @@ func (c *CacheService) DeletePattern(pattern string) error {
 
 // Exists checks if a key exists in cache
 func (c *CacheService) Exists(key string) (bool, error) {
-	if c.client == nil {
-		return false, fmt.Errorf("redis client not initialized")
+	client := GetRedisClient()
+	if client == nil {
+		return c.local.get(key) != nil, nil
 	}
 
-	count, err := c.client.Exists(c.ctx, key).Result()
+	count, err := client.Exists(c.ctx, key).Result()
 	if err != nil {
 		return false, fmt.Errorf("failed to check cache key existence: %w", err)
 	}
@@ -153,14 +204,60 @@ func (c *CacheService) Exists(key string) (bool, error) {
 
 // ClearAll clears all cache keys (use with caution)
 func (c *CacheService) ClearAll() error {
-	if c.client == nil {
-		return fmt.Errorf("redis client not initialized")
+	client := GetRedisClient()
+	if client == nil {
+		c.local.clear()
+		return nil
 	}
 
-	err := c.client.FlushDB(c.ctx).Err()
+	err := client.FlushDB(c.ctx).Err()
 	if err != nil {
 		return fmt.Errorf("failed to clear cache: %w", err)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// Keys returns all keys matching a pattern
+func (c *CacheService) Keys(pattern string) ([]string, error) {
+	client := GetRedisClient()
+	if client == nil {
+		return c.local.keys(pattern), nil
+	}
+
+	var keys []string
+	iter := client.Scan(c.ctx, 0, pattern, 0).Iterator()
+	for iter.Next(c.ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// DeleteKeys removes multiple keys from cache
+func (c *CacheService) DeleteKeys(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	client := GetRedisClient()
+	if client == nil {
+		c.local.delete(keys...)
+		return nil
+	}
+
+	if err := client.Del(c.ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete keys: %w", err)
+	}
+
+	return nil
+}
+
+// IsLocalFallback reports whether this service is using the in-memory fallback instead of Redis
+func (c *CacheService) IsLocalFallback() bool {
+	return GetRedisClient() == nil
+}
@@ -0,0 +1,51 @@
+package caching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// checkLockRelease verifies DeleteIfEquals only releases a lock while it holds the caller's token
+func checkLockRelease(t *testing.T, cache *CacheService) {
+	t.Helper()
+	const key = "cache:test:lock"
+	if ok, err := cache.SetNX(key, []byte("ours"), time.Minute); err != nil || !ok {
+		t.Fatalf("SetNX = %v, %v; want the lock acquired", ok, err)
+	}
+
+	// Our lock expired and another holder took it: releasing with our token must leave theirs
+	if err := cache.Set(key, []byte("theirs"), time.Minute); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if released, err := cache.DeleteIfEquals(key, []byte("ours")); err != nil || released {
+		t.Errorf("DeleteIfEquals with a stale token = %v, %v; want the other holder's lock kept", released, err)
+	}
+	if held, err := cache.Exists(key); err != nil || !held {
+		t.Errorf("lock exists = %v, %v; want it still held", held, err)
+	}
+
+	if released, err := cache.DeleteIfEquals(key, []byte("theirs")); err != nil || !released {
+		t.Errorf("DeleteIfEquals with the holder's token = %v, %v; want it released", released, err)
+	}
+	if held, _ := cache.Exists(key); held {
+		t.Error("lock still exists after its holder released it")
+	}
+}
+
+func TestDeleteIfEquals(t *testing.T) {
+	server := miniredis.RunT(t)
+	t.Setenv("REDIS_URL", "redis://"+server.Addr())
+	if err := InitRedis(); err != nil {
+		t.Fatalf("failed to connect to miniredis: %v", err)
+	}
+
+	checkLockRelease(t, NewCacheService())
+
+	// With Redis down the local fallback gives the same guarantee
+	if err := CloseRedis(); err != nil {
+		t.Fatalf("CloseRedis returned error: %v", err)
+	}
+	checkLockRelease(t, &CacheService{local: newLocalCache(16)})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -13,9 +14,25 @@ import (
 
 var (
 	redisClient *redis.Client
-	redisCtx    context.Context
+	redisCtx    = context.Background()
+
+	// redisMu guards redisClient and the connectivity state below
+	redisMu        sync.RWMutex
+	redisConnected bool
+	redisLastCheck time.Time
+	redisLastError string
+	redisSince     time.Time // when redisConnected last changed
 )
 
+// RedisStatus describes the current Redis connectivity state
+type RedisStatus struct {
+	Connected     bool      `json:"connected"`
+	LocalFallback bool      `json:"local_fallback"`
+	Since         time.Time `json:"since"`
+	LastChecked   time.Time `json:"last_checked"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
 // InitRedis initializes the Redis client connection
 func InitRedis() error {
 	redisURL := os.Getenv("REDIS_URL")
@@ -55,20 +72,28 @@ func InitRedis() error {
 	opts.WriteTimeout = 3 * time.Second
 	opts.PoolTimeout = 4 * time.Second
 
-	redisClient = redis.NewClient(opts)
-	redisCtx = context.Background()
+	client := redis.NewClient(opts)
+	redisMu.Lock()
+	previous := redisClient
+	redisClient = client
+	redisMu.Unlock()
+	if previous != nil {
+		_ = previous.Close()
+	}
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(redisCtx, 5*time.Second)
 	defer cancel()
 
-	pingResult, err := redisClient.Ping(ctx).Result()
+	pingResult, err := client.Ping(ctx).Result()
 	if err != nil {
+		setRedisConnected(false, err)
 		return fmt.Errorf("failed to connect to Redis: %w", err)
 	}
+	setRedisConnected(true, nil)
 
 	// Get Redis server info for verification
-	info, err := redisClient.Info(ctx, "server").Result()
+	info, err := client.Info(ctx, "server").Result()
 	redisVersion := "unknown"
 	if err == nil {
 		// Extract version from info string
@@ -86,7 +111,7 @@ func InitRedis() error {
 	}
 
 	// Get database size
-	dbSize, _ := redisClient.DBSize(ctx).Result()
+	dbSize, _ := client.DBSize(ctx).Result()
 
 	log.Printf("✅ Redis connection established successfully")
 	log.Printf("   Address: %s", opts.Addr)
@@ -97,11 +122,100 @@ func InitRedis() error {
 	return nil
 }
 
-// GetRedisClient returns the Redis client instance
+// GetRedisClient returns the Redis client instance, or nil while Redis is unreachable
+// Callers treat nil as "no Redis" (CacheService falls back to its in-memory cache)
 func GetRedisClient() *redis.Client {
+	redisMu.RLock()
+	defer redisMu.RUnlock()
+	if !redisConnected {
+		return nil
+	}
 	return redisClient
 }
 
+// GetRedisStatus returns the current Redis connectivity state
+func GetRedisStatus() RedisStatus {
+	redisMu.RLock()
+	defer redisMu.RUnlock()
+	return RedisStatus{
+		Connected:     redisConnected,
+		LocalFallback: !redisConnected,
+		Since:         redisSince,
+		LastChecked:   redisLastCheck,
+		LastError:     redisLastError,
+	}
+}
+
+// setRedisConnected records a health check result and logs connectivity transitions
+func setRedisConnected(connected bool, err error) {
+	redisMu.Lock()
+	defer redisMu.Unlock()
+
+	now := time.Now()
+	redisLastCheck = now
+	redisLastError = ""
+	if err != nil {
+		redisLastError = err.Error()
+	}
+
+	if connected == redisConnected && !redisSince.IsZero() {
+		return
+	}
+	if !redisSince.IsZero() {
+		if connected {
+			log.Printf("✅ Redis connection restored, leaving in-memory cache fallback")
+		} else {
+			log.Printf("❌ Redis connection lost, using in-memory cache fallback: %v", err)
+		}
+	}
+	redisConnected = connected
+	redisSince = now
+}
+
+// checkRedisHealth pings Redis, initializing the client first if it was never created
+func checkRedisHealth() {
+	redisMu.RLock()
+	client := redisClient
+	redisMu.RUnlock()
+
+	if client == nil {
+		// Startup failed before a client existed (e.g. bad REDIS_URL); retry the full init
+		_ = InitRedis()
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(redisCtx, 3*time.Second)
+	defer cancel()
+	err := client.Ping(ctx).Err()
+	setRedisConnected(err == nil, err)
+}
+
+// StartRedisHealthMonitor pings Redis every interval and flips CacheService between Redis and
+// the in-memory fallback as connectivity changes. Returns a func that stops the monitor.
+func StartRedisHealthMonitor(interval time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				checkRedisHealth()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// GetRedisHealthCheckInterval reads REDIS_HEALTH_CHECK_INTERVAL (default: 30s, 0 disables)
+func GetRedisHealthCheckInterval() time.Duration {
+	return parseDuration(os.Getenv("REDIS_HEALTH_CHECK_INTERVAL"), 30*time.Second)
+}
+
 // GetRedisContext returns the Redis context
 func GetRedisContext() context.Context {
 	return redisCtx
@@ -109,6 +223,9 @@ func GetRedisContext() context.Context {
 
 // CloseRedis closes the Redis connection gracefully
 func CloseRedis() error {
+	redisMu.Lock()
+	defer redisMu.Unlock()
+	redisConnected = false
 	if redisClient != nil {
 		return redisClient.Close()
 	}
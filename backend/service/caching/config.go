@@ -1,6 +1,7 @@
 package caching
 
 import (
+	"log"
 	"os"
 	"time"
 )
@@ -9,11 +10,19 @@ import (
 type CacheTTLConfig struct {
 	CompanyInfo       time.Duration
 	FundamentalData   time.Duration
+	FundamentalMetrics time.Duration // Computed metrics; also invalidated when a symbol is re-ingested
 	MarketStatistics  time.Duration
 	ScreenerResults   time.Duration
 	Historical        time.Duration
 	Screener          time.Duration
 	Symbols           time.Duration // TTL for symbols list used by cron jobs
+	NotFound          time.Duration // TTL for not-found tombstones on symbol lookups (0 disables)
+	WatchlistPerformance time.Duration // Aggregate watchlist stats; keys include the last price update
+	UpstreamResponse  time.Duration // Raw finance-query responses reused within a run (0 disables)
+	ScreenJob         time.Duration // Queued screen jobs and their results; identical screens reuse them
+	Quote             time.Duration // Live single-symbol quotes; kept short to absorb bursts only
+	ScreenerRecent    time.Duration // Recently updated screener feed; also invalidated after ingestion
+	SymbolsRefreshInterval time.Duration // Periodic symbol cache refresh interval (0 disables)
 	PersistenceSchedule time.Duration // Schedule for background persistence worker (e.g., 1h, 24h)
 	EnableRedisFirst  bool           // Enable Redis-first mode (default: true)
 }
@@ -24,11 +33,7 @@ var ttlConfig *CacheTTLConfig
 func GetTTLConfig() *CacheTTLConfig {
 	if ttlConfig == nil {
 		// Parse persistence schedule (default: 1 hour)
-		persistenceScheduleStr := os.Getenv("CACHE_PERSISTENCE_SCHEDULE")
-		if persistenceScheduleStr == "" {
-			persistenceScheduleStr = "1h"
-		}
-		persistenceSchedule := parseDuration(persistenceScheduleStr, 1*time.Hour)
+		persistenceSchedule := durationFromEnv("CACHE_PERSISTENCE_SCHEDULE", 1*time.Hour)
 		
 		// Parse enable Redis-first flag (default: true)
 		enableRedisFirst := true
@@ -39,13 +44,21 @@ func GetTTLConfig() *CacheTTLConfig {
 		}
 
 		ttlConfig = &CacheTTLConfig{
-			CompanyInfo:        parseDuration(os.Getenv("CACHE_TTL_COMPANY_INFO"), 1*time.Hour),
-			FundamentalData:    parseDuration(os.Getenv("CACHE_TTL_FUNDAMENTAL_DATA"), 1*time.Hour),
-			MarketStatistics:   parseDuration(os.Getenv("CACHE_TTL_MARKET_STATISTICS"), 5*time.Minute),
-			ScreenerResults:    parseDuration(os.Getenv("CACHE_TTL_SCREENER_RESULTS"), 15*time.Minute),
-			Historical:         parseDuration(os.Getenv("CACHE_TTL_HISTORICAL"), 30*time.Minute),
-			Screener:           parseDuration(os.Getenv("CACHE_TTL_SCREENER"), 10*time.Minute),
-			Symbols:            parseDuration(os.Getenv("CACHE_TTL_SYMBOLS"), 1*time.Hour), // Cache symbols list for 1 hour
+			CompanyInfo:        durationFromEnv("CACHE_TTL_COMPANY_INFO", 1*time.Hour),
+			FundamentalData:    durationFromEnv("CACHE_TTL_FUNDAMENTAL_DATA", 1*time.Hour),
+			FundamentalMetrics: durationFromEnv("CACHE_TTL_FUNDAMENTAL_METRICS", 24*time.Hour),
+			MarketStatistics:   durationFromEnv("CACHE_TTL_MARKET_STATISTICS", 5*time.Minute),
+			ScreenerResults:    durationFromEnv("CACHE_TTL_SCREENER_RESULTS", 15*time.Minute),
+			Historical:         durationFromEnv("CACHE_TTL_HISTORICAL", 30*time.Minute),
+			Screener:           durationFromEnv("CACHE_TTL_SCREENER", 10*time.Minute),
+			Symbols:            durationFromEnv("CACHE_TTL_SYMBOLS", 1*time.Hour), // Cache symbols list for 1 hour
+			NotFound:           durationFromEnv("CACHE_TTL_NOT_FOUND", 1*time.Minute),
+			WatchlistPerformance: durationFromEnv("CACHE_TTL_WATCHLIST_PERFORMANCE", 1*time.Minute),
+			UpstreamResponse:   durationFromEnv("CACHE_TTL_UPSTREAM_RESPONSE", 2*time.Minute),
+			ScreenJob:          durationFromEnv("CACHE_TTL_SCREEN_JOB", 10*time.Minute),
+			Quote:              durationFromEnv("CACHE_TTL_QUOTE", 5*time.Second),
+			ScreenerRecent:     durationFromEnv("CACHE_TTL_SCREENER_RECENT", 30*time.Second),
+			SymbolsRefreshInterval: durationFromEnv("CACHE_SYMBOLS_REFRESH_INTERVAL", 0),
 			PersistenceSchedule: persistenceSchedule,
 			EnableRedisFirst:   enableRedisFirst,
 		}
@@ -67,3 +80,31 @@ func parseDuration(envValue string, defaultValue time.Duration) time.Duration {
 
 	return duration
 }
+
+// durationFromEnv reads a non-negative duration from an env var, logging and falling back
+// to the default when the value is malformed or negative
+func durationFromEnv(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		log.Printf("Warning: invalid %s %q (expected a duration like 30m or 1h), using default %v", name, value, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// LogTTLConfig logs the effective cache TTLs so operators can confirm env overrides took effect
+func LogTTLConfig() {
+	cfg := GetTTLConfig()
+	log.Printf("⏱️  Cache TTLs:")
+	log.Printf("   Company Info: %v, Fundamental Data: %v, Fundamental Metrics: %v", cfg.CompanyInfo, cfg.FundamentalData, cfg.FundamentalMetrics)
+	log.Printf("   Market Statistics: %v, Screener Results: %v, Screen Jobs: %v", cfg.MarketStatistics, cfg.ScreenerResults, cfg.ScreenJob)
+	log.Printf("   Historical: %v, Screener: %v, Screener Recent: %v, Symbols: %v", cfg.Historical, cfg.Screener, cfg.ScreenerRecent, cfg.Symbols)
+	log.Printf("   Not Found: %v, Watchlist Performance: %v, Upstream Response: %v, Quote: %v", cfg.NotFound, cfg.WatchlistPerformance, cfg.UpstreamResponse, cfg.Quote)
+	log.Printf("   Persistence Schedule: %v, Redis-first: %v", cfg.PersistenceSchedule, cfg.EnableRedisFirst)
+}
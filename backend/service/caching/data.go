@@ -1,17 +1,33 @@
 package caching
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"screener/backend/model"
 )
 
+// ErrWriteBehindUnavailable is returned by the Cache* methods while Redis is down. The in-memory
+// fallback is bounded and never persisted, so write-behind data must go straight to the database.
+var ErrWriteBehindUnavailable = errors.New("write-behind cache unavailable: Redis is not connected")
+
 // DataCache provides data caching operations for fetched data
 // All data is saved to Redis ONLY (no immediate database writes)
 type DataCache struct {
 	cache *CacheService
 }
 
+// writeBehindReady reports why data can't be queued for the persistence worker, if it can't
+func (d *DataCache) writeBehindReady() error {
+	if d.cache == nil {
+		return fmt.Errorf("cache service not initialized")
+	}
+	if d.cache.IsLocalFallback() {
+		return ErrWriteBehindUnavailable
+	}
+	return nil
+}
+
 // NewDataCache creates a new data cache instance
 func NewDataCache() *DataCache {
 	return &DataCache{
@@ -22,8 +38,8 @@ func NewDataCache() *DataCache {
 // CacheHistorical caches historical data in Redis
 // Key format: cache:data:historical:{symbol}:{range}:{interval}
 func (d *DataCache) CacheHistorical(symbol, rangeParam, interval string, data []model.Historical) error {
-	if d.cache == nil {
-		return fmt.Errorf("cache service not initialized")
+	if err := d.writeBehindReady(); err != nil {
+		return err
 	}
 
 	key := fmt.Sprintf("cache:data:historical:%s:%s:%s", symbol, rangeParam, interval)
@@ -40,8 +56,8 @@ func (d *DataCache) CacheHistorical(symbol, rangeParam, interval string, data []
 // CacheCompanyInfo caches company info in Redis
 // Key format: cache:data:company-info:{symbol}
 func (d *DataCache) CacheCompanyInfo(symbol string, data *model.CompanyInfo) error {
-	if d.cache == nil {
-		return fmt.Errorf("cache service not initialized")
+	if err := d.writeBehindReady(); err != nil {
+		return err
 	}
 
 	key := fmt.Sprintf("cache:data:company-info:%s", symbol)
@@ -50,6 +66,7 @@ func (d *DataCache) CacheCompanyInfo(symbol string, data *model.CompanyInfo) err
 	if err := d.cache.SetJSON(key, data, 0); err != nil {
 		return fmt.Errorf("failed to cache company info: %w", err)
 	}
+	d.clearCompanyInfoNotFound(symbol)
 
 	log.Printf("[CACHE] Cached company info: %s", key)
 	return nil
@@ -58,8 +75,8 @@ func (d *DataCache) CacheCompanyInfo(symbol string, data *model.CompanyInfo) err
 // CacheFundamentalData caches fundamental data in Redis
 // Key format: cache:data:fundamental:{symbol}:{statementType}:{frequency}
 func (d *DataCache) CacheFundamentalData(symbol, statementType, frequency string, data *model.FundamentalData) error {
-	if d.cache == nil {
-		return fmt.Errorf("cache service not initialized")
+	if err := d.writeBehindReady(); err != nil {
+		return err
 	}
 
 	key := fmt.Sprintf("cache:data:fundamental:%s:%s:%s", symbol, statementType, frequency)
@@ -68,6 +85,11 @@ func (d *DataCache) CacheFundamentalData(symbol, statementType, frequency string
 	if err := d.cache.SetJSON(key, data, 0); err != nil {
 		return fmt.Errorf("failed to cache fundamental data: %w", err)
 	}
+	d.clearFundamentalDataNotFound(symbol)
+	// Re-ingested statements invalidate the metrics computed from the previous version
+	if err := d.cache.DeletePattern(fundamentalMetricsPattern(symbol)); err != nil {
+		log.Printf("[CACHE] Failed to invalidate fundamental metrics for %s: %v", symbol, err)
+	}
 
 	log.Printf("[CACHE] Cached fundamental data: %s", key)
 	return nil
@@ -76,8 +98,8 @@ func (d *DataCache) CacheFundamentalData(symbol, statementType, frequency string
 // CacheMarketStatistics caches market statistics in Redis
 // Key format: cache:data:market-statistics:{date}
 func (d *DataCache) CacheMarketStatistics(date string, data *model.MarketStatistics) error {
-	if d.cache == nil {
-		return fmt.Errorf("cache service not initialized")
+	if err := d.writeBehindReady(); err != nil {
+		return err
 	}
 
 	key := fmt.Sprintf("cache:data:market-statistics:%s", date)
@@ -211,27 +233,9 @@ func (d *DataCache) GetAllMarketStatisticsKeys() ([]string, error) {
 	return d.scanKeys(pattern)
 }
 
-// scanKeys scans Redis for keys matching a pattern
+// scanKeys scans the cache for keys matching a pattern
 func (d *DataCache) scanKeys(pattern string) ([]string, error) {
-	client := GetRedisClient()
-	ctx := GetRedisContext()
-
-	if client == nil {
-		return nil, fmt.Errorf("redis client not initialized")
-	}
-
-	var keys []string
-	iter := client.Scan(ctx, 0, pattern, 0).Iterator()
-
-	for iter.Next(ctx) {
-		keys = append(keys, iter.Val())
-	}
-
-	if err := iter.Err(); err != nil {
-		return nil, fmt.Errorf("failed to scan keys: %w", err)
-	}
-
-	return keys, nil
+	return d.cache.Keys(pattern)
 }
 
 // GetHistoricalByKey retrieves historical data by key
@@ -325,21 +329,5 @@ func (d *DataCache) DeleteKeys(keys []string) error {
 		return fmt.Errorf("cache service not initialized")
 	}
 
-	client := GetRedisClient()
-	ctx := GetRedisContext()
-
-	if client == nil {
-		return fmt.Errorf("redis client not initialized")
-	}
-
-	if len(keys) == 0 {
-		return nil
-	}
-
-	err := client.Del(ctx, keys...).Err()
-	if err != nil {
-		return fmt.Errorf("failed to delete keys: %w", err)
-	}
-
-	return nil
+	return d.cache.DeleteKeys(keys...)
 }
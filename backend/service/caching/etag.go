@@ -0,0 +1,58 @@
+package caching
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// etagKey returns the key under which the ETag for a cache entry is stored
+func etagKey(key string) string {
+	return fmt.Sprintf("%s:etag", key)
+}
+
+// ComputeETag computes a weak ETag from a serialized payload
+func ComputeETag(data []byte) string {
+	hash := sha256.Sum256(data)
+	return fmt.Sprintf(`W/"%s"`, hex.EncodeToString(hash[:])[:32])
+}
+
+// SetJSONWithETag marshals and stores a JSON value in cache along with a weak ETag
+// derived from its content. The ETag is stored with the same TTL as the entry.
+func (c *CacheService) SetJSONWithETag(key string, value interface{}, ttl time.Duration) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal data for cache: %w", err)
+	}
+
+	etag := ComputeETag(data)
+	if err := c.Set(key, data, ttl); err != nil {
+		return etag, err
+	}
+	if err := c.Set(etagKey(key), []byte(etag), ttl); err != nil {
+		return etag, err
+	}
+
+	log.Printf("[CACHE SET] Key: %s, TTL: %v, ETag: %s", key, ttl, etag)
+	return etag, nil
+}
+
+// GetETag retrieves the ETag stored alongside a cache entry
+// Returns false if either the entry or its ETag is missing, so a stale ETag
+// never outlives the payload it describes
+func (c *CacheService) GetETag(key string) (string, bool, error) {
+	exists, err := c.Exists(key)
+	if err != nil || !exists {
+		return "", false, err
+	}
+
+	data, err := c.Get(etagKey(key))
+	if err != nil || data == nil {
+		return "", false, err
+	}
+
+	return string(data), true, nil
+}
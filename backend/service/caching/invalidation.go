@@ -19,6 +19,7 @@ func NewInvalidationService() *InvalidationService {
 // InvalidateCompanyInfo invalidates cache for a specific company by symbol
 func (i *InvalidationService) InvalidateCompanyInfo(symbol string) error {
 	key := GenerateKeyFromPath(fmt.Sprintf("company-info/%s", symbol))
+	_ = i.cache.Delete(companyInfoNotFoundKey(symbol))
 	return i.cache.Delete(key)
 }
 
@@ -31,11 +32,14 @@ func (i *InvalidationService) InvalidateAllCompanyInfo() error {
 // InvalidateFundamentalData invalidates cache for a specific symbol's fundamental data
 func (i *InvalidationService) InvalidateFundamentalData(symbol string) error {
 	pattern := GeneratePattern(fmt.Sprintf("fundamental-data/symbol/%s", symbol))
+	_ = i.cache.DeletePattern(fmt.Sprintf("%s:fundamental:%s:*", notFoundPrefix, symbol))
+	_ = i.cache.DeletePattern(fundamentalMetricsPattern(symbol))
 	return i.cache.DeletePattern(pattern)
 }
 
 // InvalidateAllFundamentalData invalidates all fundamental data cache entries
 func (i *InvalidationService) InvalidateAllFundamentalData() error {
+	_ = i.cache.DeletePattern(fundamentalMetricsPattern("*"))
 	pattern := GeneratePattern("fundamental-data")
 	return i.cache.DeletePattern(pattern)
 }
@@ -64,6 +68,12 @@ func (i *InvalidationService) InvalidateScreener() error {
 	return i.cache.DeletePattern(pattern)
 }
 
+// InvalidateScreenerRecent invalidates the recently updated screener feed
+func (i *InvalidationService) InvalidateScreenerRecent() error {
+	pattern := GeneratePattern("screener/recent")
+	return i.cache.DeletePattern(pattern)
+}
+
 // InvalidateHistorical invalidates historical data cache for a specific symbol
 func (i *InvalidationService) InvalidateHistorical(symbol string) error {
 	pattern := GeneratePattern(fmt.Sprintf("historical/%s", symbol))
@@ -76,11 +86,22 @@ func (i *InvalidationService) InvalidateAllHistorical() error {
 	return i.cache.DeletePattern(pattern)
 }
 
-// InvalidateSymbols invalidates the cached symbols list
+// InvalidateSymbols rebuilds the cached symbols list from the database
 // This should be called when screener table is updated (symbols added/removed)
 func (i *InvalidationService) InvalidateSymbols() error {
-	key := GenerateKeyFromPath("screener/symbols")
-	return i.cache.Delete(key)
+	if err := NewSymbolCache().RefreshSymbols(); err != nil {
+		// Don't leave a stale list behind if the rebuild failed
+		_ = i.cache.Delete(symbolsCacheKey)
+		return err
+	}
+	return nil
+}
+
+// InvalidateSymbolsDetailed invalidates the symbol universe pages (screener joined with company info)
+// Call it after screener or company info ingestion
+func (i *InvalidationService) InvalidateSymbolsDetailed() error {
+	pattern := GeneratePattern("symbols/detailed")
+	return i.cache.DeletePattern(pattern)
 }
 
 // InvalidateByPattern invalidates cache entries matching a custom pattern
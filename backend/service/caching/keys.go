@@ -48,6 +48,101 @@ func GenerateKey(endpoint string, params map[string]string) string {
 	return fmt.Sprintf("%s:%s:%s", cachePrefix, endpoint, queryString)
 }
 
+// CompanyInfoPageKey returns the cache key for one page of the all-company-info list
+func CompanyInfoPageKey(page, limit int) string {
+	return GenerateKey("company-info", map[string]string{
+		"page":  fmt.Sprintf("%d", page),
+		"limit": fmt.Sprintf("%d", limit),
+	})
+}
+
+// CompanyInfoProjectionKey returns the cache key for one page of the company-info list projected to fields
+func CompanyInfoProjectionKey(page, limit int, fields []string) string {
+	return GenerateKey("company-info", map[string]string{
+		"page":   fmt.Sprintf("%d", page),
+		"limit":  fmt.Sprintf("%d", limit),
+		"fields": strings.Join(fields, ","),
+	})
+}
+
+// CompanyInfoFacetKey returns the cache key for the distinct values (with counts) of a company-info column
+// It lives under the company-info prefix, so InvalidateAllCompanyInfo clears it on ingestion
+func CompanyInfoFacetKey(column string) string {
+	return GenerateKey("company-info", map[string]string{
+		"facet": column,
+	})
+}
+
+// SymbolsDetailedKey returns the cache key for one page of the symbol universe joined with company info
+func SymbolsDetailedKey(page, limit int) string {
+	return GenerateKey("symbols/detailed", map[string]string{
+		"page":  fmt.Sprintf("%d", page),
+		"limit": fmt.Sprintf("%d", limit),
+	})
+}
+
+// ScreenerProjectionKey returns the cache key for the all-screeners list projected to fields
+func ScreenerProjectionKey(fields []string) string {
+	return GenerateKey("screener", map[string]string{
+		"fields": strings.Join(fields, ","),
+	})
+}
+
+// ScreenerRecentKey returns the cache key for the recently updated screener feed
+func ScreenerRecentKey(limit int) string {
+	return GenerateKey("screener/recent", map[string]string{
+		"limit": fmt.Sprintf("%d", limit),
+	})
+}
+
+// FundamentalMetricsKey returns the cache key for computed fundamental metrics
+// Key format: cache:fundamental-metrics:{symbol}:{statementType}:{frequency}
+func FundamentalMetricsKey(symbol, statementType, frequency string) string {
+	return fmt.Sprintf("%s:fundamental-metrics:%s:%s:%s", cachePrefix, symbol, statementType, frequency)
+}
+
+// fundamentalMetricsPattern matches every cached metrics entry for a symbol ("*" for all symbols)
+func fundamentalMetricsPattern(symbol string) string {
+	return fmt.Sprintf("%s:fundamental-metrics:%s:*", cachePrefix, symbol)
+}
+
+// WatchlistPerformanceKey returns the cache key for a watchlist's aggregate performance
+// The last item update time and item count are part of the key, so price updates and item
+// changes produce a new key instead of needing invalidation
+// Key format: cache:watchlist-performance:{watchlistID}:{lastUpdateUnixNano}:{itemCount}
+func WatchlistPerformanceKey(watchlistID string, lastUpdate int64, itemCount int64) string {
+	return fmt.Sprintf("%s:watchlist-performance:%s:%d:%d", cachePrefix, watchlistID, lastUpdate, itemCount)
+}
+
+// UpstreamResponseKey returns the cache key for a raw upstream response
+// The request URL is hashed so all configured endpoint hosts share one entry per path and query
+// Key format: cache:upstream:{sha256(pathAndQuery)[:32]}
+func UpstreamResponseKey(pathAndQuery string) string {
+	hash := sha256.Sum256([]byte(pathAndQuery))
+	return fmt.Sprintf("%s:upstream:%s", cachePrefix, hex.EncodeToString(hash[:])[:32])
+}
+
+// QuoteKey returns the cache key for a symbol's live simple quote
+// Key format: cache:quote:{symbol}
+func QuoteKey(symbol string) string {
+	return fmt.Sprintf("%s:quote:%s", cachePrefix, symbol)
+}
+
+// ScreenJobKey returns the cache key for a queued screening job
+func ScreenJobKey(jobID string) string {
+	return fmt.Sprintf("%s:screen-jobs:%s", cachePrefix, jobID)
+}
+
+// ScreenWorkerKey returns the cache key an instance's screen workers hold while they're alive
+func ScreenWorkerKey(workerID string) string {
+	return fmt.Sprintf("%s:screen-workers:%s", cachePrefix, workerID)
+}
+
+// ScreenRequestKey returns the cache key mapping a screen's parameters to the job computing it
+func ScreenRequestKey(params map[string]string) string {
+	return GenerateKey("screen-requests", params)
+}
+
 // GenerateKeyFromPath generates a cache key from a full path (e.g., "/api/company-info/AAPL")
 func GenerateKeyFromPath(path string) string {
 	path = strings.Trim(path, "/")
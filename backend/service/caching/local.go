@@ -0,0 +1,199 @@
+package caching
+
+import (
+	"bytes"
+	"container/list"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultLocalCacheSize is the maximum number of entries held by the in-memory fallback
+const defaultLocalCacheSize = 1000
+
+// localCache is a size-bounded, TTL-aware LRU used when Redis is unavailable
+type localCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	items    map[string]*list.Element
+}
+
+// localEntry is a single cached value
+type localEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // zero means no expiration
+}
+
+var (
+	fallbackCache     *localCache
+	fallbackCacheOnce sync.Once
+)
+
+// getFallbackCache returns the process-wide in-memory cache
+// Size is controlled by CACHE_LOCAL_MAX_ENTRIES (default: 1000)
+func getFallbackCache() *localCache {
+	fallbackCacheOnce.Do(func() {
+		size := defaultLocalCacheSize
+		if value := os.Getenv("CACHE_LOCAL_MAX_ENTRIES"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 {
+				log.Printf("Warning: invalid CACHE_LOCAL_MAX_ENTRIES %q, using default %d", value, defaultLocalCacheSize)
+			} else {
+				size = parsed
+			}
+		}
+		fallbackCache = newLocalCache(size)
+	})
+	return fallbackCache
+}
+
+// newLocalCache creates an empty LRU cache holding at most capacity entries
+func newLocalCache(capacity int) *localCache {
+	return &localCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get returns the value for key, or nil on a miss or expired entry
+func (l *localCache) get(key string) []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*localEntry)
+	if l.expired(entry, time.Now()) {
+		l.remove(elem)
+		return nil
+	}
+	l.order.MoveToFront(elem)
+	return entry.value
+}
+
+// set stores value under key; a ttl of 0 never expires
+func (l *localCache) set(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.store(key, value, ttl)
+}
+
+// setNX stores value only if key is absent (or expired) and reports whether it was stored
+func (l *localCache) setNX(key string, value []byte, ttl time.Duration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.items[key]; ok {
+		if !l.expired(elem.Value.(*localEntry), time.Now()) {
+			return false
+		}
+		l.remove(elem)
+	}
+	l.store(key, value, ttl)
+	return true
+}
+
+// deleteIfEquals removes key only if it holds value and reports whether it was removed
+func (l *localCache) deleteIfEquals(key string, value []byte) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	elem, ok := l.items[key]
+	if !ok {
+		return false
+	}
+	entry := elem.Value.(*localEntry)
+	if l.expired(entry, time.Now()) || !bytes.Equal(entry.value, value) {
+		return false
+	}
+	l.remove(elem)
+	return true
+}
+
+// delete removes the given keys
+func (l *localCache) delete(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := l.items[key]; ok {
+			l.remove(elem)
+		}
+	}
+}
+
+// keys returns the live keys matching a Redis-style glob pattern
+func (l *localCache) keys(pattern string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	matches := make([]string, 0)
+	for key, elem := range l.items {
+		if l.expired(elem.Value.(*localEntry), now) {
+			l.remove(elem)
+			continue
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			matches = append(matches, key)
+		}
+	}
+	return matches
+}
+
+// clear removes every entry
+func (l *localCache) clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	l.items = make(map[string]*list.Element)
+}
+
+// len returns the number of entries, including any not yet evicted after expiring
+func (l *localCache) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.items)
+}
+
+// store inserts or replaces an entry and evicts the least recently used when over capacity
+// Callers must hold l.mu
+func (l *localCache) store(key string, value []byte, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := l.items[key]; ok {
+		entry := elem.Value.(*localEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&localEntry{key: key, value: value, expiresAt: expiresAt})
+	for l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+}
+
+// remove deletes an element; callers must hold l.mu
+func (l *localCache) remove(elem *list.Element) {
+	l.order.Remove(elem)
+	delete(l.items, elem.Value.(*localEntry).key)
+}
+
+// expired reports whether an entry's TTL has passed
+func (l *localCache) expired(entry *localEntry, now time.Time) bool {
+	return !entry.expiresAt.IsZero() && now.After(entry.expiresAt)
+}
@@ -0,0 +1,84 @@
+package caching
+
+import (
+	"fmt"
+	"log"
+)
+
+// Negative cache ("tombstone") keys record lookups that returned record-not-found, so repeated
+// requests for a missing symbol are answered without a database query until the TTL expires.
+// Key formats:
+//
+//	cache:notfound:company-info:{symbol}
+//	cache:notfound:fundamental:{symbol}:{statementType}[:{frequency}]
+const notFoundPrefix = "cache:notfound"
+
+// tombstoneValue is the placeholder stored under a negative cache key
+var tombstoneValue = []byte("1")
+
+func companyInfoNotFoundKey(symbol string) string {
+	return fmt.Sprintf("%s:company-info:%s", notFoundPrefix, symbol)
+}
+
+func fundamentalNotFoundKey(symbol, statementType, frequency string) string {
+	if frequency == "" {
+		return fmt.Sprintf("%s:fundamental:%s:%s", notFoundPrefix, symbol, statementType)
+	}
+	return fmt.Sprintf("%s:fundamental:%s:%s:%s", notFoundPrefix, symbol, statementType, frequency)
+}
+
+// MarkCompanyInfoNotFound records that no company info exists for symbol
+func (d *DataCache) MarkCompanyInfoNotFound(symbol string) error {
+	return d.setTombstone(companyInfoNotFoundKey(symbol))
+}
+
+// IsCompanyInfoNotFound reports whether a tombstone exists for symbol's company info
+func (d *DataCache) IsCompanyInfoNotFound(symbol string) bool {
+	return d.hasTombstone(companyInfoNotFoundKey(symbol))
+}
+
+// MarkFundamentalDataNotFound records that no fundamental data exists for the lookup
+// frequency may be empty for lookups that don't filter by frequency
+func (d *DataCache) MarkFundamentalDataNotFound(symbol, statementType, frequency string) error {
+	return d.setTombstone(fundamentalNotFoundKey(symbol, statementType, frequency))
+}
+
+// IsFundamentalDataNotFound reports whether a tombstone exists for the lookup
+func (d *DataCache) IsFundamentalDataNotFound(symbol, statementType, frequency string) bool {
+	return d.hasTombstone(fundamentalNotFoundKey(symbol, statementType, frequency))
+}
+
+// clearCompanyInfoNotFound removes symbol's company info tombstone
+func (d *DataCache) clearCompanyInfoNotFound(symbol string) {
+	if err := d.cache.Delete(companyInfoNotFoundKey(symbol)); err != nil {
+		log.Printf("[CACHE] Failed to clear not-found marker for company info %s: %v", symbol, err)
+	}
+}
+
+// clearFundamentalDataNotFound removes every fundamental data tombstone for symbol
+func (d *DataCache) clearFundamentalDataNotFound(symbol string) {
+	pattern := fmt.Sprintf("%s:fundamental:%s:*", notFoundPrefix, symbol)
+	if err := d.cache.DeletePattern(pattern); err != nil {
+		log.Printf("[CACHE] Failed to clear not-found markers for fundamental data %s: %v", symbol, err)
+	}
+}
+
+func (d *DataCache) setTombstone(key string) error {
+	if d.cache == nil {
+		return fmt.Errorf("cache service not initialized")
+	}
+	// A zero TTL would store the tombstone permanently, so treat it as disabled
+	ttl := GetTTLConfig().NotFound
+	if ttl <= 0 {
+		return nil
+	}
+	return d.cache.Set(key, tombstoneValue, ttl)
+}
+
+func (d *DataCache) hasTombstone(key string) bool {
+	if d.cache == nil || GetTTLConfig().NotFound <= 0 {
+		return false
+	}
+	exists, err := d.cache.Exists(key)
+	return err == nil && exists
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"screener/backend/database"
+	"screener/backend/model"
 	"strings"
 
 	"gorm.io/gorm"
@@ -61,20 +62,7 @@ func (p *PersistenceService) PersistHistoricalData() error {
 		}
 
 		// Batch upsert to database
-		err = p.db.Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "symbol"}, {Name: "epoch"}, {Name: "range"}, {Name: "interval"},
-			},
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"open":       gorm.Expr("excluded.open"),
-				"high":       gorm.Expr("excluded.high"),
-				"low":        gorm.Expr("excluded.low"),
-				"close":      gorm.Expr("excluded.close"),
-				"adj_close":  gorm.Expr("excluded.adj_close"),
-				"volume":     gorm.Expr("excluded.volume"),
-				"updated_at": gorm.Expr("NOW()"),
-			}),
-		}).CreateInBatches(historical, batchSize).Error
+		err = p.db.Clauses(model.HistoricalUpsertClause()).CreateInBatches(historical, batchSize).Error
 
 		if err != nil {
 			log.Printf("[PERSIST] Error persisting historical data for key %s: %v", key, err)
@@ -175,17 +163,22 @@ func (p *PersistenceService) PersistFundamentalData() error {
 			continue
 		}
 
-		// Upsert to database
-		err = p.db.Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "symbol"},
-				{Name: "statement_type"},
-				{Name: "frequency"},
-			},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"statement", "updated_at",
-			}),
-		}).Create(fundamentalData).Error
+		// Upsert to database, rebuilding the normalized line items in the same transaction
+		err = p.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "symbol"},
+					{Name: "statement_type"},
+					{Name: "frequency"},
+				},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"statement", "updated_at",
+				}),
+			}).Create(fundamentalData).Error; err != nil {
+				return err
+			}
+			return model.ReplaceFundamentalLineItems(tx, fundamentalData)
+		})
 
 		if err != nil {
 			log.Printf("[PERSIST] Error persisting fundamental data for key %s: %v", key, err)
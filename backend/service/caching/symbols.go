@@ -6,7 +6,10 @@ import (
 	"log"
 	"screener/backend/database"
 	"screener/backend/model"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -91,13 +94,125 @@ func (s *SymbolCache) GetAllSymbols() ([]string, error) {
 	return symbols, nil
 }
 
-// RefreshSymbols manually refreshes the symbol cache from the database
+// GetSymbolsUpdatedSince returns symbols whose screener row was updated after the given time
+// Always reads the database: the cached symbol list carries no timestamps, and screener rows
+// are updated by ingestion without touching the symbol cache
+func (s *SymbolCache) GetSymbolsUpdatedSince(since time.Time) ([]string, error) {
+	var symbols []string
+	if err := s.db.Model(&model.Screener{}).
+		Where("updated_at > ?", since).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load updated symbols from database: %w", err)
+	}
+	return symbols, nil
+}
+
+// symbolsRefreshLockKey guards RefreshSymbols across server instances sharing one Redis
+const symbolsRefreshLockKey = "cache:symbols:refresh-lock"
+
+// symbolsRefreshLockTTL bounds how long a crashed instance can hold the refresh lock
+const symbolsRefreshLockTTL = 30 * time.Second
+
+// symbolRefreshCall tracks an in-flight refresh so concurrent callers share its result
+type symbolRefreshCall struct {
+	done chan struct{}
+	err  error
+}
+
+var (
+	symbolRefreshMu       sync.Mutex
+	symbolRefreshInFlight *symbolRefreshCall
+)
+
+// RefreshSymbols force-reloads the symbol cache from the database
+// The cached list is overwritten in place rather than deleted first, so readers never see a miss.
+// Concurrent calls in this process wait for the in-flight refresh, and a Redis lock ensures only
+// one instance rebuilds at a time; an instance that loses the lock skips its refresh.
 func (s *SymbolCache) RefreshSymbols() error {
-	// Delete existing cache
-	if err := s.cache.Delete(symbolsCacheKey); err != nil {
-		log.Printf("Warning: Failed to delete existing symbol cache: %v", err)
+	symbolRefreshMu.Lock()
+	if call := symbolRefreshInFlight; call != nil {
+		symbolRefreshMu.Unlock()
+		<-call.done
+		return call.err
+	}
+	call := &symbolRefreshCall{done: make(chan struct{})}
+	symbolRefreshInFlight = call
+	symbolRefreshMu.Unlock()
+
+	call.err = s.refreshSymbols()
+
+	symbolRefreshMu.Lock()
+	symbolRefreshInFlight = nil
+	symbolRefreshMu.Unlock()
+	close(call.done)
+
+	return call.err
+}
+
+// refreshSymbols rebuilds the cached symbol list while holding the distributed refresh lock
+func (s *SymbolCache) refreshSymbols() error {
+	// A random token identifies this holder, so a refresh that outlives the lock TTL can't
+	// release a lock another instance has since acquired
+	token := []byte(uuid.NewString())
+	acquired, err := s.cache.SetNX(symbolsRefreshLockKey, token, symbolsRefreshLockTTL)
+	if err != nil {
+		return fmt.Errorf("failed to acquire symbol refresh lock: %w", err)
+	}
+	if !acquired {
+		log.Println("Symbol cache refresh already in progress on another instance, skipping")
+		return nil
+	}
+	defer func() {
+		released, err := s.cache.DeleteIfEquals(symbolsRefreshLockKey, token)
+		if err != nil {
+			log.Printf("Warning: Failed to release symbol refresh lock: %v", err)
+		} else if !released {
+			log.Println("Warning: Symbol refresh lock expired before the refresh finished")
+		}
+	}()
+
+	var symbols []string
+	if err := s.db.Model(&model.Screener{}).Distinct("symbol").Pluck("symbol", &symbols).Error; err != nil {
+		return fmt.Errorf("failed to load symbols from database: %w", err)
 	}
 
-	// Reload from database
-	return s.LoadAllSymbols()
+	if len(symbols) == 0 {
+		log.Println("Warning: No symbols found in screener table")
+		return s.cache.Delete(symbolsCacheKey)
+	}
+
+	data, err := json.Marshal(symbols)
+	if err != nil {
+		return fmt.Errorf("failed to marshal symbols: %w", err)
+	}
+
+	if err := s.cache.Set(symbolsCacheKey, data, 0); err != nil {
+		return fmt.Errorf("failed to cache symbols: %w", err)
+	}
+
+	log.Printf("Successfully refreshed %d symbols in Redis cache", len(symbols))
+	return nil
+}
+
+// StartPeriodicRefresh refreshes the symbol cache on the given interval until the returned stop function is called
+func (s *SymbolCache) StartPeriodicRefresh(interval time.Duration) func() {
+	ticker := time.NewTicker(interval)
+	stopChan := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.RefreshSymbols(); err != nil {
+					log.Printf("Warning: Periodic symbol cache refresh failed: %v", err)
+				}
+			case <-stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(stopChan) }
 }
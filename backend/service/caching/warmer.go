@@ -0,0 +1,113 @@
+package caching
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"screener/backend/database"
+	"screener/backend/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CacheWarmer preloads frequently requested list endpoints into Redis so the
+// first requests after a deploy don't all miss
+type CacheWarmer struct {
+	cache *CacheService
+	db    *gorm.DB
+	ttl   *CacheTTLConfig
+}
+
+// NewCacheWarmer creates a new cache warmer instance
+func NewCacheWarmer() *CacheWarmer {
+	return &CacheWarmer{
+		cache: NewCacheService(),
+		db:    database.GetDB(),
+		ttl:   GetTTLConfig(),
+	}
+}
+
+// IsCacheWarmingEnabled reports whether startup cache warming is enabled
+// Controlled by CACHE_WARM_ON_STARTUP (default: true)
+func IsCacheWarmingEnabled() bool {
+	value := os.Getenv("CACHE_WARM_ON_STARTUP")
+	return !(value == "false" || value == "0")
+}
+
+// WarmAll preloads the first company-info page and the all-screeners list into Redis
+// Uses the same cache keys and TTLs as the corresponding service reads
+func (w *CacheWarmer) WarmAll() error {
+	start := time.Now()
+
+	companyCount, err := w.WarmCompanyInfo()
+	if err != nil {
+		return err
+	}
+
+	screenerCount, err := w.WarmScreeners()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[WARMER] Cache warming completed in %v (company_info: %d, screener: %d)",
+		time.Since(start), companyCount, screenerCount)
+	return nil
+}
+
+// DefaultCompanyInfoPageLimit is the page size of the company-info list when no limit is given
+const DefaultCompanyInfoPageLimit = 50
+
+// WarmCompanyInfo loads the first default-sized page of company info into the company-info list cache
+// The payload mirrors service.CompanyInfoPage so the service reads it as a regular cache hit
+func (w *CacheWarmer) WarmCompanyInfo() (int, error) {
+	start := time.Now()
+
+	var total int64
+	if err := w.db.Model(&model.CompanyInfo{}).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count company info: %w", err)
+	}
+
+	companyInfo := make([]model.CompanyInfo, 0)
+	if err := w.db.Order("symbol ASC").Limit(DefaultCompanyInfoPageLimit).Find(&companyInfo).Error; err != nil {
+		return 0, fmt.Errorf("failed to load company info: %w", err)
+	}
+
+	page := struct {
+		Data       []model.CompanyInfo `json:"data"`
+		Page       int                 `json:"page"`
+		Limit      int                 `json:"limit"`
+		Total      int64               `json:"total"`
+		TotalPages int                 `json:"total_pages"`
+	}{
+		Data:       companyInfo,
+		Page:       1,
+		Limit:      DefaultCompanyInfoPageLimit,
+		Total:      total,
+		TotalPages: int((total + DefaultCompanyInfoPageLimit - 1) / DefaultCompanyInfoPageLimit),
+	}
+
+	if _, err := w.cache.SetJSONWithETag(CompanyInfoPageKey(1, DefaultCompanyInfoPageLimit), page, w.ttl.CompanyInfo); err != nil {
+		return 0, fmt.Errorf("failed to cache company info: %w", err)
+	}
+
+	log.Printf("[WARMER] Loaded %d company info records in %v", len(companyInfo), time.Since(start))
+	return len(companyInfo), nil
+}
+
+// WarmScreeners loads all screener records into the screener list cache
+func (w *CacheWarmer) WarmScreeners() (int, error) {
+	start := time.Now()
+
+	var screeners []model.Screener
+	if err := w.db.Find(&screeners).Error; err != nil {
+		return 0, fmt.Errorf("failed to load screeners: %w", err)
+	}
+
+	if _, err := w.cache.SetJSONWithETag(GenerateKeyFromPath("screener"), screeners, w.ttl.Screener); err != nil {
+		return 0, fmt.Errorf("failed to cache screeners: %w", err)
+	}
+
+	log.Printf("[WARMER] Loaded %d screener records in %v", len(screeners), time.Since(start))
+	return len(screeners), nil
+}
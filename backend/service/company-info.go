@@ -6,8 +6,11 @@ import (
 	"screener/backend/database"
 	"screener/backend/model"
 	"screener/backend/service/caching"
+	"strconv"
+	"sync/atomic"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CompanyInfoService contains business logic for company info operations
@@ -26,27 +29,129 @@ func NewCompanyInfoService() *CompanyInfoService {
 	}
 }
 
-// GetAllCompanyInfo fetches all company info records (read-only)
-func (s *CompanyInfoService) GetAllCompanyInfo() ([]model.CompanyInfo, error) {
+// Company info list and search pagination bounds
+const (
+	DefaultCompanyInfoPageLimit = caching.DefaultCompanyInfoPageLimit
+	MaxCompanyInfoPageLimit     = 200
+)
+
+// CompanyInfoPage represents a paginated page of company info records
+type CompanyInfoPage struct {
+	Data       []model.CompanyInfo `json:"data"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	Total      int64               `json:"total"`
+	TotalPages int                 `json:"total_pages"`
+}
+
+// normalizeCompanyInfoPagination applies the default page/limit and caps the limit
+func normalizeCompanyInfoPagination(pagination *PaginationOptions) PaginationOptions {
+	normalized := PaginationOptions{Page: 1, Limit: DefaultCompanyInfoPageLimit}
+	if pagination != nil {
+		if pagination.Page > 0 {
+			normalized.Page = pagination.Page
+		}
+		if pagination.Limit > 0 {
+			normalized.Limit = pagination.Limit
+		}
+	}
+	if normalized.Limit > MaxCompanyInfoPageLimit {
+		normalized.Limit = MaxCompanyInfoPageLimit
+	}
+	return normalized
+}
+
+// paginateCompanyInfo counts and fetches one page of the given query
+// When columns are given only those are selected for the page (the count is unaffected)
+func paginateCompanyInfo(query *gorm.DB, pagination PaginationOptions, columns ...string) (*CompanyInfoPage, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Model(&model.CompanyInfo{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count company info: %w", err)
+	}
+
+	companyInfo := make([]model.CompanyInfo, 0)
+	offset := (pagination.Page - 1) * pagination.Limit
+	pageQuery := query.Offset(offset).Limit(pagination.Limit)
+	if len(columns) > 0 {
+		pageQuery = pageQuery.Select(columns)
+	}
+	if err := pageQuery.Find(&companyInfo).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch company info: %w", err)
+	}
+
+	totalPages := int((total + int64(pagination.Limit) - 1) / int64(pagination.Limit))
+	return &CompanyInfoPage{
+		Data:       companyInfo,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// GetAllCompanyInfo fetches a page of company info records (read-only), ordered by symbol
+// Pages default to DefaultCompanyInfoPageLimit records and are capped at MaxCompanyInfoPageLimit
+func (s *CompanyInfoService) GetAllCompanyInfo(pagination *PaginationOptions) (*CompanyInfoPage, error) {
+	normalized := normalizeCompanyInfoPagination(pagination)
+
 	// Try to get from cache
-	cacheKey := caching.GenerateKeyFromPath("company-info")
-	var companyInfo []model.CompanyInfo
-	
-	found, err := s.cache.GetJSON(cacheKey, &companyInfo)
+	cacheKey := caching.CompanyInfoPageKey(normalized.Page, normalized.Limit)
+	var page CompanyInfoPage
+
+	found, err := s.cache.GetJSON(cacheKey, &page)
 	if err == nil && found {
-		return companyInfo, nil
+		return &page, nil
 	}
 
 	// Cache miss - query database
-	result := s.db.Find(&companyInfo)
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to fetch company info: %w", result.Error)
+	result, err := paginateCompanyInfo(s.db.Order("symbol ASC"), normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in cache along with its ETag
+	_, _ = s.cache.SetJSONWithETag(cacheKey, result, s.ttl.CompanyInfo)
+
+	return result, nil
+}
+
+// GetAllCompanyInfoFields is GetAllCompanyInfo selecting only the given fields (validated with
+// ParseFields against CompanyInfoFields); other fields are left zero so callers should ProjectFields
+func (s *CompanyInfoService) GetAllCompanyInfoFields(pagination *PaginationOptions, fields []string) (*CompanyInfoPage, error) {
+	if len(fields) == 0 {
+		return s.GetAllCompanyInfo(pagination)
+	}
+	normalized := normalizeCompanyInfoPagination(pagination)
+
+	// Try to get from cache
+	cacheKey := caching.CompanyInfoProjectionKey(normalized.Page, normalized.Limit, fields)
+	var page CompanyInfoPage
+
+	found, err := s.cache.GetJSON(cacheKey, &page)
+	if err == nil && found {
+		return &page, nil
+	}
+
+	// Cache miss - query database
+	result, err := paginateCompanyInfo(s.db.Order("symbol ASC"), normalized, projectionColumns(fields, CompanyInfoFields)...)
+	if err != nil {
+		return nil, err
 	}
 
 	// Store in cache
-	_ = s.cache.SetJSON(cacheKey, companyInfo, s.ttl.CompanyInfo)
+	_ = s.cache.SetJSON(cacheKey, result, s.ttl.CompanyInfo)
 
-	return companyInfo, nil
+	return result, nil
+}
+
+// GetAllCompanyInfoETag returns the ETag of a cached all-company-info page, if present
+func (s *CompanyInfoService) GetAllCompanyInfoETag(pagination *PaginationOptions) (string, bool) {
+	normalized := normalizeCompanyInfoPagination(pagination)
+	etag, found, err := s.cache.GetETag(caching.CompanyInfoPageKey(normalized.Page, normalized.Limit))
+	if err != nil {
+		return "", false
+	}
+	return etag, found
 }
 
 // GetCompanyInfoBySymbol fetches company info by symbol
@@ -63,11 +168,17 @@ func (s *CompanyInfoService) GetCompanyInfoBySymbol(symbol string) (*model.Compa
 		return companyInfo, nil
 	}
 
+	// A recent lookup already found nothing; skip the database until the tombstone expires
+	if dataCache.IsCompanyInfoNotFound(symbol) {
+		return nil, errors.New("record not found")
+	}
+
 	// Redis miss - check database
 	var dbCompanyInfo model.CompanyInfo
 	result := s.db.Where("symbol = ?", symbol).First(&dbCompanyInfo)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			_ = dataCache.MarkCompanyInfoNotFound(symbol)
 			return nil, errors.New("record not found")
 		}
 		return nil, fmt.Errorf("failed to fetch company info: %w", result.Error)
@@ -108,37 +219,56 @@ func (s *CompanyInfoService) GetCompanyInfoBySymbols(symbols []string) ([]model.
 	return companyInfo, nil
 }
 
-// SearchCompanyInfo searches company info by name, sector, or industry
-func (s *CompanyInfoService) SearchCompanyInfo(searchTerm string) ([]model.CompanyInfo, error) {
+// SearchCompanyInfo searches company info by name, sector, industry, or symbol and returns one page of matches
+// Results are ranked: exact symbol match, symbol prefix, name prefix, name substring,
+// then sector/industry-only matches; ties are ordered by symbol
+func (s *CompanyInfoService) SearchCompanyInfo(searchTerm string, pagination *PaginationOptions) (*CompanyInfoPage, error) {
+	normalized := normalizeCompanyInfoPagination(pagination)
 	if searchTerm == "" {
-		return []model.CompanyInfo{}, nil
+		return &CompanyInfoPage{Data: []model.CompanyInfo{}, Page: normalized.Page, Limit: normalized.Limit}, nil
 	}
 
 	// Try to get from cache
 	cacheKey := caching.GenerateKey("company-info/search", map[string]string{
-		"q": searchTerm,
+		"q":     searchTerm,
+		"page":  strconv.Itoa(normalized.Page),
+		"limit": strconv.Itoa(normalized.Limit),
 	})
-	var companyInfo []model.CompanyInfo
-	
-	found, err := s.cache.GetJSON(cacheKey, &companyInfo)
+	var page CompanyInfoPage
+
+	found, err := s.cache.GetJSON(cacheKey, &page)
 	if err == nil && found {
-		return companyInfo, nil
+		return &page, nil
 	}
 
 	// Cache miss - query database
 	searchPattern := "%" + searchTerm + "%"
-	result := s.db.Where(
+	prefixPattern := searchTerm + "%"
+	query := s.db.Where(
 		"name ILIKE ? OR sector ILIKE ? OR industry ILIKE ? OR symbol ILIKE ?",
 		searchPattern, searchPattern, searchPattern, searchPattern,
-	).Find(&companyInfo)
-	if result.Error != nil {
-		return nil, fmt.Errorf("failed to search company info: %w", result.Error)
+	).Clauses(clause.OrderBy{
+		Expression: clause.Expr{
+			SQL: `CASE
+				WHEN UPPER(symbol) = UPPER(?) THEN 0
+				WHEN symbol ILIKE ? THEN 1
+				WHEN name ILIKE ? THEN 2
+				WHEN name ILIKE ? THEN 3
+				ELSE 4
+			END, symbol ASC`,
+			Vars:               []interface{}{searchTerm, prefixPattern, prefixPattern, searchPattern},
+			WithoutParentheses: true,
+		},
+	})
+	result, err := paginateCompanyInfo(query, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search company info: %w", err)
 	}
 
 	// Store in cache
-	_ = s.cache.SetJSON(cacheKey, companyInfo, s.ttl.CompanyInfo)
+	_ = s.cache.SetJSON(cacheKey, result, s.ttl.CompanyInfo)
 
-	return companyInfo, nil
+	return result, nil
 }
 
 // GetCompanyInfoBySector fetches all company info records for a specific sector
@@ -194,3 +324,101 @@ func (s *CompanyInfoService) GetCompanyInfoByIndustry(industry string) ([]model.
 
 	return companyInfo, nil
 }
+
+// CompanyInfoFacet is one distinct sector or industry and how many companies have it
+type CompanyInfoFacet struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// GetSectors returns the distinct sectors, sorted, with company counts
+func (s *CompanyInfoService) GetSectors() ([]CompanyInfoFacet, error) {
+	return s.getFacet("sector")
+}
+
+// GetIndustries returns the distinct industries, sorted, with company counts
+func (s *CompanyInfoService) GetIndustries() ([]CompanyInfoFacet, error) {
+	return s.getFacet("industry")
+}
+
+// getFacet groups company info by a column in one query; blank values are left out
+func (s *CompanyInfoService) getFacet(column string) ([]CompanyInfoFacet, error) {
+	cacheKey := caching.CompanyInfoFacetKey(column)
+	var facets []CompanyInfoFacet
+
+	found, err := s.cache.GetJSON(cacheKey, &facets)
+	if err == nil && found {
+		return facets, nil
+	}
+
+	facets = make([]CompanyInfoFacet, 0)
+	result := s.db.Model(&model.CompanyInfo{}).
+		Select(fmt.Sprintf("%s AS value, COUNT(*) AS count", column)).
+		Where(fmt.Sprintf("%s IS NOT NULL AND %s <> ''", column, column)).
+		Group(column).
+		Order(column).
+		Scan(&facets)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch distinct %s values: %w", column, result.Error)
+	}
+
+	_ = s.cache.SetJSON(cacheKey, facets, s.ttl.CompanyInfo)
+	return facets, nil
+}
+
+// ErrFuzzySearchUnavailable is returned when the pg_trgm extension isn't installed
+var ErrFuzzySearchUnavailable = errors.New("fuzzy search unavailable: pg_trgm extension is not installed")
+
+// DefaultFuzzySimilarity is the minimum trigram similarity (0-1) for a fuzzy match
+const DefaultFuzzySimilarity = 0.3
+
+// CompanyInfoMatch is a company info record with its fuzzy match score
+type CompanyInfoMatch struct {
+	model.CompanyInfo
+	Similarity float64 `json:"similarity"`
+}
+
+// trgmAvailable caches a positive pg_trgm check so fuzzy searches don't re-query pg_extension
+var trgmAvailable atomic.Bool
+
+// FuzzySearchCompanyInfo finds companies whose symbol or name is similar to the query, tolerating typos.
+// Matches are scored by the higher of symbol and name trigram similarity and only those at or
+// above minSimilarity are returned, best first. Candidates are found with the pg_trgm % operator
+// (threshold set to minSimilarity for the transaction) so the company_info trigram indexes are used
+// instead of scoring every row.
+func (s *CompanyInfoService) FuzzySearchCompanyInfo(query string, limit int, minSimilarity float64) ([]CompanyInfoMatch, error) {
+	if query == "" {
+		return []CompanyInfoMatch{}, nil
+	}
+
+	if !trgmAvailable.Load() {
+		var count int64
+		if err := s.db.Raw(`SELECT COUNT(*) FROM pg_extension WHERE extname = 'pg_trgm'`).Scan(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to check pg_trgm extension: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrFuzzySearchUnavailable
+		}
+		trgmAvailable.Store(true)
+	}
+
+	matches := make([]CompanyInfoMatch, 0)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`SELECT set_config('pg_trgm.similarity_threshold', ?, true)`,
+			strconv.FormatFloat(minSimilarity, 'f', -1, 64)).Error; err != nil {
+			return err
+		}
+		return tx.Raw(`
+			SELECT *, GREATEST(similarity(symbol, ?), similarity(name, ?)) AS similarity
+			FROM company_info
+			WHERE deleted_at IS NULL AND (symbol % ? OR name % ?)
+			ORDER BY similarity DESC, symbol ASC
+			LIMIT ?
+		`, query, query, query, query, limit).Scan(&matches).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fuzzy search company info: %w", err)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFuzzySearchCompanyInfoFiltersWithTheTrigramOperator(t *testing.T) {
+	db, mock := newMockDB(t)
+	trgmAvailable.Store(true)
+	t.Cleanup(func() { trgmAvailable.Store(false) })
+
+	// The threshold is set for the transaction so "%" (which the GIN indexes serve) filters at minSimilarity
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT set_config('pg_trgm.similarity_threshold', $1, true)`)).
+		WithArgs("0.4").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE deleted_at IS NULL AND (symbol % $3 OR name % $4)`)+`\s*`+
+		regexp.QuoteMeta(`ORDER BY similarity DESC, symbol ASC`)).
+		WithArgs("appel", "appel", "appel", "appel", 5).
+		WillReturnRows(sqlmock.NewRows([]string{"symbol", "name", "similarity"}).AddRow("AAPL", "Apple Inc.", 0.5))
+	mock.ExpectCommit()
+
+	s := &CompanyInfoService{db: db}
+	matches, err := s.FuzzySearchCompanyInfo("appel", 5, 0.4)
+	if err != nil {
+		t.Fatalf("FuzzySearchCompanyInfo returned error: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Symbol != "AAPL" || matches[0].Similarity != 0.5 {
+		t.Errorf("matches = %+v, want AAPL at 0.5", matches)
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"screener/backend/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type metricsSnapshotKey struct{}
+
+// WithMetricsSnapshots returns a context whose company-info ingestion also records a dated
+// CompanyMetricsSnapshot per symbol. Snapshots are opt-in to keep the table's growth deliberate.
+func WithMetricsSnapshots(ctx context.Context) context.Context {
+	return context.WithValue(ctx, metricsSnapshotKey{}, true)
+}
+
+// metricsSnapshotsEnabled reports whether ctx opted in to snapshots, or COMPANY_METRICS_SNAPSHOTS
+// enables them for every company-info ingestion
+func metricsSnapshotsEnabled(ctx context.Context) bool {
+	if enabled, _ := ctx.Value(metricsSnapshotKey{}).(bool); enabled {
+		return true
+	}
+	return strings.EqualFold(os.Getenv("COMPANY_METRICS_SNAPSHOTS"), "true")
+}
+
+// saveCompanyMetricsSnapshots upserts today's snapshot for each company-info record,
+// so re-running ingestion on the same day replaces that day's row
+func (s *FetcherService) saveCompanyMetricsSnapshots(infos []model.CompanyInfo) error {
+	if len(infos) == 0 {
+		return nil
+	}
+
+	today := time.Now().UTC()
+	snapshots := make([]model.CompanyMetricsSnapshot, 0, len(infos))
+	for i := range infos {
+		snapshots = append(snapshots, model.NewCompanyMetricsSnapshot(&infos[i], today))
+	}
+
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "pe", "market_cap", "beta", "ytd_return", "year_return", "volume", "updated_at"}),
+	}).CreateInBatches(snapshots, 500).Error; err != nil {
+		return fmt.Errorf("failed to save company metrics snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetCompanyMetricsHistory returns a symbol's metric snapshots between from and to (inclusive), oldest first
+func (s *CompanyInfoService) GetCompanyMetricsHistory(symbol string, from, to time.Time) ([]model.CompanyMetricsSnapshot, error) {
+	snapshots := make([]model.CompanyMetricsSnapshot, 0)
+	if err := s.db.Where("symbol = ? AND date BETWEEN ? AND ?", strings.ToUpper(symbol), from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch company metrics history: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+	"sync"
+)
+
+// MaxCompareSymbols caps how many symbols one comparison can include
+const MaxCompareSymbols = 5
+
+// CompareService assembles side-by-side views of several symbols from the existing services
+type CompareService struct {
+	screener    *ScreenerService
+	companyInfo *CompanyInfoService
+	fundamental *FundamentalDataService
+}
+
+// NewCompareService creates a new instance of CompareService
+func NewCompareService() *CompareService {
+	return &CompareService{
+		screener:    NewScreenerService(),
+		companyInfo: NewCompanyInfoService(),
+		fundamental: NewFundamentalDataService(),
+	}
+}
+
+// CompanySummary is the subset of company info shown in a comparison
+type CompanySummary struct {
+	Name          string `json:"name"`
+	Sector        string `json:"sector,omitempty"`
+	Industry      string `json:"industry,omitempty"`
+	Price         string `json:"price,omitempty"`
+	PercentChange string `json:"percentChange,omitempty"`
+	MarketCap     string `json:"marketCap,omitempty"`
+	PE            string `json:"pe,omitempty"`
+	Beta          string `json:"beta,omitempty"`
+	YearHigh      string `json:"yearHigh,omitempty"`
+	YearLow       string `json:"yearLow,omitempty"`
+	YtdReturn     string `json:"ytdReturn,omitempty"`
+	YearReturn    string `json:"yearReturn,omitempty"`
+	Logo          string `json:"logo,omitempty"`
+}
+
+// SymbolComparison holds everything known about one compared symbol
+// Sections with no data are nil; Errors lists sections that failed to load
+type SymbolComparison struct {
+	Symbol      string              `json:"symbol"`
+	Screener    *ScreenerDTO        `json:"screener"`
+	CompanyInfo *CompanySummary     `json:"company_info"`
+	Metrics     *FundamentalMetrics `json:"metrics"`
+	Errors      map[string]string   `json:"errors,omitempty"`
+}
+
+// Comparison is the result of comparing several symbols
+type Comparison struct {
+	Symbols []SymbolComparison `json:"symbols"` // In request order, excluding missing symbols
+	Missing []string           `json:"missing"` // Symbols with no screener, company info or fundamental data
+}
+
+// CompareSymbols loads screener data, a company-info summary and fundamental metrics (for the given
+// statement type and frequency) for each symbol. All lookups run concurrently.
+func (s *CompareService) CompareSymbols(symbols []string, statementType, frequency string) (*Comparison, error) {
+	normalized, err := NormalizeFilterSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("at least one symbol is required")
+	}
+	if len(normalized) > MaxCompareSymbols {
+		return nil, fmt.Errorf("too many symbols: %d (max %d)", len(normalized), MaxCompareSymbols)
+	}
+
+	results := make([]SymbolComparison, len(normalized))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	recordError := func(i int, section string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if results[i].Errors == nil {
+			results[i].Errors = make(map[string]string)
+		}
+		results[i].Errors[section] = err.Error()
+	}
+
+	for i, symbol := range normalized {
+		results[i].Symbol = symbol
+		wg.Add(3)
+
+		go func(i int, symbol string) {
+			defer wg.Done()
+			screener, err := s.screener.GetScreenerBySymbol(symbol)
+			if err != nil {
+				if err.Error() != "record not found" {
+					recordError(i, "screener", err)
+				}
+				return
+			}
+			dto := EnrichScreener(*screener)
+			results[i].Screener = &dto
+		}(i, symbol)
+
+		go func(i int, symbol string) {
+			defer wg.Done()
+			info, err := s.companyInfo.GetCompanyInfoBySymbol(symbol)
+			if err != nil {
+				if err.Error() != "record not found" {
+					recordError(i, "company_info", err)
+				}
+				return
+			}
+			results[i].CompanyInfo = summarizeCompany(info)
+		}(i, symbol)
+
+		go func(i int, symbol string) {
+			defer wg.Done()
+			metrics, err := s.fundamental.GetFundamentalMetrics(symbol, statementType, frequency)
+			if err != nil {
+				if err.Error() != "record not found" {
+					recordError(i, "metrics", err)
+				}
+				return
+			}
+			// The parsed statement is large and not needed side by side
+			selected := *metrics
+			selected.ParsedStatement = nil
+			results[i].Metrics = &selected
+		}(i, symbol)
+	}
+	wg.Wait()
+
+	comparison := &Comparison{
+		Symbols: make([]SymbolComparison, 0, len(results)),
+		Missing: make([]string, 0),
+	}
+	for _, r := range results {
+		if r.Screener == nil && r.CompanyInfo == nil && r.Metrics == nil && len(r.Errors) == 0 {
+			comparison.Missing = append(comparison.Missing, r.Symbol)
+			continue
+		}
+		comparison.Symbols = append(comparison.Symbols, r)
+	}
+	return comparison, nil
+}
+
+// summarizeCompany picks the comparison fields out of a company info record
+func summarizeCompany(info *model.CompanyInfo) *CompanySummary {
+	return &CompanySummary{
+		Name:          info.Name,
+		Sector:        info.Sector,
+		Industry:      info.Industry,
+		Price:         info.Price,
+		PercentChange: info.PercentChange,
+		MarketCap:     info.MarketCap,
+		PE:            info.PE,
+		Beta:          info.Beta,
+		YearHigh:      info.YearHigh,
+		YearLow:       info.YearLow,
+		YtdReturn:     info.YtdReturn,
+		YearReturn:    info.YearReturn,
+		Logo:          info.Logo,
+	}
+}
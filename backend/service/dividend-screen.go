@@ -0,0 +1,83 @@
+package service
+
+import (
+	"math"
+	"sort"
+)
+
+// DividendYieldFilter bounds the trailing dividend yield (%) for the dividend screen
+type DividendYieldFilter struct {
+	MinYield         *float64 `json:"minYield,omitempty"`
+	MaxYield         *float64 `json:"maxYield,omitempty"`
+	Frequency        string   `json:"frequency"`        // "annual" (latest year) or "quarterly" (sum of the latest four quarters)
+	IncludeNonPayers bool     `json:"includeNonPayers"` // Keep symbols with no dividends, at a yield of 0
+}
+
+// DividendYield is one symbol's trailing dividend yield and the values it was computed from
+type DividendYield struct {
+	Symbol            string  `json:"symbol"`
+	Period            string  `json:"period"` // Latest statement period used
+	DividendsPaid     float64 `json:"dividendsPaid"`
+	AverageShares     float64 `json:"averageShares"`
+	DividendsPerShare float64 `json:"dividendsPerShare"`
+	Price             float64 `json:"price"`
+	Yield             float64 `json:"yield"` // DividendsPerShare / Price * 100
+}
+
+// GetStocksByDividendYield returns stocks whose yield, annual dividends per share / price, falls within
+// the filter bounds. Dividends come from cash flow statements and share counts from income statements
+// of the same frequency; price is the latest screener close. Results are sorted by yield, highest first.
+func (s *FundamentalDataService) GetStocksByDividendYield(filter DividendYieldFilter) ([]DividendYield, error) {
+	periods, err := trailingPeriods(filter.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := s.loadStatementMetrics(filter.Frequency, "cashflow", "income")
+	if err != nil {
+		return nil, err
+	}
+	cashflow, income := statements["cashflow"], statements["income"]
+
+	prices, err := s.screenerCloses()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DividendYield, 0)
+	for symbol, metrics := range cashflow {
+		price := prices[symbol]
+		var shares map[string]float64
+		if incomeMetrics, ok := income[symbol]; ok {
+			shares = incomeMetrics.AverageShares
+		}
+		period, shareCount, ok := latestDatedValue(shares)
+		if !ok || shareCount <= 0 || price <= 0 {
+			continue
+		}
+
+		result := DividendYield{Symbol: symbol, Period: period, AverageShares: shareCount, Price: price}
+		if paid, dividendPeriod, ok := trailingSum(metrics.DividendsPaid, periods); ok {
+			// Dividends are reported as cash outflows (negative)
+			result.DividendsPaid = math.Abs(paid)
+			result.Period = dividendPeriod
+		}
+		if result.DividendsPaid == 0 && !filter.IncludeNonPayers {
+			continue
+		}
+		result.DividendsPerShare = result.DividendsPaid / shareCount
+		result.Yield = result.DividendsPerShare / price * 100.0
+
+		if inRange(result.Yield, filter.MinYield, filter.MaxYield) {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Yield != results[j].Yield {
+			return results[i].Yield > results[j].Yield
+		}
+		return results[i].Symbol < results[j].Symbol
+	})
+	return results, nil
+}
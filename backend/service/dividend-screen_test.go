@@ -0,0 +1,142 @@
+package service
+
+import (
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestExtractLineItemAliasPrecedence(t *testing.T) {
+	s := &FundamentalDataService{}
+	tests := []struct {
+		name      string
+		item      string
+		statement string
+		want      map[string]float64
+	}{
+		{
+			"preferred label wins", LineItemEPS,
+			`{"0": {"Breakdown": "Basic EPS", "2024-09-30": "6.11"}, "1": {"Breakdown": "Diluted EPS", "2024-09-30": "6.08"}}`,
+			map[string]float64{"2024-09-30": 6.08},
+		},
+		{
+			"falls back to a later alias", LineItemDividendsPaid,
+			`{"0": {"Breakdown": "Payment of Dividends", "2024-09-30": "(15,234)"}}`,
+			map[string]float64{"2024-09-30": -15234},
+		},
+		{
+			"skips an alias with only placeholders", LineItemTotalEquity,
+			`{"0": {"Breakdown": "Stockholders Equity", "2024-09-30": "*"}, "1": {"Breakdown": "Common Stock Equity", "2024-09-30": "56,950"}}`,
+			map[string]float64{"2024-09-30": 56950},
+		},
+		{
+			"parent-company net income", LineItemNetIncome,
+			`{"0": {"Breakdown": "Net Income(Attributable to Parent Company Shareholders)", "2024-09-30": "93,736"}}`,
+			map[string]float64{"2024-09-30": 93736},
+		},
+		{
+			"no alias present", LineItemSharesOutstanding,
+			`{"0": {"Breakdown": "Total Revenue", "2024-09-30": "391,035"}}`,
+			map[string]float64{},
+		},
+		{
+			"labels match exactly", LineItemGrossProfit,
+			`{"0": {"Breakdown": "gross profit", "2024-09-30": "180,683"}}`,
+			map[string]float64{},
+		},
+	}
+	for _, tt := range tests {
+		parsed, err := s.parseStatement(tt.statement)
+		if err != nil {
+			t.Fatalf("%s: parseStatement returned error: %v", tt.name, err)
+		}
+		got := s.extractLineItem(parsed, tt.item)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: extractLineItem(%s) = %v, want %v", tt.name, tt.item, got, tt.want)
+			continue
+		}
+		for date, v := range tt.want {
+			if got[date] != v {
+				t.Errorf("%s: extractLineItem(%s)[%s] = %v, want %v", tt.name, tt.item, date, got[date], v)
+			}
+		}
+	}
+}
+
+func TestLineItemAliasesCoverEveryLineItem(t *testing.T) {
+	items := []string{
+		LineItemTotalRevenue, LineItemGrossProfit, LineItemOperatingIncome, LineItemNetIncome, LineItemEPS,
+		LineItemAverageShares, LineItemDividendsPaid, LineItemTotalEquity, LineItemSharesOutstanding,
+	}
+	seen := make(map[string]string)
+	for _, item := range items {
+		if len(lineItemAliases[item]) == 0 {
+			t.Errorf("line item %s has no aliases", item)
+		}
+		for _, alias := range lineItemAliases[item] {
+			if other, ok := seen[alias]; ok {
+				t.Errorf("alias %q belongs to both %s and %s", alias, other, item)
+			}
+			seen[alias] = item
+		}
+	}
+}
+
+func TestGetStocksByDividendYieldResolvesAliases(t *testing.T) {
+	db, mock := newMockDB(t)
+	statementColumns := []string{"symbol", "statement_type", "frequency", "statement"}
+
+	// Each filer labels dividends and share counts differently
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "fundamental_data" WHERE statement_type IN ($1) AND frequency IN ($2)`)).
+		WithArgs("cashflow", "annual").
+		WillReturnRows(sqlmock.NewRows(statementColumns).
+			AddRow("KO", "cashflow", "annual", `{"0": {"Breakdown": "Cash Dividends Paid", "2023-12-31": "(7,952)", "2024-12-31": "(8,359)"}}`).
+			AddRow("PEP", "cashflow", "annual", `{"0": {"Breakdown": "Common Stock Dividend Paid", "2024-12-31": "(7,229)"}}`).
+			AddRow("XOM", "cashflow", "annual", `{"0": {"Breakdown": "Payment of Dividends", "2024-12-31": "(16,704)"}}`).
+			AddRow("NVDA", "cashflow", "annual", `{"0": {"Breakdown": "Free Cash Flow", "2024-12-31": "60,853"}}`))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "fundamental_data" WHERE statement_type IN ($1) AND frequency IN ($2)`)).
+		WithArgs("income", "annual").
+		WillReturnRows(sqlmock.NewRows(statementColumns).
+			AddRow("KO", "income", "annual", `{"0": {"Breakdown": "Diluted Average Shares", "2024-12-31": "4,320"}}`).
+			AddRow("PEP", "income", "annual", `{"0": {"Breakdown": "Basic Average Shares", "2024-12-31": "1,373"}}`).
+			AddRow("XOM", "income", "annual", `{"0": {"Breakdown": "Basic Average Shares", "2024-12-31": "4,000"}, "1": {"Breakdown": "Diluted Average Shares", "2024-12-31": "4,400"}}`).
+			AddRow("NVDA", "income", "annual", `{"0": {"Breakdown": "Diluted Average Shares", "2024-12-31": "24,804"}}`))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "symbol","close" FROM "screener"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"symbol", "close"}).
+			AddRow("KO", 62.0).AddRow("PEP", 150.0).AddRow("XOM", 110.0).AddRow("NVDA", 130.0))
+
+	s := &FundamentalDataService{db: db, metricsSets: make(map[string]*metricsSet)}
+	results, err := s.GetStocksByDividendYield(DividendYieldFilter{Frequency: "annual"})
+	if err != nil {
+		t.Fatalf("GetStocksByDividendYield returned error: %v", err)
+	}
+
+	want := map[string]struct {
+		paid, shares float64
+	}{
+		"KO":  {8359, 4320},  // Latest year only
+		"PEP": {7229, 1373},  // Basic shares when diluted is missing
+		"XOM": {16704, 4400}, // Diluted shares preferred over basic
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results (%+v), want %d; NVDA pays no dividend", len(results), results, len(want))
+	}
+	for i, r := range results {
+		w, ok := want[r.Symbol]
+		if !ok {
+			t.Errorf("unexpected result %s", r.Symbol)
+			continue
+		}
+		if r.DividendsPaid != w.paid || r.AverageShares != w.shares {
+			t.Errorf("%s: dividends %v over %v shares, want %v over %v", r.Symbol, r.DividendsPaid, r.AverageShares, w.paid, w.shares)
+		}
+		if wantYield := w.paid / w.shares / r.Price * 100; math.Abs(r.Yield-wantYield) > 1e-9 {
+			t.Errorf("%s: yield = %v, want %v", r.Symbol, r.Yield, wantYield)
+		}
+		if i > 0 && results[i-1].Yield < r.Yield {
+			t.Errorf("results not sorted by yield, highest first: %v before %v", results[i-1].Yield, r.Yield)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FavoriteService contains business logic for per-user favorite symbols
+type FavoriteService struct {
+	db *gorm.DB
+}
+
+// NewFavoriteService creates a new instance of FavoriteService
+func NewFavoriteService() *FavoriteService {
+	return &FavoriteService{
+		db: database.GetDB(),
+	}
+}
+
+// FavoriteDetail is a favorited symbol joined with its current screener row and company info
+// Either may be nil if the symbol has no row in that table
+type FavoriteDetail struct {
+	Symbol      string             `json:"symbol"`
+	FavoritedAt time.Time          `json:"favorited_at"`
+	Screener    *model.Screener    `json:"screener,omitempty"`
+	CompanyInfo *model.CompanyInfo `json:"company_info,omitempty"`
+}
+
+// AddFavorite favorites a symbol for a user. Favoriting an already-favorited symbol is a no-op;
+// created reports whether a new favorite was stored. The symbol must exist in the screener table.
+func (s *FavoriteService) AddFavorite(userID uuid.UUID, symbol string) (favorite *model.Favorite, created bool, err error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if userID == uuid.Nil {
+		return nil, false, errors.New("user_id is required")
+	}
+	if symbol == "" {
+		return nil, false, errors.New("symbol is required")
+	}
+
+	var known int64
+	if err := s.db.Model(&model.Screener{}).Where("symbol = ?", symbol).Count(&known).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to look up symbol: %w", err)
+	}
+	if known == 0 {
+		return nil, false, errors.New("symbol not found")
+	}
+
+	favorite = &model.Favorite{UserID: userID, Symbol: symbol}
+	result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(favorite)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("failed to add favorite: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return favorite, true, nil
+	}
+
+	// Already favorited - return the existing row
+	var existing model.Favorite
+	if err := s.db.Where("user_id = ? AND symbol = ?", userID, symbol).First(&existing).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to fetch favorite: %w", err)
+	}
+	return &existing, false, nil
+}
+
+// RemoveFavorite removes a symbol from a user's favorites
+func (s *FavoriteService) RemoveFavorite(userID uuid.UUID, symbol string) error {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return errors.New("symbol is required")
+	}
+
+	result := s.db.Where("user_id = ? AND symbol = ?", userID, symbol).Delete(&model.Favorite{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove favorite: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("record not found")
+	}
+
+	return nil
+}
+
+// GetFavorites fetches a user's favorites, newest first, with current screener and company info
+func (s *FavoriteService) GetFavorites(userID uuid.UUID) ([]FavoriteDetail, error) {
+	var favorites []model.Favorite
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&favorites).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch favorites: %w", err)
+	}
+
+	details := make([]FavoriteDetail, 0, len(favorites))
+	if len(favorites) == 0 {
+		return details, nil
+	}
+
+	symbols := make([]string, 0, len(favorites))
+	for _, f := range favorites {
+		symbols = append(symbols, f.Symbol)
+	}
+
+	var screeners []model.Screener
+	if err := s.db.Where("symbol IN ?", symbols).Find(&screeners).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch screener data: %w", err)
+	}
+	screenerBySymbol := make(map[string]*model.Screener, len(screeners))
+	for i := range screeners {
+		screenerBySymbol[screeners[i].Symbol] = &screeners[i]
+	}
+
+	var companies []model.CompanyInfo
+	if err := s.db.Where("symbol IN ?", symbols).Find(&companies).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch company info: %w", err)
+	}
+	companyBySymbol := make(map[string]*model.CompanyInfo, len(companies))
+	for i := range companies {
+		companyBySymbol[companies[i].Symbol] = &companies[i]
+	}
+
+	for _, f := range favorites {
+		details = append(details, FavoriteDetail{
+			Symbol:      f.Symbol,
+			FavoritedAt: f.CreatedAt,
+			Screener:    screenerBySymbol[f.Symbol],
+			CompanyInfo: companyBySymbol[f.Symbol],
+		})
+	}
+	return details, nil
+}
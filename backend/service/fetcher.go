@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
@@ -13,9 +14,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"screener/backend/database"
+	"screener/backend/format"
 	"screener/backend/model"
 	"screener/backend/service/caching"
 
@@ -33,9 +36,26 @@ type FetcherService struct {
 	ttl         *caching.CacheTTLConfig
 }
 
-// getBaseURLs returns primary and fallback base URLs for failover
-// Both endpoints are hardcoded as defaults and can be overridden via environment variables
-func getBaseURLs() (string, string) {
+// getBaseURLs returns the ordered list of base URLs tried by fetchWithFailover
+// FINANCE_QUERY_URLS (comma-separated) sets the full list; otherwise the list is the primary and
+// fallback hosts, each hardcoded as a default and overridable via environment variables
+func getBaseURLs() []string {
+	if list := os.Getenv("FINANCE_QUERY_URLS"); list != "" {
+		urls := make([]string, 0)
+		seen := make(map[string]bool)
+		for _, u := range strings.Split(list, ",") {
+			u = strings.TrimRight(strings.TrimSpace(u), "/")
+			if u == "" || seen[u] {
+				continue
+			}
+			seen[u] = true
+			urls = append(urls, u)
+		}
+		if len(urls) > 0 {
+			return urls
+		}
+	}
+
 	// Hardcoded default endpoints
 	defaultPrimary := "https://finance-query.onrender.com"
 	defaultFallback := "https://finance-query-uzbi.onrender.com"
@@ -51,74 +71,115 @@ func getBaseURLs() (string, string) {
 		fallback = defaultFallback
 	}
 
-	return primary, fallback
+	if fallback == primary {
+		return []string{primary}
+	}
+	return []string{primary, fallback}
 }
 
-// fetchWithFailover attempts to fetch from primary URL, falls back to secondary URL on immediate failure
-// Returns the response body and which endpoint was used
-// Fails over immediately if: network error, timeout, or HTTP error status (4xx, 5xx)
-func (s *FetcherService) fetchWithFailover(ctx context.Context, primaryURL, fallbackURL string, jobID string, batchNum, totalBatches int) (*http.Response, string, error) {
-	// Try primary endpoint first
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, primaryURL, nil)
-	if err != nil {
-		return nil, "", err
+// upstreamOutcome describes how fetchUpstream obtained a response: which endpoint served it,
+// whether that took a failover, and how long the attempts took
+type upstreamOutcome struct {
+	URL      string        // Request URL that succeeded; empty for cache hits and when every endpoint failed
+	Cached   bool          // Served from the upstream response cache without a request
+	Failover bool          // Served by an endpoint other than the first in balancer order
+	Attempts int           // Endpoints tried
+	Latency  time.Duration // Total time spent across attempts
+}
+
+// source returns the URL used, or "cache"
+func (o upstreamOutcome) source() string {
+	if o.Cached {
+		return "cache"
 	}
+	return o.URL
+}
 
-	// Try primary endpoint (single attempt)
-	resp, err := s.httpClient.Do(req)
-	primarySuccess := err == nil && resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300
+// ErrUpstreamRejected is returned when an upstream answers a request with a client error (4xx
+// other than 429); every host serves the same API, so the request is not retried elsewhere
+var ErrUpstreamRejected = errors.New("upstream rejected the request")
 
-	if primarySuccess {
-		if jobID != "" {
-			fmt.Printf("[%s] Batch %d/%d: Successfully fetched from primary endpoint\n", jobID, batchNum, totalBatches)
-		}
-		return resp, primaryURL, nil
-	}
+// upstreamHostFailed reports whether an HTTP status says the host, rather than the request, failed
+func upstreamHostFailed(status int) bool {
+	return status >= 500 || status == http.StatusTooManyRequests
+}
 
-	// If primary failed, capture error message and close response
-	var primaryErrorMsg string
-	var primaryStatusCode int
-	if err != nil {
-		primaryErrorMsg = err.Error()
-	} else if resp != nil {
-		primaryStatusCode = resp.StatusCode
-		primaryErrorMsg = fmt.Sprintf("HTTP status %d", resp.StatusCode)
-		resp.Body.Close()
-	} else {
-		primaryErrorMsg = "unknown error"
+// fetchWithFailover tries the request URLs in balancer order until one succeeds
+// Returns the response and an upstreamOutcome recording the endpoint used, failover and latency
+// Fails over immediately if: network error, timeout, HTTP 5xx or 429; only these count towards
+// the host's circuit breaker. Any other 4xx is the request's fault: the host is recorded as
+// healthy and ErrUpstreamRejected is returned without trying the other hosts.
+// Hosts with an open circuit are tried last; see upstreamBalancer
+func (s *FetcherService) fetchWithFailover(ctx context.Context, requestURLs []string, jobID string, batchNum, totalBatches int) (*http.Response, upstreamOutcome, error) {
+	var outcome upstreamOutcome
+	if len(requestURLs) == 0 {
+		return nil, outcome, errors.New("no upstream endpoints configured")
 	}
 
-	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Primary endpoint failed (%s), trying fallback: %s\n", jobID, batchNum, totalBatches, primaryErrorMsg, fallbackURL)
+	lb := getUpstreamBalancer()
+	ordered := lb.order(requestURLs)
+	startTime := time.Now()
+	finished := func() upstreamOutcome {
+		outcome.Latency = time.Since(startTime)
+		return outcome
 	}
 
-	// Try fallback endpoint
-	req, err = http.NewRequestWithContext(ctx, http.MethodGet, fallbackURL, nil)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create fallback request: %w", err)
-	}
+	failures := make([]string, 0, len(ordered))
+	for i, requestURL := range ordered {
+		if err := ctx.Err(); err != nil {
+			return nil, finished(), err
+		}
 
-	resp, err = s.httpClient.Do(req)
-	if err != nil {
-		return nil, "", fmt.Errorf("both endpoints failed. Primary: %s, Fallback: %w", primaryErrorMsg, err)
-	}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		if err != nil {
+			return nil, finished(), fmt.Errorf("failed to create request: %w", err)
+		}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		fallbackStatusCode := resp.StatusCode
-		resp.Body.Close()
-		return nil, "", fmt.Errorf("both endpoints failed. Primary: %s (status %d), Fallback: HTTP status %d", primaryErrorMsg, primaryStatusCode, fallbackStatusCode)
-	}
+		// Single attempt per endpoint
+		outcome.Attempts++
+		resp, err := s.httpClient.Do(req)
+		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			lb.recordSuccess(requestURL)
+			if jobID != "" {
+				fmt.Printf("[%s] Batch %d/%d: Successfully fetched from %s (attempt %d/%d)\n", jobID, batchNum, totalBatches, upstreamHost(requestURL), i+1, len(ordered))
+			} else if i > 0 {
+				log.Printf("Upstream request served by %s after failover (attempt %d/%d)", upstreamHost(requestURL), i+1, len(ordered))
+			}
+			outcome.URL = requestURL
+			outcome.Failover = i > 0
+			return resp, finished(), nil
+		}
 
-	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Successfully fetched from fallback endpoint\n", jobID, batchNum, totalBatches)
+		// Capture the failure and close the response
+		var errorMsg string
+		if err != nil {
+			errorMsg = err.Error()
+		} else {
+			errorMsg = fmt.Sprintf("HTTP status %d", resp.StatusCode)
+			resp.Body.Close()
+			if !upstreamHostFailed(resp.StatusCode) {
+				lb.recordSuccess(requestURL)
+				return nil, finished(), fmt.Errorf("%w: %s: %s", ErrUpstreamRejected, upstreamHost(requestURL), errorMsg)
+			}
+		}
+		// A cancelled caller says nothing about the host's health
+		if ctx.Err() == nil {
+			lb.recordFailure(requestURL)
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", upstreamHost(requestURL), errorMsg))
+
+		if jobID != "" && i+1 < len(ordered) {
+			fmt.Printf("[%s] Batch %d/%d: %s failed (%s), trying: %s\n", jobID, batchNum, totalBatches, upstreamHost(requestURL), errorMsg, ordered[i+1])
+		}
 	}
-	return resp, fallbackURL, nil
+
+	return nil, finished(), fmt.Errorf("all %d endpoints failed. %s", len(ordered), strings.Join(failures, "; "))
 }
 
 // NewFetcherService constructs a FetcherService with sensible defaults.
 func NewFetcherService() *FetcherService {
-	// Get primary URL (fallback is handled in getBaseURLs)
-	primaryBase, _ := getBaseURLs()
+	// Get primary URL (failover is handled in fetchWithFailover)
+	primaryBase := getBaseURLs()[0]
 
 	timeoutStr := os.Getenv("FETCHER_HTTP_TIMEOUT_SECONDS")
 	timeout := 15 * time.Second
@@ -130,7 +191,7 @@ func NewFetcherService() *FetcherService {
 
 	return &FetcherService{
 		db:          database.GetDB(),
-		httpClient:  &http.Client{Timeout: timeout},
+		httpClient:  &http.Client{Timeout: timeout, Transport: newFetcherTransport()},
 		baseURL:     primaryBase,
 		histService: NewHistoricalService(),
 		cache:       caching.NewCacheService(),
@@ -138,6 +199,86 @@ func NewFetcherService() *FetcherService {
 	}
 }
 
+// fetcherTransport is shared by every FetcherService so keep-alive connections to the
+// finance-query hosts are pooled across jobs instead of per service instance
+var (
+	fetcherTransport     *http.Transport
+	fetcherTransportOnce sync.Once
+)
+
+// newFetcherTransport returns the shared transport tuned for many requests to the getBaseURLs hosts
+// Pool sizes are configurable via FETCHER_MAX_IDLE_CONNS_PER_HOST (default 32),
+// FETCHER_MAX_CONNS_PER_HOST (default 64, 0 = unlimited) and FETCHER_IDLE_CONN_TIMEOUT_SECONDS (default 90)
+func newFetcherTransport() *http.Transport {
+	fetcherTransportOnce.Do(func() {
+		maxIdlePerHost := intFromEnv("FETCHER_MAX_IDLE_CONNS_PER_HOST", 32)
+		maxPerHost := intFromEnv("FETCHER_MAX_CONNS_PER_HOST", 64)
+		idleTimeout := time.Duration(intFromEnv("FETCHER_IDLE_CONN_TIMEOUT_SECONDS", 90)) * time.Second
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConns = maxIdlePerHost * len(getBaseURLs()) // Room for a full idle pool per endpoint
+		transport.MaxIdleConnsPerHost = maxIdlePerHost
+		transport.MaxConnsPerHost = maxPerHost
+		transport.IdleConnTimeout = idleTimeout
+		fetcherTransport = transport
+	})
+	return fetcherTransport
+}
+
+// intFromEnv reads a non-negative integer from an env var, falling back to the default
+func intFromEnv(name string, defaultValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < 0 {
+		log.Printf("Warning: invalid %s %q, using %d", name, value, defaultValue)
+		return defaultValue
+	}
+	return v
+}
+
+// defaultMaxResponseBytes caps upstream response bodies (a 10y/1d history is well under 1MB)
+const defaultMaxResponseBytes = 16 << 20
+
+// ErrUpstreamResponseTooLarge is returned when an upstream body exceeds FETCHER_MAX_RESPONSE_BYTES
+var ErrUpstreamResponseTooLarge = errors.New("upstream response too large")
+
+// ErrEmptyUpstreamPayload is returned when an upstream body decodes to an empty or null payload
+var ErrEmptyUpstreamPayload = errors.New("upstream returned an empty payload")
+
+// getMaxResponseBytes returns the upstream body size cap (FETCHER_MAX_RESPONSE_BYTES, default 16MB)
+func getMaxResponseBytes() int64 {
+	limit := intFromEnv("FETCHER_MAX_RESPONSE_BYTES", defaultMaxResponseBytes)
+	if limit == 0 {
+		return defaultMaxResponseBytes
+	}
+	return int64(limit)
+}
+
+// readUpstreamBody reads at most the configured number of bytes from an upstream body
+// Oversized bodies fail with ErrUpstreamResponseTooLarge instead of being buffered in full
+func readUpstreamBody(body io.Reader) ([]byte, error) {
+	maxBytes := getMaxResponseBytes()
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upstream response: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrUpstreamResponseTooLarge, maxBytes)
+	}
+	return data, nil
+}
+
+// decodeUpstreamJSON decodes an upstream body read by readUpstreamBody into dest
+func decodeUpstreamJSON(data []byte, dest interface{}) error {
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("failed to decode upstream response: %w", err)
+	}
+	return nil
+}
+
 // getAllSymbols retrieves all unique symbols from Redis cache (loaded on startup)
 // Falls back to database if Redis is unavailable
 func (s *FetcherService) getAllSymbols() ([]string, error) {
@@ -146,7 +287,10 @@ func (s *FetcherService) getAllSymbols() ([]string, error) {
 }
 
 // RunIngestion fetches and stores data for all symbols concurrently. Suitable for cron trigger.
-func (s *FetcherService) RunIngestion(ctx context.Context, concurrency int) (string, error) {
+func (s *FetcherService) RunIngestion(ctx context.Context, concurrency int) (jobID string, err error) {
+	ctx, run := s.startIngestionRun(ctx, IngestionTypeHistoricals)
+	defer func() { run.finish(jobID, err) }()
+
 	if concurrency <= 0 {
 		concurrency = 8
 	}
@@ -156,6 +300,7 @@ func (s *FetcherService) RunIngestion(ctx context.Context, concurrency int) (str
 	if err != nil {
 		return "", err
 	}
+	run.attempted(len(symbols))
 	if len(symbols) == 0 {
 		return fmt.Sprintf("job-%d", time.Now().UnixNano()), nil
 	}
@@ -169,19 +314,28 @@ func (s *FetcherService) RunIngestion(ctx context.Context, concurrency int) (str
 		go func() {
 			defer wg.Done()
 			for symbol := range jobs {
-				_ = s.processSymbol(ctx, symbol)
+				if _, err := s.processSymbol(ctx, symbol); err != nil {
+					run.failure([]string{symbol}, err)
+				} else {
+					run.success(1)
+				}
 			}
 		}()
 	}
 
-	for _, sym := range symbols {
-		select {
-		case <-ctx.Done():
-			close(jobs)
-			wg.Wait()
-			return "", ctx.Err()
-		case jobs <- sym:
+	for i, sym := range symbols {
+		// select picks at random when a worker is also ready, so check for cancellation first
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case jobs <- sym:
+				continue
+			}
 		}
+		close(jobs)
+		wg.Wait()
+		run.failure(symbols[i:], ctx.Err())
+		return "", ctx.Err()
 	}
 	close(jobs)
 	wg.Wait()
@@ -189,13 +343,50 @@ func (s *FetcherService) RunIngestion(ctx context.Context, concurrency int) (str
 	return fmt.Sprintf("job-%d", time.Now().UnixNano()), nil
 }
 
+// SymbolIngestionCounts reports how many bars were fetched per range/interval for a symbol
+type SymbolIngestionCounts struct {
+	Symbol      string `json:"symbol"`
+	Daily10y    int    `json:"10y_1d"`
+	Intraday1m  int    `json:"1d_1m"`
+	Intraday30m int    `json:"1d_30m"`
+}
+
+// refreshingSymbols tracks symbols with a single-symbol refresh in progress
+var refreshingSymbols sync.Map
+
+// RefreshSymbolHistoricals runs the ingestion pipeline for a single symbol and returns
+// the number of bars fetched per range/interval. Concurrent refreshes of the same symbol
+// are rejected with "refresh already in progress".
+func (s *FetcherService) RefreshSymbolHistoricals(ctx context.Context, symbol string) (*SymbolIngestionCounts, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return nil, errors.New("symbol is required")
+	}
+
+	if _, loaded := refreshingSymbols.LoadOrStore(symbol, struct{}{}); loaded {
+		return nil, errors.New("refresh already in progress")
+	}
+	defer refreshingSymbols.Delete(symbol)
+
+	// An explicit refresh should always see fresh upstream data
+	return s.processSymbol(WithoutUpstreamCache(ctx), symbol)
+}
+
 // processSymbol fetches 1d/1m, aggregates to daily and updates Screener, then fetches 1d/30m into Historical.
-// Data is saved to Redis ONLY (no immediate database writes)
-func (s *FetcherService) processSymbol(ctx context.Context, symbol string) error {
-	dataCache := caching.NewDataCache()
+// Data is saved to Redis ONLY (no immediate database writes). Calls for the same symbol run one at a time.
+func (s *FetcherService) processSymbol(ctx context.Context, symbol string) (*SymbolIngestionCounts, error) {
+	counts := &SymbolIngestionCounts{Symbol: symbol}
+
+	// Only one processSymbol per symbol at a time (bulk runs and single-symbol refreshes overlap)
+	unlock, err := symbolLocks.lock(ctx, symbol)
+	if err != nil {
+		return counts, err
+	}
+	defer unlock()
 	
 	// 0) Daily backfill for last 10 years (1d interval)
 	bars10y, err := s.fetchBars(ctx, symbol, "10y", "1d")
+	counts.Daily10y = len(bars10y)
 	if err == nil && len(bars10y) > 0 {
 		batch10y := make([]model.Historical, 0, len(bars10y))
 		for _, b := range bars10y {
@@ -212,13 +403,18 @@ func (s *FetcherService) processSymbol(ctx context.Context, symbol string) error
 				Volume:   b.Volume,
 			})
 		}
-		// Save to Redis ONLY
-		_ = dataCache.CacheHistorical(symbol, "10y", "1d", batch10y)
+		// Save to Redis ONLY (written straight to the database while Redis is down)
+		_ = s.histService.UpsertHistoricalBatch(batch10y)
 	}
 	
 	// 1) Screener update from 1d/1m aggregated to daily
 	bars1m, err := s.fetchBars(ctx, symbol, "1d", "1m")
+	counts.Intraday1m = len(bars1m)
 	if err == nil && len(bars1m) > 0 {
+		// range=1d/1m includes pre-market and after-hours bars unless filtered to the regular session
+		if regularHoursOnly(ctx) {
+			bars1m = regularSessionBars(bars1m)
+		}
 		daily := aggregateDailyFromIntraday(bars1m)
 		if daily != nil {
 			// Note: Screener updates still go to database immediately (price updates are critical)
@@ -231,13 +427,18 @@ func (s *FetcherService) processSymbol(ctx context.Context, symbol string) error
 				"volume": daily.Volume,
 			}
 			_ = s.db.Model(&model.Screener{}).Where("symbol = ?", symbol).Updates(updates).Error
+
+			if isCloseReconciliationEnabled() {
+				s.reconcileDailyClose(ctx, symbol, daily)
+			}
 		}
 	}
 
 	// 2) Historical: store 1d/30m into Redis ONLY (no database write)
 	bars30m, err := s.fetchBars(ctx, symbol, "1d", "30m")
+	counts.Intraday30m = len(bars30m)
 	if err != nil || len(bars30m) == 0 {
-		return err
+		return counts, err
 	}
 
 	// Prepare batch
@@ -257,8 +458,9 @@ func (s *FetcherService) processSymbol(ctx context.Context, symbol string) error
 		})
 	}
 	
-	// Save to Redis ONLY (background worker will persist to database)
-	return dataCache.CacheHistorical(symbol, "1d", "30m", batch)
+	// Save to Redis ONLY (background worker will persist to database; written straight to the
+	// database while Redis is down)
+	return counts, s.histService.UpsertHistoricalBatch(batch)
 }
 
 // fetchAndUpsertDaily10y is now handled in processSymbol
@@ -285,17 +487,14 @@ func (s *FetcherService) fetchBars(ctx context.Context, symbol, rangeParam, inte
 		return nil, errors.New("symbol is required")
 	}
 
-	// Get primary and fallback URLs
-	primaryBase, fallbackBase := getBaseURLs()
-	primaryURL := fmt.Sprintf("%s/v1/historical?symbol=%s&range=%s&interval=%s&epoch=true", primaryBase, symbol, rangeParam, interval)
-	fallbackURL := fmt.Sprintf("%s/v1/historical?symbol=%s&range=%s&interval=%s&epoch=true", fallbackBase, symbol, rangeParam, interval)
-
-	// Try with failover
-	resp, _, err := s.fetchWithFailover(ctx, primaryURL, fallbackURL, "", 0, 0)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	// Get request URLs for every configured endpoint
+	api := getUpstreamAPIConfig()
+	requestURLs := upstreamURLs(api.path(api.Historical), url.Values{
+		"symbol":   {symbol},
+		"range":    {rangeParam},
+		"interval": {interval},
+		"epoch":    {"true"},
+	})
 
 	// The response is a JSON object keyed by epoch strings
 	var raw map[string]struct {
@@ -306,7 +505,18 @@ func (s *FetcherService) fetchBars(ctx context.Context, symbol, rangeParam, inte
 		AdjClose *float64 `json:"adjClose"`
 		Volume   int64    `json:"volume"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+
+	// Try with failover
+	if _, err := s.fetchUpstream(ctx, requestURLs, "", 0, 0, func(body []byte) error {
+		raw = nil
+		if err := decodeUpstreamJSON(body, &raw); err != nil {
+			return err
+		}
+		if len(raw) == 0 {
+			return ErrEmptyUpstreamPayload
+		}
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
@@ -326,6 +536,9 @@ func (s *FetcherService) fetchBars(ctx context.Context, symbol, rangeParam, inte
 			Volume:   v.Volume,
 		})
 	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("invalid response: no bars with epoch keys for %s", symbol)
+	}
 	// sort ascending by epoch for consistent aggregation
 	sort.Slice(out, func(i, j int) bool { return out[i].Epoch < out[j].Epoch })
 	return out, nil
@@ -376,7 +589,13 @@ type simpleQuote struct {
 
 // RunWatchlistPriceUpdate fetches price data for all unique stocks in watchlists and updates them.
 // It avoids duplicate fetches by processing unique symbols only.
-func (s *FetcherService) RunWatchlistPriceUpdate(ctx context.Context) (string, error) {
+func (s *FetcherService) RunWatchlistPriceUpdate(ctx context.Context) (jobID string, err error) {
+	ctx, run := s.startIngestionRun(ctx, IngestionTypeWatchlistPrices)
+	defer func() { run.finish(jobID, err) }()
+
+	// Live prices are never served from the upstream response cache
+	ctx = WithoutUpstreamCache(ctx)
+
 	// Get all unique symbols from watchlist_items (where symbol is not empty)
 	var symbols []string
 	if err := s.db.Model(&model.WatchlistItem{}).
@@ -389,10 +608,12 @@ func (s *FetcherService) RunWatchlistPriceUpdate(ctx context.Context) (string, e
 	if len(symbols) == 0 {
 		return fmt.Sprintf("watchlist-price-update-%d", time.Now().UnixNano()), nil
 	}
+	run.attempted(len(symbols))
 
 	// Fetch quotes for all symbols in batches (API may have limits, so we'll do in chunks of 50)
 	batchSize := 50
 	totalUpdated := 0
+	jobID = fmt.Sprintf("watchlist-price-update-%d", time.Now().UnixNano())
 
 	for i := 0; i < len(symbols); i += batchSize {
 		end := i + batchSize
@@ -401,9 +622,20 @@ func (s *FetcherService) RunWatchlistPriceUpdate(ctx context.Context) (string, e
 		}
 		batch := symbols[i:end]
 
+		// Stop before the next batch once the deadline passes; batches already written are kept
+		if err := ctx.Err(); err != nil {
+			run.failure(symbols[i:], err)
+			return jobID, err
+		}
+
 		quotes, err := s.fetchSimpleQuotes(ctx, batch)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				run.failure(symbols[i:], ctxErr)
+				return jobID, ctxErr
+			}
 			// Log error but continue with next batch
+			run.failure(batch, err)
 			continue
 		}
 
@@ -411,12 +643,14 @@ func (s *FetcherService) RunWatchlistPriceUpdate(ctx context.Context) (string, e
 		updated, err := s.updateWatchlistItemsFromQuotes(quotes)
 		if err != nil {
 			// Log error but continue
+			run.failure(batch, err)
 			continue
 		}
+		run.success(len(quotes))
 		totalUpdated += updated
 	}
 
-	return fmt.Sprintf("watchlist-price-update-%d", time.Now().UnixNano()), nil
+	return jobID, nil
 }
 
 // fetchSimpleQuotes calls the simple-quotes API for a batch of symbols
@@ -432,20 +666,31 @@ func (s *FetcherService) fetchSimpleQuotesWithLogging(ctx context.Context, symbo
 
 	// Build URL with comma-separated symbols (URL encoded)
 	symbolsParam := strings.Join(symbols, ", ")
-	encodedSymbols := url.QueryEscape(symbolsParam)
 
-	// Get primary and fallback URLs
-	primaryBase, fallbackBase := getBaseURLs()
-	primaryURL := fmt.Sprintf("%s/v1/simple-quotes?symbols=%s", primaryBase, encodedSymbols)
-	fallbackURL := fmt.Sprintf("%s/v1/simple-quotes?symbols=%s", fallbackBase, encodedSymbols)
+	// Get request URLs for every configured endpoint
+	api := getUpstreamAPIConfig()
+	requestURLs := upstreamURLs(api.path(api.SimpleQuotes), url.Values{"symbols": {symbolsParam}})
 
 	// Only log detailed info if jobID is provided (for market aggregation)
 	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Calling API: %s\n", jobID, batchNum, totalBatches, primaryURL)
+		fmt.Printf("[%s] Batch %d/%d: Calling API: %s\n", jobID, batchNum, totalBatches, requestURLs[0])
 	}
 
+	// Decoded (and checked) before the response is cached
+	var quotes []simpleQuote
 	startTime := time.Now()
-	resp, usedURL, err := s.fetchWithFailover(ctx, primaryURL, fallbackURL, jobID, batchNum, totalBatches)
+	outcome, err := s.fetchUpstream(ctx, requestURLs, jobID, batchNum, totalBatches, func(body []byte) error {
+		if err := decodeUpstreamJSON(body, &quotes); err != nil {
+			if jobID != "" {
+				fmt.Printf("[%s] Batch %d/%d: ERROR decoding JSON response: %v\n", jobID, batchNum, totalBatches, err)
+			}
+			return err
+		}
+		if len(quotes) == 0 {
+			return ErrEmptyUpstreamPayload
+		}
+		return nil
+	})
 	requestDuration := time.Since(startTime)
 
 	if err != nil {
@@ -454,20 +699,9 @@ func (s *FetcherService) fetchSimpleQuotesWithLogging(ctx context.Context, symbo
 		}
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Successfully fetched from %s in %v\n", jobID, batchNum, totalBatches, usedURL, requestDuration)
-	}
-
-	// Read response body
-	var quotes []simpleQuote
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&quotes); err != nil {
-		if jobID != "" {
-			fmt.Printf("[%s] Batch %d/%d: ERROR decoding JSON response: %v\n", jobID, batchNum, totalBatches, err)
-		}
-		return nil, err
+		fmt.Printf("[%s] Batch %d/%d: Successfully fetched from %s in %v\n", jobID, batchNum, totalBatches, outcome.source(), requestDuration)
 	}
 
 	if jobID != "" {
@@ -504,26 +738,9 @@ func (s *FetcherService) updateWatchlistItemsFromQuotes(quotes []simpleQuote) (i
 		}
 
 		// Parse price strings to float64
-		var price *float64
-		if quote.Price != "" {
-			if p, err := strconv.ParseFloat(quote.Price, 64); err == nil {
-				price = &p
-			}
-		}
-
-		var afterHoursPrice *float64
-		if quote.AfterHoursPrice != "" {
-			if p, err := strconv.ParseFloat(quote.AfterHoursPrice, 64); err == nil {
-				afterHoursPrice = &p
-			}
-		}
-
-		var change *float64
-		if quote.Change != "" {
-			if c, err := strconv.ParseFloat(quote.Change, 64); err == nil {
-				change = &c
-			}
-		}
+		price := parseQuoteNumber(quote.Price)
+		afterHoursPrice := parseQuoteNumber(quote.AfterHoursPrice)
+		change := parseQuoteNumber(quote.Change)
 
 		// Update all watchlist items with this symbol (or name if symbol not set)
 		updates := map[string]interface{}{
@@ -531,7 +748,7 @@ func (s *FetcherService) updateWatchlistItemsFromQuotes(quotes []simpleQuote) (i
 			"price":             price,
 			"after_hours_price": afterHoursPrice,
 			"change":            change,
-			"percent_change":    quote.PercentChange,
+			"percent_change":    format.NormalizePercent(quote.PercentChange),
 			"logo":              quote.Logo,
 		}
 
@@ -592,7 +809,10 @@ type detailedQuote struct {
 
 // RunCompanyInfoIngestion fetches company info for all symbols from screener table and upserts them.
 // It avoids duplicate data by using ON CONFLICT (upsert) based on symbol primary key.
-func (s *FetcherService) RunCompanyInfoIngestion(ctx context.Context) (string, error) {
+func (s *FetcherService) RunCompanyInfoIngestion(ctx context.Context) (jobID string, err error) {
+	ctx, run := s.startIngestionRun(ctx, IngestionTypeCompanyInfo)
+	defer func() { run.finish(jobID, err) }()
+
 	// Get all unique symbols from screener table (with caching)
 	symbols, err := s.getAllSymbols()
 	if err != nil {
@@ -602,10 +822,13 @@ func (s *FetcherService) RunCompanyInfoIngestion(ctx context.Context) (string, e
 	if len(symbols) == 0 {
 		return fmt.Sprintf("company-info-ingestion-%d", time.Now().UnixNano()), nil
 	}
+	run.attempted(len(symbols))
 
 	// Fetch company info for all symbols in batches (API may have limits, so we'll do in chunks of 50)
 	batchSize := 50
 	totalUpserted := 0
+	jobID = fmt.Sprintf("company-info-ingestion-%d", time.Now().UnixNano())
+	snapshots := metricsSnapshotsEnabled(ctx)
 
 	for i := 0; i < len(symbols); i += batchSize {
 		end := i + batchSize
@@ -614,80 +837,129 @@ func (s *FetcherService) RunCompanyInfoIngestion(ctx context.Context) (string, e
 		}
 		batch := symbols[i:end]
 
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			return "", ctx.Err()
-		default:
+		// Stop before the next batch once the deadline passes; batches already cached are kept
+		if err := ctx.Err(); err != nil {
+			run.failure(symbols[i:], err)
+			return jobID, err
 		}
 
 		quotes, err := s.fetchDetailedQuotes(ctx, batch, "", 0, 0)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				run.failure(symbols[i:], ctxErr)
+				return jobID, ctxErr
+			}
 			// Log error but continue with next batch
+			run.failure(batch, err)
 			continue
 		}
 
 		// Cache company info in Redis ONLY (no immediate database write)
 		dataCache := caching.NewDataCache()
+		returned := make(map[string]bool, len(quotes))
+		batchInfo := make([]model.CompanyInfo, 0, len(quotes))
+		uncached := make([]detailedQuote, 0)
 		for _, quote := range quotes {
 			if quote.Symbol == "" {
 			continue
 		}
+			returned[strings.ToUpper(quote.Symbol)] = true
 			
-			companyInfo := model.CompanyInfo{
-				Symbol:           quote.Symbol,
-				Name:             quote.Name,
-				Price:            quote.Price,
-				AfterHoursPrice:  quote.AfterHoursPrice,
-				Change:           quote.Change,
-				PercentChange:    quote.PercentChange,
-				Open:             quote.Open,
-				High:             quote.High,
-				Low:              quote.Low,
-				YearHigh:         quote.YearHigh,
-				YearLow:          quote.YearLow,
-				Volume:           quote.Volume,
-				AvgVolume:        quote.AvgVolume,
-				MarketCap:        quote.MarketCap,
-				Beta:             quote.Beta,
-				PE:               quote.PE,
-				EarningsDate:     quote.EarningsDate,
-				Sector:           quote.Sector,
-				Industry:         quote.Industry,
-				About:            quote.About,
-				Employees:        quote.Employees,
-				FiveDaysReturn:   quote.FiveDaysReturn,
-				OneMonthReturn:   quote.OneMonthReturn,
-				ThreeMonthReturn: quote.ThreeMonthReturn,
-				SixMonthReturn:   quote.SixMonthReturn,
-				YtdReturn:        quote.YtdReturn,
-				YearReturn:       quote.YearReturn,
-				ThreeYearReturn:  quote.ThreeYearReturn,
-				FiveYearReturn:   quote.FiveYearReturn,
-				TenYearReturn:    quote.TenYearReturn,
-				MaxReturn:        quote.MaxReturn,
-				Logo:             quote.Logo,
-			}
+			companyInfo := companyInfoFromQuote(quote)
+			batchInfo = append(batchInfo, companyInfo)
 			
 			// Save to Redis ONLY
 			if err := dataCache.CacheCompanyInfo(quote.Symbol, &companyInfo); err != nil {
-				log.Printf("Warning: Failed to cache company info for %s: %v", quote.Symbol, err)
+				log.Printf("Warning: Failed to cache company info for %s, writing it to the database: %v", quote.Symbol, err)
+				uncached = append(uncached, quote)
 			} else {
 				totalUpserted++
+				run.success(1)
+			}
+		}
+
+		// Quotes that couldn't be queued for the persistence worker are upserted directly
+		if len(uncached) > 0 {
+			if upserted, err := s.upsertCompanyInfoFromQuotes(uncached); err != nil {
+				log.Printf("Warning: Failed to upsert company info for %d symbols: %v", len(uncached), err)
+				failed := make([]string, 0, len(uncached))
+				for _, quote := range uncached {
+					failed = append(failed, quote.Symbol)
+				}
+				run.failure(failed, err)
+			} else {
+				totalUpserted += upserted
+				run.success(upserted)
+			}
+		}
+
+		// Record dated metrics for trend analysis when the run opted in
+		if snapshots {
+			if err := s.saveCompanyMetricsSnapshots(batchInfo); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
+
+		missing := make([]string, 0)
+		for _, symbol := range batch {
+			if !returned[strings.ToUpper(symbol)] {
+				missing = append(missing, symbol)
 			}
 		}
+		if len(missing) > 0 {
+			run.failure(missing, errors.New("no quote returned"))
+		}
 	}
 
-	return fmt.Sprintf("company-info-ingestion-%d", time.Now().UnixNano()), nil
+	return jobID, nil
+}
+
+// getMarketAggregationConcurrency returns the number of quote batches fetched in parallel
+// during market aggregation (MARKET_AGGREGATION_CONCURRENCY, default: 4)
+func getMarketAggregationConcurrency() int {
+	if v, err := strconv.Atoi(os.Getenv("MARKET_AGGREGATION_CONCURRENCY")); err == nil && v > 0 {
+		return v
+	}
+	return 4
+}
+
+// Market aggregation quote sources
+const (
+	// MarketAggregationModeSimple aggregates simple quotes (price and percent change only)
+	MarketAggregationModeSimple = "simple"
+	// MarketAggregationModeDetailed aggregates detailed quotes and, in the same pass, upserts them into
+	// company_info so sector/market-cap breadth and year highs/lows are as fresh as the counts.
+	// Detailed payloads are several times larger per symbol and the upstream serves them more slowly,
+	// so prefer simple mode for the every-5-minutes cron.
+	MarketAggregationModeDetailed = "detailed"
+)
+
+// ValidMarketAggregationMode reports whether mode is a supported market aggregation mode
+func ValidMarketAggregationMode(mode string) bool {
+	return mode == MarketAggregationModeSimple || mode == MarketAggregationModeDetailed
 }
 
 // RunMarketAggregation fetches quotes for all stocks from screener table and aggregates them
 // for market statistics (up/down/unchanged counts). Suitable for cron trigger every 5 minutes.
-func (s *FetcherService) RunMarketAggregation(ctx context.Context) (string, error) {
-	jobID := fmt.Sprintf("market-aggregation-%d", time.Now().UnixNano())
+// mode selects simple or detailed quotes (see MarketAggregationModeDetailed); empty means simple.
+func (s *FetcherService) RunMarketAggregation(ctx context.Context, mode string) (jobID string, err error) {
+	if mode == "" {
+		mode = MarketAggregationModeSimple
+	}
+	if !ValidMarketAggregationMode(mode) {
+		return "", fmt.Errorf("invalid market aggregation mode %q: must be simple or detailed", mode)
+	}
+
+	ctx, run := s.startIngestionRun(ctx, IngestionTypeMarketAggregation)
+	defer func() { run.finish(jobID, err) }()
+
+	// Live prices are never served from the upstream response cache
+	ctx = WithoutUpstreamCache(ctx)
+
+	jobID = fmt.Sprintf("market-aggregation-%d", time.Now().UnixNano())
 	startTime := time.Now()
 
-	fmt.Printf("[%s] Starting market aggregation...\n", jobID)
+	fmt.Printf("[%s] Starting market aggregation (mode: %s)...\n", jobID, mode)
 
 	// Get all unique symbols from screener table (with caching)
 	symbols, err := s.getAllSymbols()
@@ -697,6 +969,7 @@ func (s *FetcherService) RunMarketAggregation(ctx context.Context) (string, erro
 	}
 
 	totalSymbols := len(symbols)
+	run.attempted(totalSymbols)
 	fmt.Printf("[%s] Loaded %d symbols from screener table\n", jobID, totalSymbols)
 
 	if totalSymbols == 0 {
@@ -710,11 +983,64 @@ func (s *FetcherService) RunMarketAggregation(ctx context.Context) (string, erro
 	// Fetch quotes for all symbols in batches (API may have limits, so we'll do in chunks of 50)
 	batchSize := 50
 	totalBatches := (totalSymbols + batchSize - 1) / batchSize
-	successfulBatches := 0
-	failedBatches := 0
-	totalQuotesProcessed := 0
+	concurrency := getMarketAggregationConcurrency()
+	var successfulBatches, failedBatches, totalQuotesProcessed atomic.Int64
+
+	fmt.Printf("[%s] Processing %d batches of %d symbols each (concurrency: %d)\n", jobID, totalBatches, batchSize, concurrency)
+
+	// Worker pool: batches are fetched in parallel, AggregateQuotes is mutex-guarded
+	type aggregationBatch struct {
+		num     int
+		symbols []string
+	}
+	jobs := make(chan aggregationBatch)
+	wg := sync.WaitGroup{}
 
-	fmt.Printf("[%s] Processing %d batches of %d symbols each\n", jobID, totalBatches, batchSize)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				batchNum, batch := job.num, job.symbols
+				fmt.Printf("[%s] Processing batch %d/%d (%d symbols): %v\n", jobID, batchNum, totalBatches, len(batch), batch)
+
+				quotes, quoteCount, err := s.fetchAggregationQuotes(ctx, mode, batch, jobID, batchNum, totalBatches)
+				if err != nil {
+					failedBatches.Add(1)
+					run.failure(batch, err)
+					fmt.Printf("[%s] ERROR: Failed to fetch quotes for batch %d/%d: %v\n", jobID, batchNum, totalBatches, err)
+					continue
+				}
+
+				if quoteCount == 0 {
+					fmt.Printf("[%s] WARNING: Batch %d/%d returned 0 quotes (all symbols may be invalid)\n", jobID, batchNum, totalBatches)
+					failedBatches.Add(1)
+					run.failure(batch, ErrEmptyUpstreamPayload)
+					continue
+				}
+
+				// Aggregate the quotes
+				if err := statsService.AggregateQuotes(ctx, quotes); err != nil {
+					failedBatches.Add(1)
+					run.failure(batch, err)
+					fmt.Printf("[%s] ERROR: Failed to aggregate quotes for batch %d/%d: %v\n", jobID, batchNum, totalBatches, err)
+					continue
+				}
+
+				// Detailed quotes also refresh company_info; a failed upsert doesn't invalidate the counts
+				if detailed, ok := quotes.([]detailedQuote); ok {
+					if _, err := s.upsertCompanyInfoFromQuotes(detailed); err != nil {
+						fmt.Printf("[%s] WARNING: Failed to upsert company info for batch %d/%d: %v\n", jobID, batchNum, totalBatches, err)
+					}
+				}
+
+				run.success(quoteCount)
+				successfulBatches.Add(1)
+				totalQuotesProcessed.Add(int64(quoteCount))
+				fmt.Printf("[%s] Batch %d/%d completed: %d quotes processed (expected %d symbols)\n", jobID, batchNum, totalBatches, quoteCount, len(batch))
+			}
+		}()
+	}
 
 	for i := 0; i < totalSymbols; i += batchSize {
 		batchNum := (i / batchSize) + 1
@@ -722,42 +1048,23 @@ func (s *FetcherService) RunMarketAggregation(ctx context.Context) (string, erro
 		if end > totalSymbols {
 			end = totalSymbols
 		}
-		batch := symbols[i:end]
-
-		// Check for context cancellation
-		select {
-		case <-ctx.Done():
-			fmt.Printf("[%s] Cancelled: context deadline exceeded at batch %d/%d\n", jobID, batchNum, totalBatches)
-			return "", ctx.Err()
-		default:
-		}
-
-		fmt.Printf("[%s] Processing batch %d/%d (%d symbols): %v\n", jobID, batchNum, totalBatches, len(batch), batch)
-
-		quotes, err := s.fetchSimpleQuotesWithLogging(ctx, batch, jobID, batchNum, totalBatches)
-		if err != nil {
-			failedBatches++
-			fmt.Printf("[%s] ERROR: Failed to fetch quotes for batch %d/%d: %v\n", jobID, batchNum, totalBatches, err)
-			continue
-		}
-
-		if len(quotes) == 0 {
-			fmt.Printf("[%s] WARNING: Batch %d/%d returned 0 quotes (all symbols may be invalid)\n", jobID, batchNum, totalBatches)
-			failedBatches++
-			continue
-		}
 
-		// Aggregate the quotes
-		if err := statsService.AggregateQuotes(ctx, quotes); err != nil {
-			failedBatches++
-			fmt.Printf("[%s] ERROR: Failed to aggregate quotes for batch %d/%d: %v\n", jobID, batchNum, totalBatches, err)
-			continue
+		// Check for context cancellation; select picks at random when a worker is also ready
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case jobs <- aggregationBatch{num: batchNum, symbols: symbols[i:end]}:
+				continue
+			}
 		}
-
-		successfulBatches++
-		totalQuotesProcessed += len(quotes)
-		fmt.Printf("[%s] Batch %d/%d completed: %d quotes processed (expected %d symbols)\n", jobID, batchNum, totalBatches, len(quotes), len(batch))
+		close(jobs)
+		wg.Wait()
+		run.failure(symbols[i:], ctx.Err())
+		fmt.Printf("[%s] Cancelled: %v at batch %d/%d\n", jobID, ctx.Err(), batchNum, totalBatches)
+		return "", ctx.Err()
 	}
+	close(jobs)
+	wg.Wait()
 
 	// Get final stats
 	finalStats, err := statsService.GetCurrentDayStats()
@@ -770,11 +1077,22 @@ func (s *FetcherService) RunMarketAggregation(ctx context.Context) (string, erro
 
 	duration := time.Since(startTime)
 	fmt.Printf("[%s] Aggregation completed in %v - Successful batches: %d/%d, Failed: %d, Quotes processed: %d\n",
-		jobID, duration, successfulBatches, totalBatches, failedBatches, totalQuotesProcessed)
+		jobID, duration, successfulBatches.Load(), totalBatches, failedBatches.Load(), totalQuotesProcessed.Load())
 
 	return jobID, nil
 }
 
+// fetchAggregationQuotes fetches a batch for market aggregation as []simpleQuote or []detailedQuote,
+// the two types AggregateQuotes accepts, along with the number of quotes returned
+func (s *FetcherService) fetchAggregationQuotes(ctx context.Context, mode string, symbols []string, jobID string, batchNum, totalBatches int) (interface{}, int, error) {
+	if mode == MarketAggregationModeDetailed {
+		quotes, err := s.fetchDetailedQuotes(ctx, symbols, jobID, batchNum, totalBatches)
+		return quotes, len(quotes), err
+	}
+	quotes, err := s.fetchSimpleQuotesWithLogging(ctx, symbols, jobID, batchNum, totalBatches)
+	return quotes, len(quotes), err
+}
+
 // fetchDetailedQuotes calls the quotes API for a batch of symbols
 func (s *FetcherService) fetchDetailedQuotes(ctx context.Context, symbols []string, jobID string, batchNum, totalBatches int) ([]detailedQuote, error) {
 	if len(symbols) == 0 {
@@ -783,20 +1101,31 @@ func (s *FetcherService) fetchDetailedQuotes(ctx context.Context, symbols []stri
 
 	// Build URL with comma-separated symbols (URL encoded)
 	symbolsParam := strings.Join(symbols, ", ")
-	encodedSymbols := url.QueryEscape(symbolsParam)
 
-	// Get primary and fallback URLs for quotes API
-	primaryBase, fallbackBase := getBaseURLs()
-	primaryURL := fmt.Sprintf("%s/v1/quotes?symbols=%s", primaryBase, encodedSymbols)
-	fallbackURL := fmt.Sprintf("%s/v1/quotes?symbols=%s", fallbackBase, encodedSymbols)
+	// Get request URLs for every configured endpoint (quotes API)
+	api := getUpstreamAPIConfig()
+	requestURLs := upstreamURLs(api.path(api.Quotes), url.Values{"symbols": {symbolsParam}})
 
 	// Only log detailed info if jobID is provided (for market aggregation)
 	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Calling API: %s\n", jobID, batchNum, totalBatches, primaryURL)
+		fmt.Printf("[%s] Batch %d/%d: Calling API: %s\n", jobID, batchNum, totalBatches, requestURLs[0])
 	}
 
+	// Decoded (and checked) before the response is cached
+	var quotes []detailedQuote
 	startTime := time.Now()
-	resp, usedURL, err := s.fetchWithFailover(ctx, primaryURL, fallbackURL, jobID, batchNum, totalBatches)
+	outcome, err := s.fetchUpstream(ctx, requestURLs, jobID, batchNum, totalBatches, func(body []byte) error {
+		if err := decodeUpstreamJSON(body, &quotes); err != nil {
+			if jobID != "" {
+				fmt.Printf("[%s] Batch %d/%d: ERROR decoding JSON response: %v\n", jobID, batchNum, totalBatches, err)
+			}
+			return err
+		}
+		if len(quotes) == 0 {
+			return ErrEmptyUpstreamPayload
+		}
+		return nil
+	})
 	requestDuration := time.Since(startTime)
 
 	if err != nil {
@@ -805,21 +1134,9 @@ func (s *FetcherService) fetchDetailedQuotes(ctx context.Context, symbols []stri
 		}
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if jobID != "" {
-		fmt.Printf("[%s] Batch %d/%d: Successfully fetched from %s in %v\n", jobID, batchNum, totalBatches, usedURL, requestDuration)
-	}
-
-	// Read response body
-	bodyReader := resp.Body
-	var quotes []detailedQuote
-	decoder := json.NewDecoder(bodyReader)
-	if err := decoder.Decode(&quotes); err != nil {
-		if jobID != "" {
-			fmt.Printf("[%s] Batch %d/%d: ERROR decoding JSON response: %v\n", jobID, batchNum, totalBatches, err)
-		}
-		return nil, err
+		fmt.Printf("[%s] Batch %d/%d: Successfully fetched from %s in %v\n", jobID, batchNum, totalBatches, outcome.source(), requestDuration)
 	}
 
 	if jobID != "" {
@@ -846,6 +1163,87 @@ func (s *FetcherService) fetchDetailedQuotes(ctx context.Context, symbols []stri
 	return quotes, nil
 }
 
+// companyInfoFromQuote converts a detailed quote into a CompanyInfo model
+func companyInfoFromQuote(quote detailedQuote) model.CompanyInfo {
+	return model.CompanyInfo{
+		Symbol:           quote.Symbol,
+		Name:             quote.Name,
+		Price:            quote.Price,
+		AfterHoursPrice:  quote.AfterHoursPrice,
+		Change:           quote.Change,
+		PercentChange:    format.NormalizePercent(quote.PercentChange),
+		Open:             quote.Open,
+		High:             quote.High,
+		Low:              quote.Low,
+		YearHigh:         quote.YearHigh,
+		YearLow:          quote.YearLow,
+		Volume:           quote.Volume,
+		AvgVolume:        quote.AvgVolume,
+		MarketCap:        format.NormalizeMarketCap(quote.MarketCap),
+		Beta:             quote.Beta,
+		PE:               quote.PE,
+		EarningsDate:     quote.EarningsDate,
+		Sector:           quote.Sector,
+		Industry:         quote.Industry,
+		About:            quote.About,
+		Employees:        quote.Employees,
+		FiveDaysReturn:   quote.FiveDaysReturn,
+		OneMonthReturn:   quote.OneMonthReturn,
+		ThreeMonthReturn: quote.ThreeMonthReturn,
+		SixMonthReturn:   quote.SixMonthReturn,
+		YtdReturn:        quote.YtdReturn,
+		YearReturn:       quote.YearReturn,
+		ThreeYearReturn:  quote.ThreeYearReturn,
+		FiveYearReturn:   quote.FiveYearReturn,
+		TenYearReturn:    quote.TenYearReturn,
+		MaxReturn:        quote.MaxReturn,
+		Logo:             quote.Logo,
+	}
+}
+
+// RefreshCompanyInfo fetches a fresh detailed quote for a single symbol, upserts it into
+// the database and refreshes its Redis entry. Returns the updated record.
+func (s *FetcherService) RefreshCompanyInfo(ctx context.Context, symbol string) (*model.CompanyInfo, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return nil, errors.New("symbol is required")
+	}
+
+	jobID := fmt.Sprintf("company-info-refresh-%s-%d", symbol, time.Now().UnixNano())
+	quotes, err := s.fetchDetailedQuotes(WithoutUpstreamCache(ctx), []string{symbol}, jobID, 1, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch quote for %s: %w", symbol, err)
+	}
+
+	var quote *detailedQuote
+	for i := range quotes {
+		if strings.EqualFold(quotes[i].Symbol, symbol) {
+			quote = &quotes[i]
+			break
+		}
+	}
+	if quote == nil {
+		return nil, errors.New("record not found")
+	}
+
+	if _, err := s.upsertCompanyInfoFromQuotes([]detailedQuote{*quote}); err != nil {
+		return nil, err
+	}
+
+	var companyInfo model.CompanyInfo
+	if err := s.db.Where("symbol = ?", quote.Symbol).First(&companyInfo).Error; err != nil {
+		return nil, fmt.Errorf("failed to load refreshed company info: %w", err)
+	}
+
+	// Overwrite any pending Redis entry so reads don't serve (or persist) the stale copy
+	dataCache := caching.NewDataCache()
+	if err := dataCache.CacheCompanyInfo(companyInfo.Symbol, &companyInfo); err != nil {
+		log.Printf("Warning: Failed to cache company info for %s: %v", companyInfo.Symbol, err)
+	}
+
+	return &companyInfo, nil
+}
+
 // upsertCompanyInfoFromQuotes upserts company info records in batch, avoiding duplicates by symbol (primary key)
 func (s *FetcherService) upsertCompanyInfoFromQuotes(quotes []detailedQuote) (int, error) {
 	if len(quotes) == 0 {
@@ -859,40 +1257,7 @@ func (s *FetcherService) upsertCompanyInfoFromQuotes(quotes []detailedQuote) (in
 			continue
 		}
 
-		companyInfo := model.CompanyInfo{
-			Symbol:           quote.Symbol,
-			Name:             quote.Name,
-			Price:            quote.Price,
-			AfterHoursPrice:  quote.AfterHoursPrice,
-			Change:           quote.Change,
-			PercentChange:    quote.PercentChange,
-			Open:             quote.Open,
-			High:             quote.High,
-			Low:              quote.Low,
-			YearHigh:         quote.YearHigh,
-			YearLow:          quote.YearLow,
-			Volume:           quote.Volume,
-			AvgVolume:        quote.AvgVolume,
-			MarketCap:        quote.MarketCap,
-			Beta:             quote.Beta,
-			PE:               quote.PE,
-			EarningsDate:     quote.EarningsDate,
-			Sector:           quote.Sector,
-			Industry:         quote.Industry,
-			About:            quote.About,
-			Employees:        quote.Employees,
-			FiveDaysReturn:   quote.FiveDaysReturn,
-			OneMonthReturn:   quote.OneMonthReturn,
-			ThreeMonthReturn: quote.ThreeMonthReturn,
-			SixMonthReturn:   quote.SixMonthReturn,
-			YtdReturn:        quote.YtdReturn,
-			YearReturn:       quote.YearReturn,
-			ThreeYearReturn:  quote.ThreeYearReturn,
-			FiveYearReturn:   quote.FiveYearReturn,
-			TenYearReturn:    quote.TenYearReturn,
-			MaxReturn:        quote.MaxReturn,
-			Logo:             quote.Logo,
-		}
+		companyInfo := companyInfoFromQuote(quote)
 		companyInfoList = append(companyInfoList, companyInfo)
 	}
 
@@ -934,7 +1299,10 @@ type financialsResponse struct {
 // RunFundamentalDataIngestion fetches fundamental data (income, balance, cashflow) for all symbols from screener table.
 // It fetches all three statement types and both annual and quarterly frequencies.
 // It avoids duplicate data by using ON CONFLICT (upsert) based on unique constraint (symbol, statement_type, frequency).
-func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (string, error) {
+func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (jobID string, err error) {
+	ctx, run := s.startIngestionRun(ctx, IngestionTypeFundamentalData)
+	defer func() { run.finish(jobID, err) }()
+
 	// Get all unique symbols from screener table (with caching)
 	symbols, err := s.getAllSymbols()
 	if err != nil {
@@ -944,6 +1312,7 @@ func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (strin
 	if len(symbols) == 0 {
 		return fmt.Sprintf("fundamental-data-ingestion-%d", time.Now().UnixNano()), nil
 	}
+	run.attempted(len(symbols))
 
 	// Statement types to fetch
 	statementTypes := []string{"income", "balance", "cashflow"}
@@ -962,12 +1331,16 @@ func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (strin
 		}
 
 		// Fetch all statement types and frequencies for this symbol
+		// The symbol counts as succeeded when at least one statement was stored
+		stored := 0
+		var lastErr error
 		for _, statementType := range statementTypes {
 			for _, frequency := range frequencies {
 				// Fetch financial data
 				financialData, err := s.fetchFinancials(ctx, symbol, statementType, frequency)
 				if err != nil {
 					// Log error but continue with next combination
+					lastErr = err
 					continue
 				}
 
@@ -978,6 +1351,7 @@ func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (strin
 				statementJSON, err := json.Marshal(financialData.Statement)
 				if err != nil {
 					log.Printf("Warning: Failed to marshal statement for %s: %v", symbol, err)
+					lastErr = err
 					continue
 				}
 				
@@ -990,12 +1364,22 @@ func (s *FetcherService) RunFundamentalDataIngestion(ctx context.Context) (strin
 				
 				// Save to Redis ONLY
 				if err := dataCache.CacheFundamentalData(symbol, statementType, frequency, &fundamentalDataRecord); err != nil {
-					log.Printf("Warning: Failed to cache fundamental data for %s: %v", symbol, err)
-					continue
+					log.Printf("Warning: Failed to cache fundamental data for %s, writing it to the database: %v", symbol, err)
+					if err := s.upsertFundamentalData(financialData); err != nil {
+						lastErr = err
+						continue
+					}
 				}
 				totalUpserted++
+				stored++
 			}
 		}
+
+		if stored > 0 {
+			run.success(1)
+		} else {
+			run.failure([]string{symbol}, lastErr)
+		}
 	}
 
 	return fmt.Sprintf("fundamental-data-ingestion-%d", time.Now().UnixNano()), nil
@@ -1007,28 +1391,28 @@ func (s *FetcherService) fetchFinancials(ctx context.Context, symbol, statementT
 		return nil, errors.New("symbol, statement type, and frequency are required")
 	}
 
-	// Get primary and fallback URLs for financials API
-	primaryBase, fallbackBase := getBaseURLs()
-	primaryURL := fmt.Sprintf("%s/v1/financials/%s?statement=%s&frequency=%s", primaryBase, symbol, statementType, frequency)
-	fallbackURL := fmt.Sprintf("%s/v1/financials/%s?statement=%s&frequency=%s", fallbackBase, symbol, statementType, frequency)
-
-	// Try with failover
-	resp, _, err := s.fetchWithFailover(ctx, primaryURL, fallbackURL, "", 0, 0)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	// Get request URLs for every configured endpoint (financials API)
+	api := getUpstreamAPIConfig()
+	requestURLs := upstreamURLs(api.path(api.Financials, symbol), url.Values{
+		"statement": {statementType},
+		"frequency": {frequency},
+	})
 
+	// Try with failover; the response is validated before it is cached
 	var financialData financialsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&financialData); err != nil {
+	if _, err := s.fetchUpstream(ctx, requestURLs, "", 0, 0, func(body []byte) error {
+		financialData = financialsResponse{}
+		if err := decodeUpstreamJSON(body, &financialData); err != nil {
+			return err
+		}
+		if financialData.Symbol == "" || financialData.StatementType == "" || financialData.Frequency == "" {
+			return errors.New("invalid response: missing required fields")
+		}
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
-	// Validate response
-	if financialData.Symbol == "" || financialData.StatementType == "" || financialData.Frequency == "" {
-		return nil, errors.New("invalid response: missing required fields")
-	}
-
 	return &financialData, nil
 }
 
@@ -1053,20 +1437,23 @@ func (s *FetcherService) upsertFundamentalData(financialData *financialsResponse
 	}
 
 	// Use upsert with ON CONFLICT based on unique constraint (symbol, statement_type, frequency)
-	result := s.db.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "symbol"},
-			{Name: "statement_type"},
-			{Name: "frequency"},
-		},
-		DoUpdates: clause.AssignmentColumns([]string{
-			"statement", "updated_at",
-		}),
-	}).Create(&fundamentalDataRecord)
+	// and rebuild the normalized line items in the same transaction
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "symbol"},
+				{Name: "statement_type"},
+				{Name: "frequency"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"statement", "updated_at",
+			}),
+		}).Create(&fundamentalDataRecord)
 
-	if result.Error != nil {
-		return fmt.Errorf("failed to upsert fundamental data: %w", result.Error)
-	}
+		if result.Error != nil {
+			return fmt.Errorf("failed to upsert fundamental data: %w", result.Error)
+		}
 
-	return nil
+		return model.ReplaceFundamentalLineItems(tx, &fundamentalDataRecord)
+	})
 }
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"screener/backend/service/caching"
+)
+
+// newUpstreamServer serves status after delay on every request
+func newUpstreamServer(t *testing.T, status int, delay time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchWithFailoverPrimarySuccess(t *testing.T) {
+	primary := newUpstreamServer(t, http.StatusOK, 20*time.Millisecond)
+	fallback := newUpstreamServer(t, http.StatusOK, 0)
+	s := &FetcherService{httpClient: primary.Client()}
+
+	urls := []string{primary.URL + "/v1/quotes", fallback.URL + "/v1/quotes"}
+	resp, outcome, err := s.fetchWithFailover(context.Background(), urls, "", 0, 0)
+	if err != nil {
+		t.Fatalf("fetchWithFailover returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if outcome.URL != urls[0] {
+		t.Errorf("URL = %q, want the primary %q", outcome.URL, urls[0])
+	}
+	if outcome.Failover {
+		t.Error("Failover = true, want false when the primary serves the request")
+	}
+	if outcome.Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", outcome.Attempts)
+	}
+	if outcome.Latency < 20*time.Millisecond {
+		t.Errorf("Latency = %v, want at least the primary's 20ms", outcome.Latency)
+	}
+	if outcome.Cached || outcome.source() != urls[0] {
+		t.Errorf("source() = %q, want the primary URL", outcome.source())
+	}
+}
+
+func TestFetchWithFailoverFallsBackAfterPrimaryError(t *testing.T) {
+	primary := newUpstreamServer(t, http.StatusServiceUnavailable, 10*time.Millisecond)
+	fallback := newUpstreamServer(t, http.StatusOK, 10*time.Millisecond)
+	s := &FetcherService{httpClient: primary.Client()}
+
+	urls := []string{primary.URL + "/v1/quotes", fallback.URL + "/v1/quotes"}
+	resp, outcome, err := s.fetchWithFailover(context.Background(), urls, "", 0, 0)
+	if err != nil {
+		t.Fatalf("fetchWithFailover returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if outcome.URL != urls[1] {
+		t.Errorf("URL = %q, want the fallback %q", outcome.URL, urls[1])
+	}
+	if !outcome.Failover {
+		t.Error("Failover = false, want true when the fallback serves the request")
+	}
+	if outcome.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2", outcome.Attempts)
+	}
+	// Latency covers both attempts, not just the one that succeeded
+	if outcome.Latency < 20*time.Millisecond {
+		t.Errorf("Latency = %v, want at least both endpoints' 10ms", outcome.Latency)
+	}
+}
+
+func TestFetchWithFailoverFallsBackAfterNetworkError(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL + "/v1/quotes"
+	down.Close()
+	fallback := newUpstreamServer(t, http.StatusOK, 0)
+	s := &FetcherService{httpClient: fallback.Client()}
+
+	urls := []string{downURL, fallback.URL + "/v1/quotes"}
+	resp, outcome, err := s.fetchWithFailover(context.Background(), urls, "", 0, 0)
+	if err != nil {
+		t.Fatalf("fetchWithFailover returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if outcome.URL != urls[1] || !outcome.Failover || outcome.Attempts != 2 {
+		t.Errorf("outcome = %+v, want URL %q, Failover and 2 attempts", outcome, urls[1])
+	}
+}
+
+func TestFetchWithFailoverAllEndpointsFail(t *testing.T) {
+	primary := newUpstreamServer(t, http.StatusInternalServerError, 0)
+	fallback := newUpstreamServer(t, http.StatusBadGateway, 0)
+	s := &FetcherService{httpClient: primary.Client()}
+
+	urls := []string{primary.URL + "/v1/quotes", fallback.URL + "/v1/quotes"}
+	resp, outcome, err := s.fetchWithFailover(context.Background(), urls, "", 0, 0)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("fetchWithFailover returned nil error, want every endpoint to fail")
+	}
+	if outcome.URL != "" || outcome.Failover {
+		t.Errorf("outcome = %+v, want no URL and no failover when nothing succeeded", outcome)
+	}
+	if outcome.Attempts != 2 {
+		t.Errorf("Attempts = %d, want 2", outcome.Attempts)
+	}
+	if outcome.Latency <= 0 {
+		t.Errorf("Latency = %v, want the time spent on the failed attempts", outcome.Latency)
+	}
+}
+
+func TestFetchWithFailoverClientErrorsDoNotOpenTheCircuit(t *testing.T) {
+	notFound := newUpstreamServer(t, http.StatusNotFound, 0)
+	fallback := newUpstreamServer(t, http.StatusOK, 0)
+	s := &FetcherService{httpClient: notFound.Client()}
+	lb := getUpstreamBalancer()
+
+	// Well past FETCHER_BREAKER_FAILURES: bad symbols must not take a healthy host out
+	urls := []string{notFound.URL + "/v1/quotes?symbols=NOPE", fallback.URL + "/v1/quotes?symbols=NOPE"}
+	for i := 0; i < 2*lb.maxFailures+1; i++ {
+		resp, outcome, err := s.fetchWithFailover(context.Background(), urls, "", 0, 0)
+		if !errors.Is(err, ErrUpstreamRejected) {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			t.Fatalf("fetchWithFailover of a 404 returned %v, want ErrUpstreamRejected", err)
+		}
+		if outcome.Attempts != 1 {
+			t.Errorf("Attempts = %d, want the 404 returned without failing over", outcome.Attempts)
+		}
+	}
+
+	lb.mu.Lock()
+	_, tracked := lb.hosts[upstreamHost(notFound.URL)]
+	lb.mu.Unlock()
+	if tracked {
+		t.Error("the 404 host has recorded failures, want client errors to count as host successes")
+	}
+	if got := lb.order(urls); got[0] != urls[0] {
+		t.Errorf("order = %v, want the 404 host still first", got)
+	}
+
+	// A 429 is the host's fault and fails over like a 5xx
+	limited := newUpstreamServer(t, http.StatusTooManyRequests, 0)
+	resp, outcome, err := s.fetchWithFailover(context.Background(), []string{limited.URL + "/v1/quotes", fallback.URL + "/v1/quotes"}, "", 0, 0)
+	if err != nil {
+		t.Fatalf("fetchWithFailover after a 429 returned error: %v", err)
+	}
+	resp.Body.Close()
+	if !outcome.Failover {
+		t.Errorf("outcome = %+v, want the 429 to fail over", outcome)
+	}
+}
+
+// failingReader returns some bytes and then a read error, like a connection reset mid-body
+type failingReader struct {
+	sent bool
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.sent {
+		return 0, errors.New("connection reset by peer")
+	}
+	f.sent = true
+	return copy(p, `{"AAPL":`), nil
+}
+
+// infiniteReader returns an endless stream of spaces
+type infiniteReader struct{}
+
+func (infiniteReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}
+
+func TestReadUpstreamBodyEnforcesTheSizeCap(t *testing.T) {
+	t.Setenv("FETCHER_MAX_RESPONSE_BYTES", "16")
+
+	body, err := readUpstreamBody(strings.NewReader(strings.Repeat("x", 16)))
+	if err != nil || len(body) != 16 {
+		t.Errorf("reading exactly the cap = %d bytes, %v; want all 16 bytes", len(body), err)
+	}
+
+	if _, err := readUpstreamBody(strings.NewReader(strings.Repeat("x", 17))); !errors.Is(err, ErrUpstreamResponseTooLarge) {
+		t.Errorf("reading one byte over the cap returned %v, want ErrUpstreamResponseTooLarge", err)
+	}
+
+	// Oversized bodies are rejected after cap+1 bytes rather than read to the end
+	huge := io.LimitReader(infiniteReader{}, 1<<30)
+	if _, err := readUpstreamBody(huge); !errors.Is(err, ErrUpstreamResponseTooLarge) {
+		t.Errorf("reading a 1GB body returned %v, want ErrUpstreamResponseTooLarge", err)
+	}
+
+	if _, err := readUpstreamBody(&failingReader{}); err == nil || errors.Is(err, ErrUpstreamResponseTooLarge) {
+		t.Errorf("reading a body that fails midway returned %v, want the read error", err)
+	}
+}
+
+func TestGetMaxResponseBytesDefaults(t *testing.T) {
+	for _, value := range []string{"", "0", "-1", "lots"} {
+		t.Setenv("FETCHER_MAX_RESPONSE_BYTES", value)
+		if got := getMaxResponseBytes(); got != defaultMaxResponseBytes {
+			t.Errorf("FETCHER_MAX_RESPONSE_BYTES=%q gives %d, want the default %d", value, got, defaultMaxResponseBytes)
+		}
+	}
+}
+
+func TestDecodeUpstreamJSONRejectsMalformedBodies(t *testing.T) {
+	for _, body := range []string{"", "not json", `{"AAPL":`, `<html>502 Bad Gateway</html>`, `[1,2]`} {
+		var dest map[string]float64
+		if err := decodeUpstreamJSON([]byte(body), &dest); err == nil {
+			t.Errorf("decodeUpstreamJSON(%q) returned nil, want a decode error", body)
+		}
+	}
+
+	var dest map[string]float64
+	if err := decodeUpstreamJSON([]byte(`{"AAPL": 182.5}`), &dest); err != nil || dest["AAPL"] != 182.5 {
+		t.Errorf("decodeUpstreamJSON of a valid body = %v, %v", dest, err)
+	}
+}
+
+func TestFetchUpstreamRejectsOversizedBody(t *testing.T) {
+	t.Setenv("FETCHER_MAX_RESPONSE_BYTES", "64")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat(" ", 65)))
+	}))
+	defer server.Close()
+	s := &FetcherService{httpClient: server.Client(), ttl: &caching.CacheTTLConfig{}}
+
+	outcome, err := s.fetchUpstream(context.Background(), []string{server.URL + "/v1/quotes"}, "", 0, 0, func([]byte) error { return nil })
+	if !errors.Is(err, ErrUpstreamResponseTooLarge) {
+		t.Errorf("fetchUpstream returned %v, want ErrUpstreamResponseTooLarge", err)
+	}
+	if outcome.URL == "" {
+		t.Error("outcome.URL is empty, want the endpoint that sent the oversized body")
+	}
+}
+
+func TestFetchBarsRejectsMalformedAndEmptyPayloads(t *testing.T) {
+	tests := []struct {
+		body    string
+		wantErr error
+	}{
+		{`{"1700000000": {"open": 1,`, nil},
+		{`{}`, ErrEmptyUpstreamPayload},
+		{`null`, ErrEmptyUpstreamPayload},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(tt.body))
+		}))
+		t.Setenv("FINANCE_QUERY_URLS", server.URL)
+		s := &FetcherService{httpClient: server.Client(), ttl: &caching.CacheTTLConfig{}}
+
+		_, err := s.fetchBars(context.Background(), "AAPL", "1y", "1d")
+		server.Close()
+		if err == nil {
+			t.Errorf("fetchBars with body %q returned nil error", tt.body)
+			continue
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("fetchBars with body %q returned %v, want %v", tt.body, err, tt.wantErr)
+		}
+		if tt.wantErr == nil && errors.Is(err, ErrEmptyUpstreamPayload) {
+			t.Errorf("fetchBars with truncated body %q returned %v, want a decode error", tt.body, err)
+		}
+	}
+}
+
+func TestFetchUpstreamCachesOnlyDecodedBodies(t *testing.T) {
+	newTestRedis(t)
+	var requests int
+	body := `{}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	t.Setenv("FINANCE_QUERY_URLS", server.URL)
+	s := &FetcherService{httpClient: server.Client(), cache: caching.NewCacheService(), ttl: &caching.CacheTTLConfig{UpstreamResponse: time.Minute}}
+
+	// An empty 200 is rejected and not cached, so the next call goes upstream again
+	if _, err := s.fetchBars(context.Background(), "AAPL", "1y", "1d"); !errors.Is(err, ErrEmptyUpstreamPayload) {
+		t.Fatalf("fetchBars of an empty payload returned %v, want ErrEmptyUpstreamPayload", err)
+	}
+	body = `{"1700000000": {"open": 1, "high": 2, "low": 0.5, "close": 1.5, "volume": 100}}`
+	for i := 0; i < 2; i++ {
+		bars, err := s.fetchBars(context.Background(), "AAPL", "1y", "1d")
+		if err != nil || len(bars) != 1 {
+			t.Fatalf("fetchBars = %v, %v; want one bar", bars, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("upstream requests = %d, want 2 (the empty payload, then one valid body served from cache)", requests)
+	}
+}
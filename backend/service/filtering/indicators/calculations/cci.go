@@ -0,0 +1,42 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+)
+
+// cciConstant is Lambert's scaling factor, chosen so roughly 70-80% of CCI values fall within ±100
+const cciConstant = 0.015
+
+// CCI computes the Commodity Channel Index as of the last bar:
+//
+//	CCI = (TP - SMA(TP, lookback)) / (0.015 * mean absolute deviation of TP from its SMA)
+//
+// where TP (typical price) = (high+low+close)/3. A flat window (zero mean deviation) yields 0.
+// Returns ErrInsufficientData if there are fewer than lookback bars.
+func CCI(rows []model.Historical, lookback int) (float64, error) {
+	if lookback <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(rows) < lookback {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, lookback, len(rows))
+	}
+
+	typical := make([]float64, 0, lookback)
+	for _, r := range rows[len(rows)-lookback:] {
+		typical = append(typical, (r.High+r.Low+r.Close)/3)
+	}
+
+	mean := SimpleMovingAverage(typical, lookback)
+	var deviation float64
+	for _, tp := range typical {
+		deviation += abs(tp - mean)
+	}
+	deviation /= float64(lookback)
+
+	if deviation == 0 {
+		return 0, nil
+	}
+	return (typical[len(typical)-1] - mean) / (cciConstant * deviation), nil
+}
@@ -0,0 +1,58 @@
+package calculations
+
+import (
+	"errors"
+	"testing"
+
+	"screener/backend/model"
+)
+
+func TestCCIKnownValue(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []model.Historical
+		want float64
+	}{
+		// TP 1, 2, 6: mean 3, mean deviation 2, so CCI = (6-3) / (0.015*2) = 100 exactly
+		{"close-only bars", []model.Historical{bar(1, 1, 1), bar(2, 2, 2), bar(6, 6, 6)}, 100},
+		// Same typical prices from (high+low+close)/3
+		{"typical price", []model.Historical{bar(3, 0, 0), bar(3, 3, 0), bar(9, 6, 3)}, 100},
+		// A falling last bar mirrors it: TP 6, 2, 1 -> (1-3) / (0.015*2)
+		{"below the mean", []model.Historical{bar(6, 6, 6), bar(2, 2, 2), bar(1, 1, 1)}, -200.0 / 3},
+		// Only the trailing lookback bars count
+		{"trailing window", []model.Historical{bar(500, 500, 500), bar(1, 1, 1), bar(2, 2, 2), bar(6, 6, 6)}, 100},
+	}
+	for _, tt := range tests {
+		got, err := CCI(tt.rows, 3)
+		if err != nil {
+			t.Errorf("%s: CCI returned error: %v", tt.name, err)
+			continue
+		}
+		if !approxEqual(got, tt.want) {
+			t.Errorf("%s: CCI = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCCIZeroDeviation(t *testing.T) {
+	// Different bars, identical typical price (10): zero mean deviation yields 0 instead of dividing by it
+	rows := []model.Historical{bar(12, 8, 10), bar(11, 9, 10), bar(10, 10, 10)}
+
+	got, err := CCI(rows, 3)
+	if err != nil {
+		t.Fatalf("CCI returned error: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("CCI = %v, want 0", got)
+	}
+}
+
+func TestCCIInsufficientBars(t *testing.T) {
+	rows := []model.Historical{bar(1, 1, 1), bar(2, 2, 2)}
+	if _, err := CCI(rows, 3); !errors.Is(err, ErrInsufficientData) {
+		t.Errorf("CCI with 2 bars returned %v, want ErrInsufficientData", err)
+	}
+	if _, err := CCI(rows, 0); err == nil || errors.Is(err, ErrInsufficientData) {
+		t.Errorf("CCI with lookback 0 returned %v, want a non-positive lookback error", err)
+	}
+}
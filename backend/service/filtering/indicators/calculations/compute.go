@@ -5,6 +5,7 @@ import (
 	"screener/backend/database"
 	"screener/backend/model"
 	"screener/backend/service/filtering/indicators"
+	"sync"
 
 	"gorm.io/gorm"
 )
@@ -23,30 +24,108 @@ func NewIndicatorCalculationService() *IndicatorCalculationService {
 
 // ComputeIndicators computes a snapshot of indicators for the most recent bar
 // using historical table data constrained by symbol/range/interval and lookbacks.
-// It expects data ordered by epoch ascending.
+// Only the trailing window the lookbacks need is loaded (see IndicatorLookbacks.TrailingWindow):
+// for 10 years of daily bars and the default lookbacks that is 55 rows instead of ~2,500.
 func (s *IndicatorCalculationService) ComputeIndicators(symbol, rangeParam, interval string, lookbacks indicators.IndicatorLookbacks) (*indicators.IndicatorSnapshot, error) {
 	if symbol == "" || rangeParam == "" || interval == "" {
 		return nil, errors.New("symbol, range and interval are required")
 	}
 
+	// Newest bars first so the limit keeps the trailing window, then back to epoch ascending
 	var rows []model.Historical
 	if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, rangeParam, interval).
-		Order("epoch ASC").
+		Order("epoch DESC").
+		Limit(lookbacks.TrailingWindow()).
 		Find(&rows).Error; err != nil {
 		return nil, err
 	}
 	if len(rows) == 0 {
 		return nil, errors.New("no historical data")
 	}
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+
+	return computeSnapshot(symbol, rangeParam, interval, rows, lookbacks), nil
+}
+
+// ComputeIndicatorsBatch computes indicator snapshots for multiple symbols sharing the same
+// range/interval and lookbacks. The trailing window of every series is loaded with a single
+// query and computed concurrently. Symbols without data are returned in the skipped map with
+// a reason instead of failing the whole batch.
+func (s *IndicatorCalculationService) ComputeIndicatorsBatch(symbols []string, rangeParam, interval string, lookbacks indicators.IndicatorLookbacks) (map[string]*indicators.IndicatorSnapshot, map[string]string, error) {
+	if len(symbols) == 0 || rangeParam == "" || interval == "" {
+		return nil, nil, errors.New("symbols, range and interval are required")
+	}
+
+	// Rank each symbol's bars newest first and keep the trailing window per symbol
+	// (the outer query is unscoped: soft deletes are already filtered inside)
+	recent := s.db.Model(&model.Historical{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY symbol ORDER BY epoch DESC) AS bar_rank").
+		Where("symbol IN ? AND range = ? AND interval = ?", symbols, rangeParam, interval)
+
+	var rows []model.Historical
+	if err := s.db.Unscoped().Table("(?) AS recent", recent).
+		Where("bar_rank <= ?", lookbacks.TrailingWindow()).
+		Order("symbol ASC, epoch ASC").
+		Find(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// Group rows by symbol (order within each group stays epoch ASC)
+	series := make(map[string][]model.Historical, len(symbols))
+	for _, r := range rows {
+		series[r.Symbol] = append(series[r.Symbol], r)
+	}
 
+	snapshots := make(map[string]*indicators.IndicatorSnapshot, len(symbols))
+	skipped := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchComputeConcurrency)
+
+	for _, symbol := range symbols {
+		symRows := series[symbol]
+		if len(symRows) == 0 {
+			skipped[symbol] = "no historical data"
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(symbol string, symRows []model.Historical) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			snapshot := computeSnapshot(symbol, rangeParam, interval, symRows, lookbacks)
+			mu.Lock()
+			snapshots[symbol] = snapshot
+			mu.Unlock()
+		}(symbol, symRows)
+	}
+	wg.Wait()
+
+	return snapshots, skipped, nil
+}
+
+// batchComputeConcurrency bounds the number of snapshots computed in parallel
+const batchComputeConcurrency = 8
+
+// computeSnapshot computes the indicator snapshot for the most recent bar of a series
+// ordered by epoch ascending
+func computeSnapshot(symbol, rangeParam, interval string, rows []model.Historical, lookbacks indicators.IndicatorLookbacks) *indicators.IndicatorSnapshot {
 	// Most recent bar (data is ordered ASC)
 	last := rows[len(rows)-1]
 
+	// Indicators whose lookback exceeds the available bars are left at 0 and reported
+	insufficient := make([]string, 0)
+
 	// ATR% using TR over consecutive bars, then SMA(ATR, n) / close * 100
 	var atrPct float64 = 0
 	if lookbacks.ATR > 0 {
-		atr := AverageTrueRange(rows, lookbacks.ATR)
-		if last.Close != 0 {
+		if atr, err := AverageTrueRangeStrict(rows, lookbacks.ATR); err != nil {
+			insufficient = append(insufficient, "atr_percent")
+		} else if last.Close != 0 {
 			atrPct = (atr / last.Close) * 100.0
 		}
 	}
@@ -58,8 +137,9 @@ func (s *IndicatorCalculationService) ComputeIndicators(symbol, rangeParam, inte
 		for _, r := range rows {
 			rngSeries = append(rngSeries, r.High-r.Low)
 		}
-		adr := SimpleMovingAverage(rngSeries, lookbacks.ADR)
-		if last.Close != 0 {
+		if adr, err := SimpleMovingAverageStrict(rngSeries, lookbacks.ADR); err != nil {
+			insufficient = append(insufficient, "adr_percent")
+		} else if last.Close != 0 {
 			adrPct = (adr / last.Close) * 100.0
 		}
 	}
@@ -78,8 +158,11 @@ func (s *IndicatorCalculationService) ComputeIndicators(symbol, rangeParam, inte
 		for _, r := range rows {
 			volDollarSeries = append(volDollarSeries, float64(r.Volume)*r.Close)
 		}
-		v := SimpleMovingAverage(volDollarSeries, lookbacks.VolumeSMA)
-		volDollarsSMAM = v / 1_000_000.0
+		if v, err := SimpleMovingAverageStrict(volDollarSeries, lookbacks.VolumeSMA); err != nil {
+			insufficient = append(insufficient, "volume_dollars_sma_m")
+		} else {
+			volDollarsSMAM = v / 1_000_000.0
+		}
 	}
 
 	// Daily volume dollars (last bar), in millions
@@ -92,8 +175,9 @@ func (s *IndicatorCalculationService) ComputeIndicators(symbol, rangeParam, inte
 		for _, r := range rows {
 			closes = append(closes, r.Close)
 		}
-		ma := SimpleMovingAverage(closes, lookbacks.MA)
-		if ma != 0 {
+		if ma, err := SimpleMovingAverageStrict(closes, lookbacks.MA); err != nil {
+			insufficient = append(insufficient, "percent_gain_from_ma")
+		} else if ma != 0 {
 			pctFromMA = ((last.Close - ma) / ma) * 100.0
 		}
 	}
@@ -117,5 +201,6 @@ func (s *IndicatorCalculationService) ComputeIndicators(symbol, rangeParam, inte
 		DailyVolumeDollarsM:  dailyVolDollarsM,
 		PercentGainFromMA:    pctFromMA,
 		InsideDay:            insideDay,
-	}, nil
+		InsufficientData:     insufficient,
+	}
 }
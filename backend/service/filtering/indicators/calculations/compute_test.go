@@ -0,0 +1,118 @@
+package calculations
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// tenYearsOfDailyBars is roughly how many daily bars a 10y range holds
+const tenYearsOfDailyBars = 2520
+
+// dailySeries returns n synthetic daily bars ordered by epoch ascending
+func dailySeries(n int) []model.Historical {
+	rows := make([]model.Historical, n)
+	for i := range rows {
+		close := 100 + 10*math.Sin(float64(i)/15)
+		rows[i] = model.Historical{
+			Symbol:   "AAPL",
+			Epoch:    1420070400 + int64(i)*86400,
+			Range:    "10y",
+			Interval: "1d",
+			Open:     close - 0.5,
+			High:     close + 1 + float64(i%7)/10,
+			Low:      close - 1 - float64(i%5)/10,
+			Close:    close,
+			Volume:   1_000_000 + int64(i%11)*10_000,
+		}
+	}
+	return rows
+}
+
+// newestFirst returns the sqlmock result of rows ordered by epoch descending
+func newestFirst(rows []model.Historical) *sqlmock.Rows {
+	result := sqlmock.NewRows([]string{"symbol", "epoch", "range", "interval", "open", "high", "low", "close", "volume"})
+	for i := len(rows) - 1; i >= 0; i-- {
+		r := rows[i]
+		result.AddRow(r.Symbol, r.Epoch, r.Range, r.Interval, r.Open, r.High, r.Low, r.Close, r.Volume)
+	}
+	return result
+}
+
+func TestComputeSnapshotTrailingWindowMatchesFullSeries(t *testing.T) {
+	rows := dailySeries(tenYearsOfDailyBars)
+	lookbacks := indicators.DefaultIndicatorLookbacks()
+
+	full := computeSnapshot("AAPL", "10y", "1d", rows, lookbacks)
+	trailing := computeSnapshot("AAPL", "10y", "1d", rows[len(rows)-lookbacks.TrailingWindow():], lookbacks)
+	if !reflect.DeepEqual(trailing, full) {
+		t.Errorf("snapshot over the trailing %d bars = %+v, want the full-series snapshot %+v", lookbacks.TrailingWindow(), trailing, full)
+	}
+}
+
+// BenchmarkComputeIndicators compares ComputeIndicators loading only the trailing window
+// against loading and computing over 10 years of daily bars
+func BenchmarkComputeIndicators(b *testing.B) {
+	rows := dailySeries(tenYearsOfDailyBars)
+	lookbacks := indicators.DefaultIndicatorLookbacks()
+	query := regexp.QuoteMeta(`SELECT * FROM "historical" WHERE (symbol = $1 AND range = $2 AND interval = $3)`)
+
+	b.Run("trailing window", func(b *testing.B) {
+		db, mock := newBenchmarkDB(b)
+		s := &IndicatorCalculationService{db: db}
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			mock.ExpectQuery(query).WillReturnRows(newestFirst(rows[len(rows)-lookbacks.TrailingWindow():]))
+			b.StartTimer()
+
+			if _, err := s.ComputeIndicators("AAPL", "10y", "1d", lookbacks); err != nil {
+				b.Fatalf("ComputeIndicators returned error: %v", err)
+			}
+		}
+	})
+
+	b.Run("full series", func(b *testing.B) {
+		db, mock := newBenchmarkDB(b)
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			mock.ExpectQuery(query).WillReturnRows(newestFirst(rows))
+			b.StartTimer()
+
+			// The same load without the limit, as before the trailing window
+			var series []model.Historical
+			if err := db.Where("symbol = ? AND range = ? AND interval = ?", "AAPL", "10y", "1d").
+				Order("epoch DESC").
+				Find(&series).Error; err != nil {
+				b.Fatalf("loading the full series returned error: %v", err)
+			}
+			for lo, hi := 0, len(series)-1; lo < hi; lo, hi = lo+1, hi-1 {
+				series[lo], series[hi] = series[hi], series[lo]
+			}
+			computeSnapshot("AAPL", "10y", "1d", series, lookbacks)
+		}
+	})
+}
+
+// newBenchmarkDB returns a Postgres-dialect gorm.DB backed by sqlmock
+func newBenchmarkDB(b *testing.B) (*gorm.DB, sqlmock.Sqlmock) {
+	b.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatalf("failed to create sqlmock: %v", err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		b.Fatalf("failed to open gorm on sqlmock: %v", err)
+	}
+	b.Cleanup(func() { _ = sqlDB.Close() })
+	return db, mock
+}
@@ -1,9 +1,19 @@
 package calculations
 
-import "screener/backend/model"
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+)
 
-// SimpleMovingAverage returns SMA over the last N values of the series.
+// ErrInsufficientData is returned when a series has fewer points than the requested lookback
+var ErrInsufficientData = errors.New("insufficient data")
+
+// SimpleMovingAverage returns the trailing SMA of the series: the mean of the last N values,
+// i.e. series[len-N:], which includes the most recent point. Callers can pass the full series;
+// only the trailing window is used.
 // If there are fewer than N points, it averages available points; if series is empty returns 0.
+// Use SimpleMovingAverageStrict to reject under-sampled series instead.
 func SimpleMovingAverage(series []float64, n int) float64 {
 	if n <= 0 || len(series) == 0 {
 		return 0
@@ -23,13 +33,61 @@ func SimpleMovingAverage(series []float64, n int) float64 {
 	return sum / float64(n)
 }
 
+// SimpleMovingAverageStrict returns the trailing SMA over the last N values of the series
+// (same windowing as SimpleMovingAverage), or ErrInsufficientData if len(series) < N.
+func SimpleMovingAverageStrict(series []float64, n int) (float64, error) {
+	if n <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(series) < n {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, n, len(series))
+	}
+	return SimpleMovingAverage(series, n), nil
+}
+
+// ExponentialMovingAverage returns the EMA of the series as of its last point, using
+// smoothing 2/(N+1) seeded with the SMA of the first N values. Returns ErrInsufficientData
+// if len(series) < N, since the seed needs N warmup points.
+func ExponentialMovingAverage(series []float64, n int) (float64, error) {
+	if n <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(series) < n {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, n, len(series))
+	}
+	ema := SimpleMovingAverage(series[:n], n)
+	k := 2.0 / float64(n+1)
+	for _, v := range series[n:] {
+		ema = v*k + ema*(1-k)
+	}
+	return ema, nil
+}
+
+// AverageTrueRangeStrict computes ATR over the last N bars (see AverageTrueRange),
+// or returns ErrInsufficientData if there are fewer than N bars.
+func AverageTrueRangeStrict(rows []model.Historical, n int) (float64, error) {
+	if n <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(rows) < n {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, n, len(rows))
+	}
+	return AverageTrueRange(rows, n), nil
+}
+
 // AverageTrueRange computes ATR over the last N bars using Wilder's SMA of True Range.
+// The TR series is built over all rows and the trailing window of N TRs is averaged.
 // If fewer than N bars, it averages available TRs.
 func AverageTrueRange(rows []model.Historical, n int) float64 {
 	if n <= 0 || len(rows) == 0 {
 		return 0
 	}
-	// Build TR series
+	return SimpleMovingAverage(TrueRanges(rows), n)
+}
+
+// TrueRanges returns each bar's True Range: the max of high-low, |high-prevClose| and |low-prevClose|.
+// The first bar has no previous close, so its own close is used.
+func TrueRanges(rows []model.Historical) []float64 {
 	trs := make([]float64, 0, len(rows))
 	for i := range rows {
 		cur := rows[i]
@@ -51,7 +109,7 @@ func AverageTrueRange(rows []model.Historical, n int) float64 {
 		}
 		trs = append(trs, tr)
 	}
-	return SimpleMovingAverage(trs, n)
+	return trs
 }
 
 func abs(v float64) float64 {
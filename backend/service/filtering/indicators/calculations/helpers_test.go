@@ -0,0 +1,17 @@
+package calculations
+
+import (
+	"math"
+
+	"screener/backend/model"
+)
+
+// bar returns a daily bar with the given high, low and close
+func bar(high, low, close float64) model.Historical {
+	return model.Historical{High: high, Low: low, Close: close}
+}
+
+// approxEqual reports whether a and b agree to within 1e-9
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
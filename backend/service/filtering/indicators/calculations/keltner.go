@@ -0,0 +1,32 @@
+package calculations
+
+import (
+	"errors"
+	"screener/backend/model"
+)
+
+// KeltnerChannels computes the Keltner Channel as of the last bar:
+// middle = EMA(typical price, emaLookback), where typical price = (high+low+close)/3,
+// upper/lower = middle ± mult × ATR(atrLookback).
+// Returns ErrInsufficientData if there are fewer bars than either lookback.
+func KeltnerChannels(rows []model.Historical, emaLookback, atrLookback int, mult float64) (middle, upper, lower float64, err error) {
+	if mult <= 0 {
+		return 0, 0, 0, errors.New("multiplier must be positive")
+	}
+
+	typical := make([]float64, 0, len(rows))
+	for _, r := range rows {
+		typical = append(typical, (r.High+r.Low+r.Close)/3)
+	}
+
+	middle, err = ExponentialMovingAverage(typical, emaLookback)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	atr, err := AverageTrueRangeStrict(rows, atrLookback)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return middle, middle + mult*atr, middle - mult*atr, nil
+}
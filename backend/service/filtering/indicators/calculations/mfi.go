@@ -0,0 +1,46 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+)
+
+// MoneyFlowIndex computes the Money Flow Index (a volume-weighted RSI) over the last lookback periods.
+// Raw money flow is typical price × volume; it counts as positive when typical price rises from the
+// prior bar and negative when it falls (unchanged bars are ignored).
+//
+//	MFI = 100 - 100 / (1 + positive flow / negative flow)
+//
+// With no negative flow MFI is 100 (or 50 if there was no flow at all).
+// Returns ErrInsufficientData if there are fewer than lookback+1 bars.
+func MoneyFlowIndex(rows []model.Historical, lookback int) (float64, error) {
+	if lookback <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(rows) < lookback+1 {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, lookback+1, len(rows))
+	}
+
+	window := rows[len(rows)-lookback-1:]
+	var positive, negative float64
+	prevTP := (window[0].High + window[0].Low + window[0].Close) / 3
+	for _, r := range window[1:] {
+		tp := (r.High + r.Low + r.Close) / 3
+		flow := tp * float64(r.Volume)
+		if tp > prevTP {
+			positive += flow
+		} else if tp < prevTP {
+			negative += flow
+		}
+		prevTP = tp
+	}
+
+	if negative == 0 {
+		if positive == 0 {
+			return 50, nil
+		}
+		return 100, nil
+	}
+	return 100 - 100/(1+positive/negative), nil
+}
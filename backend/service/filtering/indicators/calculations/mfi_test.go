@@ -0,0 +1,67 @@
+package calculations
+
+import (
+	"errors"
+	"testing"
+
+	"screener/backend/model"
+)
+
+// flowBar returns a bar whose typical price is tp, with the given volume
+func flowBar(tp float64, volume int64) model.Historical {
+	return model.Historical{High: tp, Low: tp, Close: tp, Volume: volume}
+}
+
+func TestMoneyFlowIndexConstructedSeries(t *testing.T) {
+	rows := []model.Historical{
+		flowBar(1, 1_000_000), // Outside the window: ignored
+		flowBar(10, 100),      // Window base
+		flowBar(11, 100),      // Up: +1100
+		flowBar(11, 500),      // Unchanged: ignored
+		flowBar(10.5, 200),    // Down: -2100
+		flowBar(12, 100),      // Up: +1200
+	}
+
+	got, err := MoneyFlowIndex(rows, 4)
+	if err != nil {
+		t.Fatalf("MoneyFlowIndex returned error: %v", err)
+	}
+	// 100 - 100 / (1 + 2300/2100)
+	if want := 100 * 2300.0 / 4400; !approxEqual(got, want) {
+		t.Errorf("MoneyFlowIndex = %v, want %v", got, want)
+	}
+}
+
+func TestMoneyFlowIndexEdgeCases(t *testing.T) {
+	tests := []struct {
+		name string
+		rows []model.Historical
+		want float64
+	}{
+		{"no negative flow", []model.Historical{flowBar(10, 100), flowBar(11, 100), flowBar(11, 100), flowBar(12, 100)}, 100},
+		{"no flow at all", []model.Historical{flowBar(10, 100), flowBar(10, 100), flowBar(10, 100), flowBar(10, 100)}, 50},
+		{"rising on zero volume", []model.Historical{flowBar(10, 0), flowBar(11, 0), flowBar(12, 0), flowBar(13, 0)}, 50},
+		{"no positive flow", []model.Historical{flowBar(13, 100), flowBar(12, 100), flowBar(11, 100), flowBar(10, 100)}, 0},
+	}
+	for _, tt := range tests {
+		got, err := MoneyFlowIndex(tt.rows, 3)
+		if err != nil {
+			t.Errorf("%s: MoneyFlowIndex returned error: %v", tt.name, err)
+			continue
+		}
+		if !approxEqual(got, tt.want) {
+			t.Errorf("%s: MoneyFlowIndex = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFlowIndexInsufficientBars(t *testing.T) {
+	// lookback+1 bars are needed for lookback price changes
+	rows := []model.Historical{flowBar(10, 100), flowBar(11, 100), flowBar(12, 100)}
+	if _, err := MoneyFlowIndex(rows, 3); !errors.Is(err, ErrInsufficientData) {
+		t.Errorf("MoneyFlowIndex with 3 bars returned %v, want ErrInsufficientData", err)
+	}
+	if _, err := MoneyFlowIndex(rows, 0); err == nil || errors.Is(err, ErrInsufficientData) {
+		t.Errorf("MoneyFlowIndex with lookback 0 returned %v, want a non-positive lookback error", err)
+	}
+}
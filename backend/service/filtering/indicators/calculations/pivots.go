@@ -0,0 +1,15 @@
+package calculations
+
+// PivotPoints computes classic (floor-trader) pivot levels from the previous session's bar:
+//
+//	pivot = (high + low + close) / 3
+//	r1 = 2*pivot - low,  s1 = 2*pivot - high
+//	r2 = pivot + (high - low),  s2 = pivot - (high - low)
+func PivotPoints(prevHigh, prevLow, prevClose float64) (pivot, r1, r2, s1, s2 float64) {
+	pivot = (prevHigh + prevLow + prevClose) / 3
+	r1 = 2*pivot - prevLow
+	s1 = 2*pivot - prevHigh
+	r2 = pivot + (prevHigh - prevLow)
+	s2 = pivot - (prevHigh - prevLow)
+	return pivot, r1, r2, s1, s2
+}
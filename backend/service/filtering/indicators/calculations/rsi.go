@@ -0,0 +1,103 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RelativeStrengthIndex computes Wilder's RSI as of the last close:
+// average gain and loss are seeded with the simple mean of the first lookback changes and then
+// smoothed as avg = (prevAvg*(lookback-1) + current) / lookback.
+//
+//	RSI = 100 - 100 / (1 + avgGain/avgLoss)
+//
+// With no losses RSI is 100 (or 50 if prices never moved).
+// Returns ErrInsufficientData if there are fewer than lookback+1 closes.
+func RelativeStrengthIndex(closes []float64, lookback int) (float64, error) {
+	if lookback <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(closes) < lookback+1 {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, lookback+1, len(closes))
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= lookback; i++ {
+		change := closes[i] - closes[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(lookback)
+	avgLoss /= float64(lookback)
+
+	for i := lookback + 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gain, loss := 0.0, 0.0
+		if change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(lookback-1) + gain) / float64(lookback)
+		avgLoss = (avgLoss*float64(lookback-1) + loss) / float64(lookback)
+	}
+
+	return rsiFromAverages(avgGain, avgLoss), nil
+}
+
+// RelativeStrengthIndexSeries returns RelativeStrengthIndex(closes[:i+1], lookback) for every i in one
+// pass. Entries before the first lookback+1 closes are NaN.
+func RelativeStrengthIndexSeries(closes []float64, lookback int) ([]float64, error) {
+	if lookback <= 0 {
+		return nil, errors.New("lookback must be positive")
+	}
+	series := make([]float64, len(closes))
+	for i := range series {
+		series[i] = math.NaN()
+	}
+	if len(closes) < lookback+1 {
+		return series, nil
+	}
+
+	var avgGain, avgLoss float64
+	for i := 1; i <= lookback; i++ {
+		change := closes[i] - closes[i-1]
+		if change > 0 {
+			avgGain += change
+		} else {
+			avgLoss -= change
+		}
+	}
+	avgGain /= float64(lookback)
+	avgLoss /= float64(lookback)
+	series[lookback] = rsiFromAverages(avgGain, avgLoss)
+
+	for i := lookback + 1; i < len(closes); i++ {
+		change := closes[i] - closes[i-1]
+		gain, loss := 0.0, 0.0
+		if change > 0 {
+			gain = change
+		} else {
+			loss = -change
+		}
+		avgGain = (avgGain*float64(lookback-1) + gain) / float64(lookback)
+		avgLoss = (avgLoss*float64(lookback-1) + loss) / float64(lookback)
+		series[i] = rsiFromAverages(avgGain, avgLoss)
+	}
+	return series, nil
+}
+
+// rsiFromAverages converts smoothed average gain and loss to RSI
+func rsiFromAverages(avgGain, avgLoss float64) float64 {
+	if avgLoss == 0 {
+		if avgGain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
@@ -0,0 +1,46 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+)
+
+// Stochastic computes the fast Stochastic oscillator as of the last bar, with %K unsmoothed:
+//
+//	%K = (close - lowest low over kLookback) / (highest high over kLookback - lowest low) * 100
+//	%D = SMA of the last dSmoothing %K values (the fast signal line)
+//
+// A flat window (highest high == lowest low) yields %K = 50.
+// Returns ErrInsufficientData if there are fewer than kLookback+dSmoothing-1 bars.
+func Stochastic(rows []model.Historical, kLookback, dSmoothing int) (percentK, percentD float64, err error) {
+	if kLookback <= 0 || dSmoothing <= 0 {
+		return 0, 0, errors.New("lookback and smoothing must be positive")
+	}
+	need := kLookback + dSmoothing - 1
+	if len(rows) < need {
+		return 0, 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, need, len(rows))
+	}
+
+	ks := make([]float64, 0, dSmoothing)
+	for end := len(rows) - dSmoothing + 1; end <= len(rows); end++ {
+		window := rows[end-kLookback : end]
+		highest, lowest := window[0].High, window[0].Low
+		for _, r := range window[1:] {
+			if r.High > highest {
+				highest = r.High
+			}
+			if r.Low < lowest {
+				lowest = r.Low
+			}
+		}
+
+		k := 50.0
+		if highest != lowest {
+			k = (window[len(window)-1].Close - lowest) / (highest - lowest) * 100
+		}
+		ks = append(ks, k)
+	}
+
+	return ks[len(ks)-1], SimpleMovingAverage(ks, dSmoothing), nil
+}
@@ -0,0 +1,58 @@
+package calculations
+
+import (
+	"errors"
+	"testing"
+
+	"screener/backend/model"
+)
+
+func TestStochasticKnownValues(t *testing.T) {
+	rows := []model.Historical{
+		bar(10, 8, 9),
+		bar(11, 9, 10),
+		bar(12, 10, 11), // Window 1-3: (11-8)/(12-8) = 75
+		bar(13, 11, 12), // Window 2-4: (12-9)/(13-9) = 75
+		bar(12, 9, 10),  // Window 3-5: (10-9)/(13-9) = 25
+	}
+
+	k, d, err := Stochastic(rows, 3, 3)
+	if err != nil {
+		t.Fatalf("Stochastic returned error: %v", err)
+	}
+	if !approxEqual(k, 25) {
+		t.Errorf("%%K = %v, want 25", k)
+	}
+	if want := (75.0 + 75 + 25) / 3; !approxEqual(d, want) {
+		t.Errorf("%%D = %v, want %v", d, want)
+	}
+}
+
+func TestStochasticFlatWindow(t *testing.T) {
+	rows := []model.Historical{bar(10, 10, 10), bar(10, 10, 10), bar(10, 10, 10), bar(10, 10, 10)}
+
+	k, d, err := Stochastic(rows, 3, 2)
+	if err != nil {
+		t.Fatalf("Stochastic returned error: %v", err)
+	}
+	if k != 50 || d != 50 {
+		t.Errorf("flat window %%K, %%D = %v, %v, want 50, 50", k, d)
+	}
+}
+
+func TestStochasticInsufficientBars(t *testing.T) {
+	rows := []model.Historical{bar(10, 8, 9), bar(11, 9, 10), bar(12, 10, 11), bar(13, 11, 12)}
+
+	// kLookback+dSmoothing-1 = 5 bars are needed
+	if _, _, err := Stochastic(rows, 3, 3); !errors.Is(err, ErrInsufficientData) {
+		t.Errorf("Stochastic with 4 bars returned %v, want ErrInsufficientData", err)
+	}
+	if _, _, err := Stochastic(rows, 3, 2); err != nil {
+		t.Errorf("Stochastic with exactly 4 bars returned %v, want nil", err)
+	}
+	for _, lookbacks := range [][2]int{{0, 3}, {3, 0}, {-1, 3}} {
+		if _, _, err := Stochastic(rows, lookbacks[0], lookbacks[1]); err == nil || errors.Is(err, ErrInsufficientData) {
+			t.Errorf("Stochastic(%d, %d) returned %v, want a non-positive lookback error", lookbacks[0], lookbacks[1], err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package calculations
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+)
+
+// WilliamsPercentR computes Williams %R as of the last bar:
+//
+//	%R = (highest high - close) / (highest high - lowest low) * -100
+//
+// over the last lookback bars, ranging from -100 (close at the low) to 0 (close at the high).
+// A flat window yields -50. Returns ErrInsufficientData if there are fewer than lookback bars.
+func WilliamsPercentR(rows []model.Historical, lookback int) (float64, error) {
+	if lookback <= 0 {
+		return 0, errors.New("lookback must be positive")
+	}
+	if len(rows) < lookback {
+		return 0, fmt.Errorf("%w: need %d bars, have %d", ErrInsufficientData, lookback, len(rows))
+	}
+
+	window := rows[len(rows)-lookback:]
+	highest, lowest := window[0].High, window[0].Low
+	for _, r := range window[1:] {
+		if r.High > highest {
+			highest = r.High
+		}
+		if r.Low < lowest {
+			lowest = r.Low
+		}
+	}
+
+	if highest == lowest {
+		return -50, nil
+	}
+	return (highest - window[len(window)-1].Close) / (highest - lowest) * -100, nil
+}
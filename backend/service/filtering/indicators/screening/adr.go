@@ -2,10 +2,8 @@ package screening
 
 import (
 	"errors"
-	"fmt"
 	"screener/backend/database"
 	"screener/backend/model"
-	"screener/backend/service/filtering/indicators/calculations"
 
 	"gorm.io/gorm"
 )
@@ -22,96 +20,131 @@ func NewADRScreeningService() *ADRScreeningService {
 	}
 }
 
-// GetSymbolsByADR scans all symbols with the given range/interval and returns those
-// whose ADR% (Average Daily Range as percentage) falls within the specified thresholds.
-// ADR% = SMA(high-low, lookback) / close * 100
-func (s *ADRScreeningService) GetSymbolsByADR(rangeParam, interval string, lookback int, minADR, maxADR *float64) ([]string, error) {
-	if rangeParam == "" || interval == "" || lookback <= 0 {
-		return nil, errors.New("range, interval, and lookback (positive) are required")
-	}
+// adrIndicator computes ADR% = SMA(high-low, lookback) / close * 100
+type adrIndicator struct{}
 
-	// Collect distinct symbols that have records for this range/interval
-	var symbols []string
-	if err := s.db.Model(&model.Historical{}).
-		Where("range = ? AND interval = ?", rangeParam, interval).
-		Distinct("symbol").
-		Pluck("symbol", &symbols).Error; err != nil {
-		return nil, fmt.Errorf("failed to load symbols: %w", err)
-	}
-	if len(symbols) == 0 {
-		return []string{}, nil
-	}
+func (adrIndicator) Name() string { return "adr" }
 
-	matches := make([]string, 0)
-	for _, sym := range symbols {
-		// Fetch historical data for this symbol
-		var rows []model.Historical
-		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
-			Order("epoch ASC").
-			Find(&rows).Error; err != nil {
-			continue
-		}
-		if len(rows) == 0 {
-			continue
-		}
-
-		// Calculate ADR%: SMA of (high-low) over lookback period, then divide by last close
-		rngSeries := make([]float64, 0, len(rows))
-		for _, r := range rows {
-			rngSeries = append(rngSeries, r.High-r.Low)
-		}
-		adr := calculations.SimpleMovingAverage(rngSeries, lookback)
-		last := rows[len(rows)-1]
-		if last.Close == 0 {
-			continue // skip if no valid close price
-		}
-		adrPercent := (adr / last.Close) * 100.0
-
-		// Apply filters if provided
-		matchesThreshold := true
-		if minADR != nil && adrPercent < *minADR {
-			matchesThreshold = false
-		}
-		if maxADR != nil && adrPercent > *maxADR {
-			matchesThreshold = false
-		}
-		if matchesThreshold {
-			matches = append(matches, sym)
-		}
+func (adrIndicator) DefaultLookback() int { return Lookbacks().ADR }
+
+func (adrIndicator) Compute(rows []model.Historical, params IndicatorParams) (float64, error) {
+	adr, err := averageDailyRange(rows, params)
+	if err != nil {
+		return 0, err
 	}
+	return adrPercent(rows, adr)
+}
 
-	return matches, nil
+func (adrIndicator) Series(rows []model.Historical, params IndicatorParams) []float64 {
+	return percentOfClose(dailyRangeAverages(rows, params), rows)
 }
 
-// GetADRForSymbol calculates and returns ADR% for a specific symbol.
-// ADR% = SMA(high-low, lookback) / close * 100
-func (s *ADRScreeningService) GetADRForSymbol(symbol, rangeParam, interval string, lookback int) (float64, error) {
-	if symbol == "" || rangeParam == "" || interval == "" || lookback <= 0 {
-		return 0, errors.New("symbol, range, interval, and lookback (positive) are required")
-	}
+// adrDollarsIndicator computes ADR in absolute terms: SMA(high-low, lookback), not divided by close
+type adrDollarsIndicator struct{}
 
-	var rows []model.Historical
-	if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, rangeParam, interval).
-		Order("epoch ASC").
-		Find(&rows).Error; err != nil {
-		return 0, fmt.Errorf("failed to fetch historical data: %w", err)
+func (adrDollarsIndicator) Name() string { return "adr-dollars" }
+
+func (adrDollarsIndicator) DefaultLookback() int { return Lookbacks().ADR }
+
+func (adrDollarsIndicator) Compute(rows []model.Historical, params IndicatorParams) (float64, error) {
+	return averageDailyRange(rows, params)
+}
+
+func (adrDollarsIndicator) Series(rows []model.Historical, params IndicatorParams) []float64 {
+	return dailyRangeAverages(rows, params)
+}
+
+// dailyRangeAverages returns averageDailyRange as of every bar
+func dailyRangeAverages(rows []model.Historical, params IndicatorParams) []float64 {
+	rngSeries := make([]float64, 0, len(rows))
+	for _, r := range rows {
+		rngSeries = append(rngSeries, r.High-r.Low)
 	}
+	return trailingAverages(rngSeries, params.Lookback, params.Strict)
+}
+
+// averageDailyRange builds the (high-low) series and returns its SMA over the lookback period
+func averageDailyRange(rows []model.Historical, params IndicatorParams) (float64, error) {
 	if len(rows) == 0 {
 		return 0, errors.New("no historical data found for symbol")
 	}
 
-	// Calculate ADR%: SMA of (high-low) over lookback period, then divide by last close
 	rngSeries := make([]float64, 0, len(rows))
 	for _, r := range rows {
 		rngSeries = append(rngSeries, r.High-r.Low)
 	}
-	adr := calculations.SimpleMovingAverage(rngSeries, lookback)
+	return movingAverage(rngSeries, params.Lookback, params.Strict)
+}
+
+// adrPercent expresses an absolute ADR as a percentage of the last close
+func adrPercent(rows []model.Historical, adr float64) (float64, error) {
 	last := rows[len(rows)-1]
 	if last.Close == 0 {
 		return 0, errors.New("invalid close price (zero)")
 	}
-	adrPercent := (adr / last.Close) * 100.0
+	return (adr / last.Close) * 100.0, nil
+}
+
+// ADRValues holds a symbol's average daily range in dollars and as a percentage of the last close
+type ADRValues struct {
+	Dollars   float64 `json:"adr_dollars"`
+	Percent   float64 `json:"adr_percent"`
+	LastClose float64 `json:"last_close"`
+}
+
+// GetSymbolsByADR scans all symbols with the given range/interval and returns those
+// whose ADR% (Average Daily Range as percentage) falls within the specified thresholds.
+// ADR% = SMA(high-low, lookback) / close * 100
+// When strict, symbols with fewer bars than lookback are excluded rather than averaged over fewer points.
+// The liquidity filter drops penny stocks and thin names whose percentage ranges are untradeable.
+func (s *ADRScreeningService) GetSymbolsByADR(rangeParam, interval string, lookback int, minADR, maxADR *float64, strict bool, liquidity LiquidityFilter) ([]string, error) {
+	results, err := s.ScreenADR(rangeParam, interval, lookback, minADR, maxADR, strict, liquidity, ResultOrder{})
+	if err != nil {
+		return nil, err
+	}
+	return resultSymbols(results), nil
+}
 
-	return adrPercent, nil
+// ScreenADR is GetSymbolsByADR returning each matching symbol's ADR% alongside it, in the given order
+func (s *ADRScreeningService) ScreenADR(rangeParam, interval string, lookback int, minADR, maxADR *float64, strict bool, liquidity LiquidityFilter, order ResultOrder) ([]IndicatorResult, error) {
+	indicators := &IndicatorService{db: s.db}
+	return indicators.Screen("adr", rangeParam, interval, IndicatorParams{Lookback: lookback, Strict: strict, Liquidity: liquidity, Order: order}, minADR, maxADR)
 }
 
+// GetADRForSymbol calculates and returns ADR% for a specific symbol.
+// ADR% = SMA(high-low, lookback) / close * 100
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *ADRScreeningService) GetADRForSymbol(symbol, rangeParam, interval string, lookback int, strict bool) (float64, error) {
+	indicators := &IndicatorService{db: s.db}
+	return indicators.ComputeForSymbol("adr", symbol, rangeParam, interval, IndicatorParams{Lookback: lookback, Strict: strict})
+}
+
+// GetADRDollarsForSymbol calculates a symbol's absolute ADR, SMA(high-low, lookback), with the
+// matching ADR% alongside, for sizing stops in dollars.
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *ADRScreeningService) GetADRDollarsForSymbol(symbol, rangeParam, interval string, lookback int, strict bool) (*ADRValues, error) {
+	if symbol == "" || rangeParam == "" || interval == "" || lookback <= 0 {
+		return nil, errors.New("symbol, range, interval, and lookback (positive) are required")
+	}
+
+	indicators := &IndicatorService{db: s.db}
+	rows, err := indicators.symbolRows(symbol, rangeParam, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	adr, err := averageDailyRange(rows, IndicatorParams{Lookback: lookback, Strict: strict})
+	if err != nil {
+		return nil, err
+	}
+	percent, err := adrPercent(rows, adr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ADRValues{
+		Dollars:   adr,
+		Percent:   percent,
+		LastClose: rows[len(rows)-1].Close,
+	}, nil
+}
@@ -22,90 +22,115 @@ func NewATRScreeningService() *ATRScreeningService {
 	}
 }
 
-// GetSymbolsByATR scans all symbols with the given range/interval and returns those
-// whose ATR% (Average True Range as percentage) falls within the specified thresholds.
-// ATR% = ATR(lookback) / close * 100
+// atrIndicator computes ATR% = ATR(lookback) / close * 100
 // ATR is calculated as SMA of True Range (max of: high-low, |high-prevClose|, |low-prevClose|)
-func (s *ATRScreeningService) GetSymbolsByATR(rangeParam, interval string, lookback int, minATR, maxATR *float64) ([]string, error) {
-	if rangeParam == "" || interval == "" || lookback <= 0 {
-		return nil, errors.New("range, interval, and lookback (positive) are required")
+type atrIndicator struct{}
+
+func (atrIndicator) Name() string { return "atr" }
+
+func (atrIndicator) DefaultLookback() int { return Lookbacks().ATR }
+
+func (atrIndicator) Compute(rows []model.Historical, params IndicatorParams) (float64, error) {
+	if len(rows) == 0 {
+		return 0, errors.New("no historical data found for symbol")
 	}
 
-	// Collect distinct symbols that have records for this range/interval
-	var symbols []string
-	if err := s.db.Model(&model.Historical{}).
-		Where("range = ? AND interval = ?", rangeParam, interval).
-		Distinct("symbol").
-		Pluck("symbol", &symbols).Error; err != nil {
-		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	// ATR over lookback period, then divide by last close
+	atr, err := averageTrueRange(rows, params.Lookback, params.Strict)
+	if err != nil {
+		return 0, err
 	}
-	if len(symbols) == 0 {
-		return []string{}, nil
+	last := rows[len(rows)-1]
+	if last.Close == 0 {
+		return 0, errors.New("invalid close price (zero)")
 	}
+	return (atr / last.Close) * 100.0, nil
+}
 
-	matches := make([]string, 0)
-	for _, sym := range symbols {
-		// Fetch historical data for this symbol
-		var rows []model.Historical
-		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
-			Order("epoch ASC").
-			Find(&rows).Error; err != nil {
-			continue
-		}
-		if len(rows) == 0 {
-			continue
-		}
-
-		// Calculate ATR%: ATR over lookback period, then divide by last close
-		atr := calculations.AverageTrueRange(rows, lookback)
-		last := rows[len(rows)-1]
-		if last.Close == 0 {
-			continue // skip if no valid close price
-		}
-		atrPercent := (atr / last.Close) * 100.0
-
-		// Apply filters if provided
-		matchesThreshold := true
-		if minATR != nil && atrPercent < *minATR {
-			matchesThreshold = false
-		}
-		if maxATR != nil && atrPercent > *maxATR {
-			matchesThreshold = false
-		}
-		if matchesThreshold {
-			matches = append(matches, sym)
-		}
+func (atrIndicator) Series(rows []model.Historical, params IndicatorParams) []float64 {
+	// A bar's true range only depends on it and the previous close, so the TRs of every prefix
+	// are a prefix of the full TR series
+	averages := trailingAverages(calculations.TrueRanges(rows), params.Lookback, params.Strict)
+	return percentOfClose(averages, rows)
+}
+
+// GetSymbolsByATR scans all symbols with the given range/interval and returns those
+// whose ATR% (Average True Range as percentage) falls within the specified thresholds.
+// ATR% = ATR(lookback) / close * 100
+// When strict, symbols with fewer bars than lookback are excluded rather than averaged over fewer points.
+func (s *ATRScreeningService) GetSymbolsByATR(rangeParam, interval string, lookback int, minATR, maxATR *float64, strict bool, liquidity LiquidityFilter) ([]string, error) {
+	results, err := s.ScreenATR(rangeParam, interval, lookback, minATR, maxATR, strict, liquidity, ResultOrder{})
+	if err != nil {
+		return nil, err
 	}
+	return resultSymbols(results), nil
+}
 
-	return matches, nil
+// ScreenATR is GetSymbolsByATR returning each matching symbol's ATR% alongside it, in the given order
+func (s *ATRScreeningService) ScreenATR(rangeParam, interval string, lookback int, minATR, maxATR *float64, strict bool, liquidity LiquidityFilter, order ResultOrder) ([]IndicatorResult, error) {
+	indicators := &IndicatorService{db: s.db}
+	return indicators.Screen("atr", rangeParam, interval, IndicatorParams{Lookback: lookback, Strict: strict, Liquidity: liquidity, Order: order}, minATR, maxATR)
 }
 
 // GetATRForSymbol calculates and returns ATR% for a specific symbol.
 // ATR% = ATR(lookback) / close * 100
-// ATR is calculated as SMA of True Range (max of: high-low, |high-prevClose|, |low-prevClose|)
-func (s *ATRScreeningService) GetATRForSymbol(symbol, rangeParam, interval string, lookback int) (float64, error) {
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *ATRScreeningService) GetATRForSymbol(symbol, rangeParam, interval string, lookback int, strict bool) (float64, error) {
+	indicators := &IndicatorService{db: s.db}
+	return indicators.ComputeForSymbol("atr", symbol, rangeParam, interval, IndicatorParams{Lookback: lookback, Strict: strict})
+}
+
+// ATRStop holds a suggested ATR trailing stop for a symbol
+type ATRStop struct {
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"`
+	Close      float64 `json:"close"`
+	ATR        float64 `json:"atr"`
+	Multiplier float64 `json:"multiplier"`
+	Stop       float64 `json:"stop"`
+}
+
+// GetATRStopForSymbol calculates a trailing stop at multiplier*ATR(lookback) from the last close.
+// Longs: stop = close - multiplier*ATR; shorts: stop = close + multiplier*ATR.
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *ATRScreeningService) GetATRStopForSymbol(symbol, rangeParam, interval string, lookback int, multiplier float64, side string, strict bool) (*ATRStop, error) {
 	if symbol == "" || rangeParam == "" || interval == "" || lookback <= 0 {
-		return 0, errors.New("symbol, range, interval, and lookback (positive) are required")
+		return nil, errors.New("symbol, range, interval, and lookback (positive) are required")
+	}
+	if multiplier <= 0 {
+		return nil, errors.New("multiplier must be positive")
+	}
+	if side != "long" && side != "short" {
+		return nil, errors.New("side must be long or short")
 	}
 
 	var rows []model.Historical
 	if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, rangeParam, interval).
 		Order("epoch ASC").
 		Find(&rows).Error; err != nil {
-		return 0, fmt.Errorf("failed to fetch historical data: %w", err)
+		return nil, fmt.Errorf("failed to fetch historical data: %w", err)
 	}
 	if len(rows) == 0 {
-		return 0, errors.New("no historical data found for symbol")
+		return nil, errors.New("no historical data found for symbol")
 	}
 
-	// Calculate ATR%: ATR over lookback period, then divide by last close
-	atr := calculations.AverageTrueRange(rows, lookback)
+	atr, err := averageTrueRange(rows, lookback, strict)
+	if err != nil {
+		return nil, err
+	}
 	last := rows[len(rows)-1]
-	if last.Close == 0 {
-		return 0, errors.New("invalid close price (zero)")
+
+	stop := last.Close - multiplier*atr
+	if side == "short" {
+		stop = last.Close + multiplier*atr
 	}
-	atrPercent := (atr / last.Close) * 100.0
 
-	return atrPercent, nil
+	return &ATRStop{
+		Symbol:     symbol,
+		Side:       side,
+		Close:      last.Close,
+		ATR:        atr,
+		Multiplier: multiplier,
+		Stop:       stop,
+	}, nil
 }
-
@@ -0,0 +1,157 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"screener/backend/model"
+	"strings"
+)
+
+// MaxBacktestSymbols caps the number of symbols evaluated in one backtest
+const MaxBacktestSymbols = 50
+
+// BacktestRequest describes a threshold entry rule evaluated over historical bars.
+// A signal fires on every bar where the indicator is above (or below) the threshold;
+// the trade enters at that bar's close and exits HoldingPeriod bars later at the close.
+type BacktestRequest struct {
+	Symbols       []string `json:"symbols"`
+	Range         string   `json:"range"`
+	Interval      string   `json:"interval"`
+	Indicator     string   `json:"indicator"`
+	Lookback      int      `json:"lookback"`
+	Condition     string   `json:"condition"` // "above" or "below"
+	Threshold     float64  `json:"threshold"`
+	HoldingPeriod int      `json:"holding_period"`
+}
+
+// BacktestStats summarizes forward returns for a set of signals
+type BacktestStats struct {
+	Signals     int     `json:"signals"`
+	Wins        int     `json:"wins"`
+	WinRate     float64 `json:"win_rate"`   // percent of signals with a positive forward return
+	AvgReturn   float64 `json:"avg_return"` // mean forward return in percent
+	BestReturn  float64 `json:"best_return"`
+	WorstReturn float64 `json:"worst_return"`
+}
+
+// BacktestSymbolResult holds backtest stats for one symbol
+type BacktestSymbolResult struct {
+	Symbol string `json:"symbol"`
+	BacktestStats
+}
+
+// BacktestResult holds per-symbol and combined backtest stats
+type BacktestResult struct {
+	Summary BacktestStats          `json:"summary"`
+	Symbols []BacktestSymbolResult `json:"symbols"`
+	Skipped map[string]string      `json:"skipped,omitempty"`
+}
+
+// backtestAccumulator collects forward returns into BacktestStats
+type backtestAccumulator struct {
+	stats BacktestStats
+	total float64
+}
+
+func (a *backtestAccumulator) add(ret float64) {
+	if a.stats.Signals == 0 || ret > a.stats.BestReturn {
+		a.stats.BestReturn = ret
+	}
+	if a.stats.Signals == 0 || ret < a.stats.WorstReturn {
+		a.stats.WorstReturn = ret
+	}
+	a.stats.Signals++
+	if ret > 0 {
+		a.stats.Wins++
+	}
+	a.total += ret
+}
+
+func (a *backtestAccumulator) result() BacktestStats {
+	stats := a.stats
+	if stats.Signals > 0 {
+		stats.WinRate = float64(stats.Wins) / float64(stats.Signals) * 100
+		stats.AvgReturn = a.total / float64(stats.Signals)
+	}
+	return stats
+}
+
+// Backtest evaluates a threshold entry rule for a registered indicator over each symbol's history.
+// The indicator's value at each bar uses only the bars up to and including it, so signals never
+// see future data; indicators implementing SeriesIndicator compute all of them in one pass.
+// Symbols without data are reported in Skipped.
+func (s *IndicatorService) Backtest(req BacktestRequest) (*BacktestResult, error) {
+	indicator, err := GetIndicator(req.Indicator)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Symbols) == 0 {
+		return nil, errors.New("at least one symbol is required")
+	}
+	if len(req.Symbols) > MaxBacktestSymbols {
+		return nil, fmt.Errorf("too many symbols: maximum is %d", MaxBacktestSymbols)
+	}
+	if req.Range == "" || req.Interval == "" {
+		return nil, errors.New("range and interval are required")
+	}
+	if req.Condition != "above" && req.Condition != "below" {
+		return nil, errors.New("condition must be above or below")
+	}
+	if req.HoldingPeriod <= 0 {
+		return nil, errors.New("holding_period must be a positive integer")
+	}
+	if req.Lookback <= 0 {
+		req.Lookback = indicator.DefaultLookback()
+	}
+	params := IndicatorParams{Lookback: req.Lookback, Strict: true}
+
+	result := &BacktestResult{
+		Symbols: make([]BacktestSymbolResult, 0, len(req.Symbols)),
+		Skipped: make(map[string]string),
+	}
+	var combined backtestAccumulator
+
+	for _, symbol := range req.Symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, req.Range, req.Interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch historical data: %w", err)
+		}
+		if len(rows) <= req.HoldingPeriod {
+			result.Skipped[symbol] = "not enough historical data"
+			continue
+		}
+
+		values := indicatorSeries(indicator, rows, params)
+		var acc backtestAccumulator
+		for i := 0; i+req.HoldingPeriod < len(rows); i++ {
+			value := values[i]
+			if math.IsNaN(value) {
+				continue // still warming up
+			}
+			if (req.Condition == "above" && value <= req.Threshold) || (req.Condition == "below" && value >= req.Threshold) {
+				continue
+			}
+
+			entry := rows[i].Close
+			if entry == 0 {
+				continue
+			}
+			ret := (rows[i+req.HoldingPeriod].Close - entry) / entry * 100
+			acc.add(ret)
+			combined.add(ret)
+		}
+
+		result.Symbols = append(result.Symbols, BacktestSymbolResult{
+			Symbol:        symbol,
+			BacktestStats: acc.result(),
+		})
+	}
+
+	result.Summary = combined.result()
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package screening
+
+import (
+	"math"
+	"testing"
+
+	"screener/backend/model"
+)
+
+// backtestBars returns n synthetic daily bars, oldest first, with a flat stretch and a zero close
+func backtestBars(n int) []model.Historical {
+	rows := make([]model.Historical, n)
+	for i := range rows {
+		close := 100 + 10*math.Sin(float64(i)/7)
+		if i >= 20 && i < 25 {
+			close = 100
+		}
+		rows[i] = model.Historical{
+			Symbol: "AAPL",
+			Epoch:  1700000000 + int64(i)*86400,
+			Open:   close - 0.5,
+			High:   close + 1 + float64(i%7)/10,
+			Low:    close - 1 - float64(i%5)/10,
+			Close:  close,
+			Volume: 1_000_000,
+		}
+	}
+	rows[40].Close = 0
+	return rows
+}
+
+func TestSeriesMatchesComputeOverEveryPrefix(t *testing.T) {
+	rows := backtestBars(120)
+	for _, name := range IndicatorNames() {
+		indicator, err := GetIndicator(name)
+		if err != nil {
+			t.Fatalf("GetIndicator(%q) returned error: %v", name, err)
+		}
+		if _, ok := indicator.(SeriesIndicator); !ok {
+			t.Errorf("%s doesn't implement SeriesIndicator, so backtests recompute it over every prefix", name)
+			continue
+		}
+
+		for _, params := range []IndicatorParams{
+			{Lookback: indicator.DefaultLookback(), Strict: true},
+			{Lookback: indicator.DefaultLookback()},
+			{Lookback: 5, Strict: true},
+			{Lookback: 0, Strict: true},
+		} {
+			series := indicatorSeries(indicator, rows, params)
+			if len(series) != len(rows) {
+				t.Fatalf("%s %+v: series has %d values for %d bars", name, params, len(series), len(rows))
+			}
+			for i := range rows {
+				want, err := indicator.Compute(rows[:i+1], params)
+				if err != nil {
+					want = math.NaN()
+				}
+				if got := series[i]; got != want && !(math.IsNaN(got) && math.IsNaN(want)) {
+					t.Errorf("%s %+v: series[%d] = %v, Compute over the prefix = %v", name, params, i, got, want)
+				}
+			}
+		}
+	}
+}
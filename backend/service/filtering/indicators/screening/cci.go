@@ -0,0 +1,78 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// CCIResult holds a symbol's Commodity Channel Index value
+type CCIResult struct {
+	Symbol string  `json:"symbol"`
+	CCI    float64 `json:"cci"`
+}
+
+// CCIScreeningService handles Commodity Channel Index screening logic
+type CCIScreeningService struct {
+	db *gorm.DB
+}
+
+// NewCCIScreeningService creates a new instance of CCIScreeningService
+func NewCCIScreeningService() *CCIScreeningService {
+	return &CCIScreeningService{
+		db: database.GetDB(),
+	}
+}
+
+// GetSymbolsByCCI scans all symbols with the given range/interval and returns those whose
+// CCI(lookback) falls within the specified thresholds. Symbols with fewer bars than lookback are skipped;
+// order sorts matches by CCI and applies its limit.
+func (s *CCIScreeningService) GetSymbolsByCCI(rangeParam, interval string, lookback int, minCCI, maxCCI *float64, liquidity LiquidityFilter, order ResultOrder) ([]CCIResult, error) {
+	if rangeParam == "" || interval == "" || lookback <= 0 {
+		return nil, errors.New("range, interval, and lookback (positive) are required")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []CCIResult{}, nil
+	}
+
+	matches := make([]CCIResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
+
+		cci, err := calculations.CCI(rows, lookback)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
+
+		if minCCI != nil && cci < *minCCI {
+			continue
+		}
+		if maxCCI != nil && cci > *maxCCI {
+			continue
+		}
+		matches = append(matches, CCIResult{Symbol: sym, CCI: cci})
+	}
+
+	return applyOrder(order, matches, func(r CCIResult) (string, float64) { return r.Symbol, r.CCI }), nil
+}
@@ -0,0 +1,51 @@
+package screening
+
+import (
+	"math"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+)
+
+// movingAverage returns the trailing SMA over the last n values.
+// When strict, series shorter than n return calculations.ErrInsufficientData;
+// otherwise the available points are averaged (legacy behavior).
+func movingAverage(series []float64, n int, strict bool) (float64, error) {
+	if strict {
+		return calculations.SimpleMovingAverageStrict(series, n)
+	}
+	return calculations.SimpleMovingAverage(series, n), nil
+}
+
+// trailingAverages returns movingAverage(series[:i+1], n, strict) for every i, NaN where it errors.
+// Each average only sums its last n points, so the series costs O(len(series) * n).
+func trailingAverages(series []float64, n int, strict bool) []float64 {
+	averages := make([]float64, len(series))
+	for i := range series {
+		avg, err := movingAverage(series[:i+1], n, strict)
+		if err != nil {
+			avg = math.NaN()
+		}
+		averages[i] = avg
+	}
+	return averages
+}
+
+// percentOfClose divides each value by its bar's close and scales to percent; NaN on a zero close
+func percentOfClose(values []float64, rows []model.Historical) []float64 {
+	for i, v := range values {
+		if rows[i].Close == 0 {
+			values[i] = math.NaN()
+			continue
+		}
+		values[i] = (v / rows[i].Close) * 100.0
+	}
+	return values
+}
+
+// averageTrueRange returns the ATR over the last n bars with the same strictness rules as movingAverage
+func averageTrueRange(rows []model.Historical, n int, strict bool) (float64, error) {
+	if strict {
+		return calculations.AverageTrueRangeStrict(rows, n)
+	}
+	return calculations.AverageTrueRange(rows, n), nil
+}
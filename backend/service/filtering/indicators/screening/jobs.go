@@ -0,0 +1,255 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"screener/backend/service/caching"
+
+	"github.com/google/uuid"
+)
+
+// Screen job statuses
+const (
+	ScreenJobQueued    = "queued"
+	ScreenJobRunning   = "running"
+	ScreenJobCompleted = "completed"
+	ScreenJobFailed    = "failed"
+)
+
+// ErrScreenQueueFull is returned when the screen job queue can't accept more work
+var ErrScreenQueueFull = errors.New("screen job queue is full")
+
+// ScreenJobRequest describes a registry-backed indicator screen to run in the background
+type ScreenJobRequest struct {
+	Indicator string          `json:"indicator"`
+	Range     string          `json:"range"`
+	Interval  string          `json:"interval"`
+	Lookback  int             `json:"lookback"`
+	Strict    bool            `json:"strict"`
+	Min       *float64        `json:"min"`
+	Max       *float64        `json:"max"`
+	Liquidity LiquidityFilter `json:"liquidity"`
+	Order     ResultOrder     `json:"order"`
+}
+
+// ScreenJob is a queued screen and, once completed, its results
+// Jobs are stored in Redis so any instance can report their status; Worker names the
+// instance whose in-memory queue holds the job
+type ScreenJob struct {
+	ID          string            `json:"id"`
+	Status      string            `json:"status"`
+	Worker      string            `json:"worker,omitempty"`
+	Request     ScreenJobRequest  `json:"request"`
+	Results     []IndicatorResult `json:"results,omitempty"`
+	Count       int               `json:"count"`
+	Error       string            `json:"error,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	StartedAt   *time.Time        `json:"started_at,omitempty"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+}
+
+// screenJobQueueSize bounds how many jobs can wait for a worker
+const screenJobQueueSize = 64
+
+// screenWorkerLease is how long an instance's worker key outlives its last renewal. The queue is
+// in memory, so once the key lapses the instance's queued and running jobs are gone.
+const screenWorkerLease = 30 * time.Second
+
+// errScreenJobLost is recorded on a job whose worker stopped before completing it
+var errScreenJobLost = errors.New("screen job was lost when its worker stopped; submit the screen again")
+
+var (
+	screenJobQueue     chan string
+	screenJobQueueOnce sync.Once
+
+	// screenWorkerID identifies this instance's workers on the jobs they own
+	screenWorkerID = uuid.NewString()
+)
+
+// startScreenWorkers lazily starts the screen job workers (SCREEN_JOB_WORKERS, default 2)
+// and the renewal of this instance's worker key
+func startScreenWorkers() {
+	screenJobQueueOnce.Do(func() {
+		workers := 2
+		if v, err := strconv.Atoi(os.Getenv("SCREEN_JOB_WORKERS")); err == nil && v > 0 {
+			workers = v
+		}
+
+		renewScreenWorkerLease()
+		go func() {
+			for range time.Tick(screenWorkerLease / 3) {
+				renewScreenWorkerLease()
+			}
+		}()
+
+		screenJobQueue = make(chan string, screenJobQueueSize)
+		for i := 0; i < workers; i++ {
+			go func() {
+				for jobID := range screenJobQueue {
+					runScreenJob(jobID)
+				}
+			}()
+		}
+	})
+}
+
+// renewScreenWorkerLease marks this instance's workers alive for another screenWorkerLease
+func renewScreenWorkerLease() {
+	if err := caching.NewCacheService().Set(caching.ScreenWorkerKey(screenWorkerID), []byte("1"), screenWorkerLease); err != nil {
+		log.Printf("Warning: failed to renew screen worker lease: %v", err)
+	}
+}
+
+// cacheParams returns the request as cache key parameters; identical screens share a key
+func (r ScreenJobRequest) cacheParams() map[string]string {
+	params := map[string]string{
+		"indicator": strings.ToLower(r.Indicator),
+		"range":     r.Range,
+		"interval":  r.Interval,
+		"lookback":  strconv.Itoa(r.Lookback),
+		"strict":    strconv.FormatBool(r.Strict),
+	}
+	if r.Min != nil {
+		params["min"] = strconv.FormatFloat(*r.Min, 'f', -1, 64)
+	}
+	if r.Max != nil {
+		params["max"] = strconv.FormatFloat(*r.Max, 'f', -1, 64)
+	}
+	if r.Liquidity.MinPrice != nil {
+		params["min_price"] = strconv.FormatFloat(*r.Liquidity.MinPrice, 'f', -1, 64)
+	}
+	if r.Liquidity.MinDollarVolume != nil {
+		params["min_dollar_volume"] = strconv.FormatFloat(*r.Liquidity.MinDollarVolume, 'f', -1, 64)
+	}
+	if r.Order.Sort != "" {
+		params["sort"] = r.Order.Sort
+	}
+	if r.Order.Limit > 0 {
+		params["limit"] = strconv.Itoa(r.Order.Limit)
+	}
+	return params
+}
+
+// EnqueueScreenJob queues a screen and returns its job. If an identical screen was queued within
+// CACHE_TTL_SCREEN_JOB and hasn't failed or been lost with its worker, that job is returned
+// instead, so repeated requests are served from its cached result once completed.
+func EnqueueScreenJob(req ScreenJobRequest) (*ScreenJob, error) {
+	if _, err := GetIndicator(req.Indicator); err != nil {
+		return nil, err
+	}
+	if req.Range == "" || req.Interval == "" || req.Lookback <= 0 {
+		return nil, errors.New("range, interval, and lookback (positive) are required")
+	}
+
+	cache := caching.NewCacheService()
+	ttl := caching.GetTTLConfig().ScreenJob
+	requestKey := caching.ScreenRequestKey(req.cacheParams())
+
+	if existingID, err := cache.Get(requestKey); err == nil && len(existingID) > 0 {
+		if job, err := GetScreenJob(string(existingID)); err == nil && job.Status != ScreenJobFailed {
+			return job, nil
+		}
+	}
+
+	startScreenWorkers()
+
+	job := &ScreenJob{
+		ID:        fmt.Sprintf("screen-%d", time.Now().UnixNano()),
+		Status:    ScreenJobQueued,
+		Worker:    screenWorkerID,
+		Request:   req,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := saveScreenJob(job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case screenJobQueue <- job.ID:
+	default:
+		job.Status = ScreenJobFailed
+		job.Error = ErrScreenQueueFull.Error()
+		_ = saveScreenJob(job)
+		return nil, ErrScreenQueueFull
+	}
+
+	_ = cache.Set(requestKey, []byte(job.ID), ttl)
+	return job, nil
+}
+
+// GetScreenJob loads a screen job by ID; returns "record not found" when it doesn't exist or expired.
+// A queued or running job whose worker's lease has lapsed is marked failed, since no worker will
+// ever finish it.
+func GetScreenJob(jobID string) (*ScreenJob, error) {
+	cache := caching.NewCacheService()
+	var job ScreenJob
+	found, err := cache.GetJSON(caching.ScreenJobKey(jobID), &job)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("record not found")
+	}
+
+	if job.Status == ScreenJobQueued || job.Status == ScreenJobRunning {
+		alive, err := cache.Exists(caching.ScreenWorkerKey(job.Worker))
+		if err != nil {
+			return nil, err
+		}
+		if !alive {
+			completedAt := time.Now().UTC()
+			job.Status = ScreenJobFailed
+			job.Error = errScreenJobLost.Error()
+			job.CompletedAt = &completedAt
+			if err := saveScreenJob(&job); err != nil {
+				log.Printf("[%s] Failed to mark lost screen job failed: %v", jobID, err)
+			}
+		}
+	}
+	return &job, nil
+}
+
+// saveScreenJob stores a job for CACHE_TTL_SCREEN_JOB
+func saveScreenJob(job *ScreenJob) error {
+	return caching.NewCacheService().SetJSON(caching.ScreenJobKey(job.ID), job, caching.GetTTLConfig().ScreenJob)
+}
+
+// runScreenJob computes a queued job's screen and records the outcome
+func runScreenJob(jobID string) {
+	job, err := GetScreenJob(jobID)
+	if err != nil {
+		log.Printf("[%s] Screen job disappeared before running: %v", jobID, err)
+		return
+	}
+
+	startedAt := time.Now().UTC()
+	job.Status = ScreenJobRunning
+	job.StartedAt = &startedAt
+	_ = saveScreenJob(job)
+
+	req := job.Request
+	params := IndicatorParams{Lookback: req.Lookback, Strict: req.Strict, Liquidity: req.Liquidity, Order: req.Order}
+	results, err := NewIndicatorService().Screen(req.Indicator, req.Range, req.Interval, params, req.Min, req.Max)
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	if err != nil {
+		job.Status = ScreenJobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = ScreenJobCompleted
+		job.Results = results
+		job.Count = len(results)
+	}
+
+	if err := saveScreenJob(job); err != nil {
+		log.Printf("[%s] Failed to store screen job result: %v", jobID, err)
+	}
+}
@@ -0,0 +1,58 @@
+package screening
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestGetScreenJobFailsJobsLostWithTheirWorker(t *testing.T) {
+	renewScreenWorkerLease()
+
+	tests := []struct {
+		name   string
+		status string
+		worker string
+		want   string
+	}{
+		{"queued on a live worker", ScreenJobQueued, screenWorkerID, ScreenJobQueued},
+		{"running on a live worker", ScreenJobRunning, screenWorkerID, ScreenJobRunning},
+		{"queued on a stopped worker", ScreenJobQueued, "stopped-worker", ScreenJobFailed},
+		{"running on a stopped worker", ScreenJobRunning, "stopped-worker", ScreenJobFailed},
+		{"queued before workers were tracked", ScreenJobQueued, "", ScreenJobFailed},
+		{"completed on a stopped worker", ScreenJobCompleted, "stopped-worker", ScreenJobCompleted},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &ScreenJob{
+				ID:        "screen-test-" + strconv.Itoa(i),
+				Status:    tt.status,
+				Worker:    tt.worker,
+				CreatedAt: time.Now().UTC(),
+			}
+			if err := saveScreenJob(job); err != nil {
+				t.Fatalf("saveScreenJob returned error: %v", err)
+			}
+
+			got, err := GetScreenJob(job.ID)
+			if err != nil {
+				t.Fatalf("GetScreenJob returned error: %v", err)
+			}
+			if got.Status != tt.want {
+				t.Errorf("Status = %q, want %q", got.Status, tt.want)
+			}
+			if lost := got.Error == errScreenJobLost.Error(); lost != (tt.want == ScreenJobFailed) {
+				t.Errorf("Error = %q, want the lost-job error only on failed jobs", got.Error)
+			}
+
+			// The failure is stored, so later lookups and identical screens don't wait on the job
+			stored, err := GetScreenJob(job.ID)
+			if err != nil {
+				t.Fatalf("second GetScreenJob returned error: %v", err)
+			}
+			if stored.Status != tt.want {
+				t.Errorf("stored Status = %q, want %q", stored.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// KeltnerResult holds a symbol's last close and Keltner Channel bounds
+type KeltnerResult struct {
+	Symbol string  `json:"symbol"`
+	Close  float64 `json:"close"`
+	Middle float64 `json:"middle"`
+	Upper  float64 `json:"upper"`
+	Lower  float64 `json:"lower"`
+}
+
+// KeltnerScreeningService handles Keltner Channel screening logic
+type KeltnerScreeningService struct {
+	db *gorm.DB
+}
+
+// NewKeltnerScreeningService creates a new instance of KeltnerScreeningService
+func NewKeltnerScreeningService() *KeltnerScreeningService {
+	return &KeltnerScreeningService{
+		db: database.GetDB(),
+	}
+}
+
+// GetSymbolsByKeltner scans all symbols with the given range/interval and returns those whose
+// last close is above the upper band (position "above") or below the lower band (position "below").
+// Symbols without enough bars to warm up both the EMA and ATR are skipped.
+func (s *KeltnerScreeningService) GetSymbolsByKeltner(rangeParam, interval string, emaLookback, atrLookback int, mult float64, position string, liquidity LiquidityFilter) ([]KeltnerResult, error) {
+	if rangeParam == "" || interval == "" || emaLookback <= 0 || atrLookback <= 0 {
+		return nil, errors.New("range, interval, ema_lookback and atr_lookback (positive) are required")
+	}
+	if position != "above" && position != "below" {
+		return nil, errors.New("position must be above or below")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []KeltnerResult{}, nil
+	}
+
+	matches := make([]KeltnerResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		middle, upper, lower, err := calculations.KeltnerChannels(rows, emaLookback, atrLookback, mult)
+		if err != nil {
+			continue // skip symbols with fewer bars than the warmup
+		}
+		last := rows[len(rows)-1]
+
+		if (position == "above" && last.Close > upper) || (position == "below" && last.Close < lower) {
+			matches = append(matches, KeltnerResult{
+				Symbol: sym,
+				Close:  last.Close,
+				Middle: middle,
+				Upper:  upper,
+				Lower:  lower,
+			})
+		}
+	}
+
+	return matches, nil
+}
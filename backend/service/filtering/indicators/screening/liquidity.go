@@ -0,0 +1,31 @@
+package screening
+
+import (
+	"screener/backend/model"
+)
+
+// LiquidityFilter excludes thin names from universe-wide screens using the latest bar.
+// Both guards are off (nil) by default.
+type LiquidityFilter struct {
+	MinPrice        *float64 `json:"min_price,omitempty"`         // Minimum latest close
+	MinDollarVolume *float64 `json:"min_dollar_volume,omitempty"` // Minimum latest close * volume
+}
+
+// Allows reports whether the latest bar of rows (oldest first) passes the filter
+func (f LiquidityFilter) Allows(rows []model.Historical) bool {
+	if f.MinPrice == nil && f.MinDollarVolume == nil {
+		return true
+	}
+	if len(rows) == 0 {
+		return false
+	}
+
+	last := rows[len(rows)-1]
+	if f.MinPrice != nil && last.Close < *f.MinPrice {
+		return false
+	}
+	if f.MinDollarVolume != nil && last.Close*float64(last.Volume) < *f.MinDollarVolume {
+		return false
+	}
+	return true
+}
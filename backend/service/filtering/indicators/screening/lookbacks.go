@@ -0,0 +1,117 @@
+package screening
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"screener/backend/service/filtering/indicators"
+)
+
+// MaxDefaultLookback bounds the DEFAULT_*_LOOKBACK settings
+const MaxDefaultLookback = 1000
+
+// LookbackDefaults holds the lookback each indicator uses when a request doesn't specify one
+type LookbackDefaults struct {
+	ADR         int // ADR and ADR in dollars
+	ATR         int // ATR, ATR screen and ATR stop
+	RSI         int
+	CCI         int
+	MFI         int
+	WilliamsR   int
+	Volume      int // Average volume in dollars / percent
+	KeltnerEMA  int
+	KeltnerATR  int
+	StochasticK int
+	StochasticD int // %D smoothing
+}
+
+var (
+	lookbackDefaultsMu sync.RWMutex
+	lookbackDefaults   = builtinLookbackDefaults()
+)
+
+// builtinLookbackDefaults returns the defaults used when no DEFAULT_*_LOOKBACK override is set
+func builtinLookbackDefaults() LookbackDefaults {
+	return LookbackDefaults{
+		ADR:         14,
+		ATR:         14,
+		RSI:         14,
+		CCI:         20,
+		MFI:         14,
+		WilliamsR:   14,
+		Volume:      50,
+		KeltnerEMA:  20,
+		KeltnerATR:  10,
+		StochasticK: 14,
+		StochasticD: 3,
+	}
+}
+
+// LoadLookbackDefaults reads DEFAULT_*_LOOKBACK overrides from the environment and makes them
+// the active defaults. It is called at startup; any invalid value is an error and nothing is applied.
+func LoadLookbackDefaults() (LookbackDefaults, error) {
+	defaults := builtinLookbackDefaults()
+	settings := []struct {
+		name  string
+		value *int
+	}{
+		{"DEFAULT_ADR_LOOKBACK", &defaults.ADR},
+		{"DEFAULT_ATR_LOOKBACK", &defaults.ATR},
+		{"DEFAULT_RSI_LOOKBACK", &defaults.RSI},
+		{"DEFAULT_CCI_LOOKBACK", &defaults.CCI},
+		{"DEFAULT_MFI_LOOKBACK", &defaults.MFI},
+		{"DEFAULT_WILLIAMS_R_LOOKBACK", &defaults.WilliamsR},
+		{"DEFAULT_VOLUME_LOOKBACK", &defaults.Volume},
+		{"DEFAULT_KELTNER_EMA_LOOKBACK", &defaults.KeltnerEMA},
+		{"DEFAULT_KELTNER_ATR_LOOKBACK", &defaults.KeltnerATR},
+		{"DEFAULT_STOCHASTIC_K_LOOKBACK", &defaults.StochasticK},
+		{"DEFAULT_STOCHASTIC_D_SMOOTHING", &defaults.StochasticD},
+	}
+
+	invalid := make([]string, 0)
+	for _, setting := range settings {
+		raw := strings.TrimSpace(os.Getenv(setting.name))
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > MaxDefaultLookback {
+			invalid = append(invalid, fmt.Sprintf("%s=%q", setting.name, raw))
+			continue
+		}
+		*setting.value = value
+	}
+	if len(invalid) > 0 {
+		return LookbackDefaults{}, fmt.Errorf("invalid default lookback (expected an integer between 1 and %d): %s",
+			MaxDefaultLookback, strings.Join(invalid, ", "))
+	}
+	if defaults.WilliamsR > MaxWilliamsRLookback {
+		return LookbackDefaults{}, fmt.Errorf("invalid default lookback: DEFAULT_WILLIAMS_R_LOOKBACK exceeds %d", MaxWilliamsRLookback)
+	}
+
+	lookbackDefaultsMu.Lock()
+	lookbackDefaults = defaults
+	lookbackDefaultsMu.Unlock()
+	return defaults, nil
+}
+
+// Lookbacks returns the active default lookbacks
+func Lookbacks() LookbackDefaults {
+	lookbackDefaultsMu.RLock()
+	defer lookbackDefaultsMu.RUnlock()
+	return lookbackDefaults
+}
+
+// SnapshotLookbacks returns the lookbacks for indicator snapshots (/indicators) seeded from the
+// active defaults. The moving average has no DEFAULT_*_LOOKBACK and keeps its built-in window.
+func SnapshotLookbacks() indicators.IndicatorLookbacks {
+	defaults := Lookbacks()
+	lookbacks := indicators.DefaultIndicatorLookbacks()
+	lookbacks.ATR = defaults.ATR
+	lookbacks.ADR = defaults.ADR
+	lookbacks.VolumeSMA = defaults.Volume
+	return lookbacks
+}
@@ -0,0 +1,77 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// MFIResult holds a symbol's Money Flow Index value
+type MFIResult struct {
+	Symbol string  `json:"symbol"`
+	MFI    float64 `json:"mfi"`
+}
+
+// MFIScreeningService handles Money Flow Index screening logic
+type MFIScreeningService struct {
+	db *gorm.DB
+}
+
+// NewMFIScreeningService creates a new instance of MFIScreeningService
+func NewMFIScreeningService() *MFIScreeningService {
+	return &MFIScreeningService{
+		db: database.GetDB(),
+	}
+}
+
+// GetSymbolsByMFI scans all symbols with the given range/interval and returns those whose
+// MFI(lookback) is above threshold (condition "overbought") or below threshold (condition "oversold").
+// Symbols with fewer than lookback+1 bars are skipped; order sorts matches by MFI and applies its limit.
+func (s *MFIScreeningService) GetSymbolsByMFI(rangeParam, interval string, lookback int, condition string, threshold float64, liquidity LiquidityFilter, order ResultOrder) ([]MFIResult, error) {
+	if rangeParam == "" || interval == "" || lookback <= 0 {
+		return nil, errors.New("range, interval, and lookback (positive) are required")
+	}
+	if condition != "overbought" && condition != "oversold" {
+		return nil, errors.New("condition must be overbought or oversold")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []MFIResult{}, nil
+	}
+
+	matches := make([]MFIResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
+
+		mfi, err := calculations.MoneyFlowIndex(rows, lookback)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
+
+		if (condition == "overbought" && mfi > threshold) || (condition == "oversold" && mfi < threshold) {
+			matches = append(matches, MFIResult{Symbol: sym, MFI: mfi})
+		}
+	}
+
+	return applyOrder(order, matches, func(r MFIResult) (string, float64) { return r.Symbol, r.MFI }), nil
+}
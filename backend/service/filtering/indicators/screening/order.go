@@ -0,0 +1,64 @@
+package screening
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Screen result orderings. Without a sort, results are in symbol order.
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// ResultOrder sorts screen results by their computed value and optionally truncates them
+type ResultOrder struct {
+	Sort  string `json:"sort,omitempty"`  // "asc", "desc" or "" (symbol order)
+	Limit int    `json:"limit,omitempty"` // Keep only the first Limit results after sorting (0 keeps all)
+}
+
+// ParseResultOrder validates a sort direction ("", "asc" or "desc") and a non-negative limit
+func ParseResultOrder(sortDir string, limit int) (ResultOrder, error) {
+	sortDir = strings.ToLower(strings.TrimSpace(sortDir))
+	if sortDir != "" && sortDir != SortAscending && sortDir != SortDescending {
+		return ResultOrder{}, errors.New("sort must be asc or desc")
+	}
+	if limit < 0 {
+		return ResultOrder{}, errors.New("limit must be a non-negative integer")
+	}
+	return ResultOrder{Sort: sortDir, Limit: limit}, nil
+}
+
+// Apply sorts results by value (ties broken by symbol) and applies the limit
+func (o ResultOrder) Apply(results []IndicatorResult) []IndicatorResult {
+	return applyOrder(o, results, func(r IndicatorResult) (string, float64) { return r.Symbol, r.Value })
+}
+
+// applyOrder is Apply for screens with their own result types; key returns a result's symbol and sort value
+func applyOrder[T any](o ResultOrder, results []T, key func(T) (string, float64)) []T {
+	switch o.Sort {
+	case SortAscending:
+		sort.SliceStable(results, func(i, j int) bool {
+			symI, valI := key(results[i])
+			symJ, valJ := key(results[j])
+			if valI != valJ {
+				return valI < valJ
+			}
+			return symI < symJ
+		})
+	case SortDescending:
+		sort.SliceStable(results, func(i, j int) bool {
+			symI, valI := key(results[i])
+			symJ, valJ := key(results[j])
+			if valI != valJ {
+				return valI > valJ
+			}
+			return symI < symJ
+		})
+	}
+	if o.Limit > 0 && len(results) > o.Limit {
+		results = results[:o.Limit]
+	}
+	return results
+}
@@ -0,0 +1,31 @@
+package screening
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyOrder(t *testing.T) {
+	matches := []CCIResult{{"MSFT", 120}, {"AAPL", 150}, {"NVDA", 120}, {"AMD", 180}}
+	tests := []struct {
+		order ResultOrder
+		want  []string
+	}{
+		{ResultOrder{}, []string{"MSFT", "AAPL", "NVDA", "AMD"}},
+		{ResultOrder{Sort: SortAscending}, []string{"MSFT", "NVDA", "AAPL", "AMD"}},
+		{ResultOrder{Sort: SortDescending}, []string{"AMD", "AAPL", "MSFT", "NVDA"}},
+		{ResultOrder{Sort: SortDescending, Limit: 2}, []string{"AMD", "AAPL"}},
+		{ResultOrder{Limit: 10}, []string{"MSFT", "AAPL", "NVDA", "AMD"}},
+	}
+	for _, tt := range tests {
+		results := append([]CCIResult(nil), matches...)
+		ordered := applyOrder(tt.order, results, func(r CCIResult) (string, float64) { return r.Symbol, r.CCI })
+		symbols := make([]string, 0, len(ordered))
+		for _, r := range ordered {
+			symbols = append(symbols, r.Symbol)
+		}
+		if !reflect.DeepEqual(symbols, tt.want) {
+			t.Errorf("applyOrder(%+v) = %v, want %v", tt.order, symbols, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// PivotLevels holds classic pivot point levels for a symbol's current session
+type PivotLevels struct {
+	Symbol    string  `json:"symbol"`
+	Pivot     float64 `json:"pivot"`
+	R1        float64 `json:"r1"`
+	R2        float64 `json:"r2"`
+	S1        float64 `json:"s1"`
+	S2        float64 `json:"s2"`
+	PrevEpoch int64   `json:"prev_epoch"`
+	PrevHigh  float64 `json:"prev_high"`
+	PrevLow   float64 `json:"prev_low"`
+	PrevClose float64 `json:"prev_close"`
+}
+
+// PivotPointsService handles pivot point calculations
+type PivotPointsService struct {
+	db *gorm.DB
+}
+
+// NewPivotPointsService constructs a new PivotPointsService
+func NewPivotPointsService() *PivotPointsService {
+	return &PivotPointsService{db: database.GetDB()}
+}
+
+// GetPivotPointsForSymbol computes classic pivot levels from the symbol's previous daily (10y/1d) bar.
+// The most recent bar is treated as the current session and the one before it as the previous
+// session, so at least two daily bars are required.
+func (s *PivotPointsService) GetPivotPointsForSymbol(symbol string) (*PivotLevels, error) {
+	if symbol == "" {
+		return nil, errors.New("symbol is required")
+	}
+
+	var rows []model.Historical
+	if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, "10y", "1d").
+		Order("epoch DESC").
+		Limit(2).
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch historical data: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("%w: need 2 daily bars, have %d", calculations.ErrInsufficientData, len(rows))
+	}
+
+	// rows[0] = most recent (current day), rows[1] = previous day
+	previous := rows[1]
+	pivot, r1, r2, s1, s2 := calculations.PivotPoints(previous.High, previous.Low, previous.Close)
+
+	return &PivotLevels{
+		Symbol:    symbol,
+		Pivot:     pivot,
+		R1:        r1,
+		R2:        r2,
+		S1:        s1,
+		S2:        s2,
+		PrevEpoch: previous.Epoch,
+		PrevHigh:  previous.High,
+		PrevLow:   previous.Low,
+		PrevClose: previous.Close,
+	}, nil
+}
@@ -0,0 +1,216 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"screener/backend/database"
+	"screener/backend/model"
+	"sort"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownIndicator is returned when an indicator name is not registered
+var ErrUnknownIndicator = errors.New("unknown indicator")
+
+// IndicatorParams holds the parameters shared by single-value indicators
+type IndicatorParams struct {
+	Lookback int
+	// Strict rejects series with fewer bars than Lookback instead of averaging what is available
+	Strict bool
+	// Liquidity excludes thin names from Screen; ignored when computing for a single symbol
+	Liquidity LiquidityFilter
+	// Order sorts and truncates Screen results; by default they are in symbol order
+	Order ResultOrder
+}
+
+// Indicator computes a single value from a symbol's bars (oldest first).
+// New indicators only need to implement this and call RegisterIndicator.
+type Indicator interface {
+	Name() string
+	DefaultLookback() int
+	Compute(rows []model.Historical, params IndicatorParams) (float64, error)
+}
+
+// SeriesIndicator is an Indicator that can compute its value at every bar in one pass.
+// Series(rows, params)[i] equals Compute(rows[:i+1], params), or NaN where Compute errors.
+type SeriesIndicator interface {
+	Indicator
+	Series(rows []model.Historical, params IndicatorParams) []float64
+}
+
+// indicatorSeries returns the indicator's value at every bar, using Series when the indicator
+// provides it and otherwise recomputing over each prefix (quadratic in len(rows))
+func indicatorSeries(indicator Indicator, rows []model.Historical, params IndicatorParams) []float64 {
+	if series, ok := indicator.(SeriesIndicator); ok {
+		return series.Series(rows, params)
+	}
+	values := make([]float64, len(rows))
+	for i := range rows {
+		value, err := indicator.Compute(rows[:i+1], params)
+		if err != nil {
+			value = math.NaN()
+		}
+		values[i] = value
+	}
+	return values
+}
+
+// IndicatorResult holds an indicator value for a symbol
+type IndicatorResult struct {
+	Symbol string  `json:"symbol"`
+	Value  float64 `json:"value"`
+}
+
+var (
+	indicatorRegistryMu sync.RWMutex
+	indicatorRegistry   = make(map[string]Indicator)
+)
+
+// RegisterIndicator adds an indicator to the registry under its lowercase name
+func RegisterIndicator(indicator Indicator) {
+	indicatorRegistryMu.Lock()
+	defer indicatorRegistryMu.Unlock()
+	indicatorRegistry[strings.ToLower(indicator.Name())] = indicator
+}
+
+// GetIndicator looks up a registered indicator by name (case-insensitive)
+func GetIndicator(name string) (Indicator, error) {
+	indicatorRegistryMu.RLock()
+	defer indicatorRegistryMu.RUnlock()
+	indicator, ok := indicatorRegistry[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIndicator, name)
+	}
+	return indicator, nil
+}
+
+// IndicatorNames returns the sorted names of all registered indicators
+func IndicatorNames() []string {
+	indicatorRegistryMu.RLock()
+	defer indicatorRegistryMu.RUnlock()
+	names := make([]string, 0, len(indicatorRegistry))
+	for name := range indicatorRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterIndicator(adrIndicator{})
+	RegisterIndicator(adrDollarsIndicator{})
+	RegisterIndicator(atrIndicator{})
+	RegisterIndicator(rsiIndicator{})
+}
+
+// IndicatorService runs registered indicators against stored historical data
+type IndicatorService struct {
+	db *gorm.DB
+}
+
+// NewIndicatorService creates a new instance of IndicatorService
+func NewIndicatorService() *IndicatorService {
+	return &IndicatorService{
+		db: database.GetDB(),
+	}
+}
+
+// ComputeForSymbol computes the named indicator for a specific symbol
+func (s *IndicatorService) ComputeForSymbol(name, symbol, rangeParam, interval string, params IndicatorParams) (float64, error) {
+	indicator, err := GetIndicator(name)
+	if err != nil {
+		return 0, err
+	}
+	if symbol == "" || rangeParam == "" || interval == "" || params.Lookback <= 0 {
+		return 0, errors.New("symbol, range, interval, and lookback (positive) are required")
+	}
+
+	rows, err := s.symbolRows(symbol, rangeParam, interval)
+	if err != nil {
+		return 0, err
+	}
+
+	return indicator.Compute(rows, params)
+}
+
+// symbolRows loads a symbol's bars for a range/interval in ascending epoch order
+func (s *IndicatorService) symbolRows(symbol, rangeParam, interval string) ([]model.Historical, error) {
+	var rows []model.Historical
+	if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, rangeParam, interval).
+		Order("epoch ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch historical data: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no historical data found for symbol")
+	}
+	return rows, nil
+}
+
+// Screen scans all symbols with the given range/interval and returns those whose value for the
+// named indicator falls within the specified thresholds. Symbols the indicator can't be
+// computed for (e.g. too few bars when strict) are skipped. Results are in symbol order
+// unless params.Order sorts them by value.
+func (s *IndicatorService) Screen(name, rangeParam, interval string, params IndicatorParams, minValue, maxValue *float64) ([]IndicatorResult, error) {
+	indicator, err := GetIndicator(name)
+	if err != nil {
+		return nil, err
+	}
+	if rangeParam == "" || interval == "" || params.Lookback <= 0 {
+		return nil, errors.New("range, interval, and lookback (positive) are required")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Order("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []IndicatorResult{}, nil
+	}
+
+	matches := make([]IndicatorResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if len(rows) == 0 || !params.Liquidity.Allows(rows) {
+			continue
+		}
+
+		value, err := indicator.Compute(rows, params)
+		if err != nil {
+			continue
+		}
+
+		if minValue != nil && value < *minValue {
+			continue
+		}
+		if maxValue != nil && value > *maxValue {
+			continue
+		}
+		matches = append(matches, IndicatorResult{Symbol: sym, Value: value})
+	}
+
+	return params.Order.Apply(matches), nil
+}
+
+// resultSymbols extracts the symbols from a list of indicator results
+func resultSymbols(results []IndicatorResult) []string {
+	symbols := make([]string, 0, len(results))
+	for _, r := range results {
+		symbols = append(symbols, r.Symbol)
+	}
+	return symbols
+}
@@ -0,0 +1,38 @@
+package screening
+
+import (
+	"math"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+)
+
+// rsiIndicator computes Wilder's RSI(lookback) over closes
+// RSI always needs lookback+1 bars, so Strict has no effect
+type rsiIndicator struct{}
+
+func (rsiIndicator) Name() string { return "rsi" }
+
+func (rsiIndicator) DefaultLookback() int { return Lookbacks().RSI }
+
+func (rsiIndicator) Compute(rows []model.Historical, params IndicatorParams) (float64, error) {
+	closes := make([]float64, 0, len(rows))
+	for _, r := range rows {
+		closes = append(closes, r.Close)
+	}
+	return calculations.RelativeStrengthIndex(closes, params.Lookback)
+}
+
+func (rsiIndicator) Series(rows []model.Historical, params IndicatorParams) []float64 {
+	closes := make([]float64, 0, len(rows))
+	for _, r := range rows {
+		closes = append(closes, r.Close)
+	}
+	series, err := calculations.RelativeStrengthIndexSeries(closes, params.Lookback)
+	if err != nil {
+		series = make([]float64, len(rows))
+		for i := range series {
+			series[i] = math.NaN()
+		}
+	}
+	return series
+}
@@ -0,0 +1,82 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// StochasticResult holds a symbol's Stochastic oscillator values
+type StochasticResult struct {
+	Symbol   string  `json:"symbol"`
+	PercentK float64 `json:"percent_k"`
+	PercentD float64 `json:"percent_d"`
+}
+
+// StochasticScreeningService handles Stochastic oscillator screening logic
+type StochasticScreeningService struct {
+	db *gorm.DB
+}
+
+// NewStochasticScreeningService creates a new instance of StochasticScreeningService
+func NewStochasticScreeningService() *StochasticScreeningService {
+	return &StochasticScreeningService{
+		db: database.GetDB(),
+	}
+}
+
+// GetSymbolsByStochastic scans all symbols with the given range/interval and returns those whose
+// %K is above threshold (condition "overbought") or below threshold (condition "oversold").
+// Symbols with fewer than kLookback+dSmoothing-1 bars are skipped; order sorts matches by %K and applies its limit.
+func (s *StochasticScreeningService) GetSymbolsByStochastic(rangeParam, interval string, kLookback, dSmoothing int, condition string, threshold float64, liquidity LiquidityFilter, order ResultOrder) ([]StochasticResult, error) {
+	if rangeParam == "" || interval == "" || kLookback <= 0 || dSmoothing <= 0 {
+		return nil, errors.New("range, interval, k_lookback and d_smoothing (positive) are required")
+	}
+	if condition != "overbought" && condition != "oversold" {
+		return nil, errors.New("condition must be overbought or oversold")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []StochasticResult{}, nil
+	}
+
+	matches := make([]StochasticResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
+
+		percentK, percentD, err := calculations.Stochastic(rows, kLookback, dSmoothing)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
+
+		if (condition == "overbought" && percentK > threshold) || (condition == "oversold" && percentK < threshold) {
+			matches = append(matches, StochasticResult{
+				Symbol:   sym,
+				PercentK: percentK,
+				PercentD: percentD,
+			})
+		}
+	}
+
+	return applyOrder(order, matches, func(r StochasticResult) (string, float64) { return r.Symbol, r.PercentK }), nil
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"screener/backend/database"
 	"screener/backend/model"
-	"screener/backend/service/filtering/indicators/calculations"
 
 	"gorm.io/gorm"
 )
@@ -25,7 +24,17 @@ func NewVolumeScreeningService() *VolumeScreeningService {
 // GetSymbolsByAvgVolumeDollars scans all symbols and returns those whose average daily
 // volume in dollars (SMA of volume*close over lookback) falls within the thresholds.
 // Volume in dollars = volume * close, then SMA over lookback, then convert to millions ($M)
-func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interval string, lookback int, minVolDollarsM, maxVolDollarsM *float64) ([]string, error) {
+// When strict, symbols with fewer bars than lookback are excluded rather than averaged over fewer points.
+func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interval string, lookback int, minVolDollarsM, maxVolDollarsM *float64, strict bool, liquidity LiquidityFilter) ([]string, error) {
+	results, err := s.ScreenAvgVolumeDollars(rangeParam, interval, lookback, minVolDollarsM, maxVolDollarsM, strict, liquidity, ResultOrder{})
+	if err != nil {
+		return nil, err
+	}
+	return resultSymbols(results), nil
+}
+
+// ScreenAvgVolumeDollars is GetSymbolsByAvgVolumeDollars returning each matching symbol's value alongside it, in the given order
+func (s *VolumeScreeningService) ScreenAvgVolumeDollars(rangeParam, interval string, lookback int, minVolDollarsM, maxVolDollarsM *float64, strict bool, liquidity LiquidityFilter, order ResultOrder) ([]IndicatorResult, error) {
 	if rangeParam == "" || interval == "" || lookback <= 0 {
 		return nil, errors.New("range, interval, and lookback (positive) are required")
 	}
@@ -34,14 +43,15 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interv
 	if err := s.db.Model(&model.Historical{}).
 		Where("range = ? AND interval = ?", rangeParam, interval).
 		Distinct("symbol").
+		Order("symbol").
 		Pluck("symbol", &symbols).Error; err != nil {
 		return nil, fmt.Errorf("failed to load symbols: %w", err)
 	}
 	if len(symbols) == 0 {
-		return []string{}, nil
+		return []IndicatorResult{}, nil
 	}
 
-	matches := make([]string, 0)
+	matches := make([]IndicatorResult, 0)
 	for _, sym := range symbols {
 		var rows []model.Historical
 		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
@@ -49,6 +59,9 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interv
 			Find(&rows).Error; err != nil {
 			continue
 		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
 		if len(rows) == 0 {
 			continue
 		}
@@ -58,7 +71,11 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interv
 		for _, r := range rows {
 			volDollarSeries = append(volDollarSeries, float64(r.Volume)*r.Close)
 		}
-		avgVolDollarsM := calculations.SimpleMovingAverage(volDollarSeries, lookback) / 1_000_000.0
+		avgVolDollars, err := movingAverage(volDollarSeries, lookback, strict)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
+		avgVolDollarsM := avgVolDollars / 1_000_000.0
 
 		matchesThreshold := true
 		if minVolDollarsM != nil && avgVolDollarsM < *minVolDollarsM {
@@ -68,17 +85,27 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumeDollars(rangeParam, interv
 			matchesThreshold = false
 		}
 		if matchesThreshold {
-			matches = append(matches, sym)
+			matches = append(matches, IndicatorResult{Symbol: sym, Value: avgVolDollarsM})
 		}
 	}
 
-	return matches, nil
+	return order.Apply(matches), nil
 }
 
 // GetSymbolsByAvgVolumePercent scans all symbols and returns those whose current volume
 // as a percentage of average volume (SMA over lookback) falls within the thresholds.
 // Volume % = (current volume / SMA(volume, lookback)) * 100
-func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interval string, lookback int, minVolPercent, maxVolPercent *float64) ([]string, error) {
+// When strict, symbols with fewer bars than lookback are excluded rather than averaged over fewer points.
+func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interval string, lookback int, minVolPercent, maxVolPercent *float64, strict bool, liquidity LiquidityFilter) ([]string, error) {
+	results, err := s.ScreenAvgVolumePercent(rangeParam, interval, lookback, minVolPercent, maxVolPercent, strict, liquidity, ResultOrder{})
+	if err != nil {
+		return nil, err
+	}
+	return resultSymbols(results), nil
+}
+
+// ScreenAvgVolumePercent is GetSymbolsByAvgVolumePercent returning each matching symbol's value alongside it, in the given order
+func (s *VolumeScreeningService) ScreenAvgVolumePercent(rangeParam, interval string, lookback int, minVolPercent, maxVolPercent *float64, strict bool, liquidity LiquidityFilter, order ResultOrder) ([]IndicatorResult, error) {
 	if rangeParam == "" || interval == "" || lookback <= 0 {
 		return nil, errors.New("range, interval, and lookback (positive) are required")
 	}
@@ -87,14 +114,15 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interv
 	if err := s.db.Model(&model.Historical{}).
 		Where("range = ? AND interval = ?", rangeParam, interval).
 		Distinct("symbol").
+		Order("symbol").
 		Pluck("symbol", &symbols).Error; err != nil {
 		return nil, fmt.Errorf("failed to load symbols: %w", err)
 	}
 	if len(symbols) == 0 {
-		return []string{}, nil
+		return []IndicatorResult{}, nil
 	}
 
-	matches := make([]string, 0)
+	matches := make([]IndicatorResult, 0)
 	for _, sym := range symbols {
 		var rows []model.Historical
 		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
@@ -102,6 +130,9 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interv
 			Find(&rows).Error; err != nil {
 			continue
 		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
 		if len(rows) == 0 {
 			continue
 		}
@@ -111,7 +142,11 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interv
 		for _, r := range rows {
 			volumes = append(volumes, float64(r.Volume))
 		}
-		avgVolume := calculations.SimpleMovingAverage(volumes, lookback)
+		// The average window includes the current bar, so volume % is relative to the trailing N bars
+		avgVolume, err := movingAverage(volumes, lookback, strict)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
 		if avgVolume == 0 {
 			continue // skip if average is zero
 		}
@@ -126,16 +161,17 @@ func (s *VolumeScreeningService) GetSymbolsByAvgVolumePercent(rangeParam, interv
 			matchesThreshold = false
 		}
 		if matchesThreshold {
-			matches = append(matches, sym)
+			matches = append(matches, IndicatorResult{Symbol: sym, Value: volPercent})
 		}
 	}
 
-	return matches, nil
+	return order.Apply(matches), nil
 }
 
 // GetAvgVolumeDollarsForSymbol calculates and returns average daily volume in dollars (millions)
 // for a specific symbol. Volume $ = SMA(volume * close, lookback) / 1,000,000
-func (s *VolumeScreeningService) GetAvgVolumeDollarsForSymbol(symbol, rangeParam, interval string, lookback int) (float64, error) {
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *VolumeScreeningService) GetAvgVolumeDollarsForSymbol(symbol, rangeParam, interval string, lookback int, strict bool) (float64, error) {
 	if symbol == "" || rangeParam == "" || interval == "" || lookback <= 0 {
 		return 0, errors.New("symbol, range, interval, and lookback (positive) are required")
 	}
@@ -154,14 +190,19 @@ func (s *VolumeScreeningService) GetAvgVolumeDollarsForSymbol(symbol, rangeParam
 	for _, r := range rows {
 		volDollarSeries = append(volDollarSeries, float64(r.Volume)*r.Close)
 	}
-	avgVolDollarsM := calculations.SimpleMovingAverage(volDollarSeries, lookback) / 1_000_000.0
+	avgVolDollars, err := movingAverage(volDollarSeries, lookback, strict)
+	if err != nil {
+		return 0, err
+	}
+	avgVolDollarsM := avgVolDollars / 1_000_000.0
 
 	return avgVolDollarsM, nil
 }
 
 // GetAvgVolumePercentForSymbol calculates and returns current volume as a percentage
 // of average volume for a specific symbol. Volume % = (current volume / SMA(volume, lookback)) * 100
-func (s *VolumeScreeningService) GetAvgVolumePercentForSymbol(symbol, rangeParam, interval string, lookback int) (float64, error) {
+// When strict, ErrInsufficientData is returned if the symbol has fewer bars than lookback.
+func (s *VolumeScreeningService) GetAvgVolumePercentForSymbol(symbol, rangeParam, interval string, lookback int, strict bool) (float64, error) {
 	if symbol == "" || rangeParam == "" || interval == "" || lookback <= 0 {
 		return 0, errors.New("symbol, range, interval, and lookback (positive) are required")
 	}
@@ -180,7 +221,11 @@ func (s *VolumeScreeningService) GetAvgVolumePercentForSymbol(symbol, rangeParam
 	for _, r := range rows {
 		volumes = append(volumes, float64(r.Volume))
 	}
-	avgVolume := calculations.SimpleMovingAverage(volumes, lookback)
+	// The average window includes the current bar, so volume % is relative to the trailing N bars
+	avgVolume, err := movingAverage(volumes, lookback, strict)
+	if err != nil {
+		return 0, err
+	}
 	if avgVolume == 0 {
 		return 0, errors.New("average volume is zero")
 	}
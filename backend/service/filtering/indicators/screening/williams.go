@@ -0,0 +1,83 @@
+package screening
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/filtering/indicators/calculations"
+
+	"gorm.io/gorm"
+)
+
+// MaxWilliamsRLookback caps the Williams %R lookback; larger values are clamped
+const MaxWilliamsRLookback = 250
+
+// WilliamsRResult holds a symbol's Williams %R value
+type WilliamsRResult struct {
+	Symbol    string  `json:"symbol"`
+	WilliamsR float64 `json:"williams_r"`
+}
+
+// WilliamsRScreeningService handles Williams %R screening logic
+type WilliamsRScreeningService struct {
+	db *gorm.DB
+}
+
+// NewWilliamsRScreeningService creates a new instance of WilliamsRScreeningService
+func NewWilliamsRScreeningService() *WilliamsRScreeningService {
+	return &WilliamsRScreeningService{
+		db: database.GetDB(),
+	}
+}
+
+// GetSymbolsByWilliamsR scans all symbols with the given range/interval and returns those whose
+// %R is above threshold (condition "overbought") or below threshold (condition "oversold").
+// Symbols with fewer bars than lookback are skipped; order sorts matches by %R and applies its limit.
+func (s *WilliamsRScreeningService) GetSymbolsByWilliamsR(rangeParam, interval string, lookback int, condition string, threshold float64, liquidity LiquidityFilter, order ResultOrder) ([]WilliamsRResult, error) {
+	if rangeParam == "" || interval == "" || lookback <= 0 {
+		return nil, errors.New("range, interval, and lookback (positive) are required")
+	}
+	if lookback > MaxWilliamsRLookback {
+		lookback = MaxWilliamsRLookback
+	}
+	if condition != "overbought" && condition != "oversold" {
+		return nil, errors.New("condition must be overbought or oversold")
+	}
+
+	// Collect distinct symbols that have records for this range/interval
+	var symbols []string
+	if err := s.db.Model(&model.Historical{}).
+		Where("range = ? AND interval = ?", rangeParam, interval).
+		Distinct("symbol").
+		Pluck("symbol", &symbols).Error; err != nil {
+		return nil, fmt.Errorf("failed to load symbols: %w", err)
+	}
+	if len(symbols) == 0 {
+		return []WilliamsRResult{}, nil
+	}
+
+	matches := make([]WilliamsRResult, 0)
+	for _, sym := range symbols {
+		var rows []model.Historical
+		if err := s.db.Where("symbol = ? AND range = ? AND interval = ?", sym, rangeParam, interval).
+			Order("epoch ASC").
+			Find(&rows).Error; err != nil {
+			continue
+		}
+		if !liquidity.Allows(rows) {
+			continue
+		}
+
+		percentR, err := calculations.WilliamsPercentR(rows, lookback)
+		if err != nil {
+			continue // skip symbols with fewer bars than the lookback
+		}
+
+		if (condition == "overbought" && percentR > threshold) || (condition == "oversold" && percentR < threshold) {
+			matches = append(matches, WilliamsRResult{Symbol: sym, WilliamsR: percentR})
+		}
+	}
+
+	return applyOrder(order, matches, func(r WilliamsRResult) (string, float64) { return r.Symbol, r.WilliamsR }), nil
+}
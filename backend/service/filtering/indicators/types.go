@@ -8,6 +8,34 @@ type IndicatorLookbacks struct {
 	MA        int // e.g., 50
 }
 
+// DefaultIndicatorLookbacks returns the default window sizes:
+// ATR 14, ADR 14, volume SMA 50 and MA 50 bars
+func DefaultIndicatorLookbacks() IndicatorLookbacks {
+	return IndicatorLookbacks{
+		ATR:       14,
+		ADR:       14,
+		VolumeSMA: 50,
+		MA:        50,
+	}
+}
+
+// trailingWindowBuffer is the number of bars loaded beyond the longest lookback: ATR needs the
+// close before its first bar and inside-day compares the last bar with the previous one
+const trailingWindowBuffer = 5
+
+// TrailingWindow returns how many of the most recent bars a snapshot with these lookbacks needs.
+// Every snapshot indicator is a trailing window, so computing over only these bars gives the
+// same values as the full series.
+func (l IndicatorLookbacks) TrailingWindow() int {
+	longest := l.ATR
+	for _, n := range []int{l.ADR, l.VolumeSMA, l.MA} {
+		if n > longest {
+			longest = n
+		}
+	}
+	return longest + trailingWindowBuffer
+}
+
 // IndicatorSnapshot represents a single-bar snapshot of computed indicators
 type IndicatorSnapshot struct {
 	Symbol               string  `json:"symbol"`
@@ -21,4 +49,7 @@ type IndicatorSnapshot struct {
 	DailyVolumeDollarsM  float64 `json:"daily_volume_dollars_m"`
 	PercentGainFromMA    float64 `json:"percent_gain_from_ma"`
 	InsideDay            bool    `json:"inside_day"`
+	// InsufficientData lists indicators (by JSON field) left at 0 because the series
+	// has fewer bars than their lookback
+	InsufficientData []string `json:"insufficient_data,omitempty"`
 }
@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -19,14 +21,19 @@ type FundamentalDataService struct {
 	db    *gorm.DB
 	cache *caching.CacheService
 	ttl   *caching.CacheTTLConfig
+
+	// metricsSets memoizes parsed metrics per statement type/frequency (see loadMetrics)
+	metricsMu   sync.Mutex
+	metricsSets map[string]*metricsSet
 }
 
 // NewFundamentalDataService creates a new instance of FundamentalDataService
 func NewFundamentalDataService() *FundamentalDataService {
 	return &FundamentalDataService{
-		db:    database.GetDB(),
-		cache: caching.NewCacheService(),
-		ttl:   caching.GetTTLConfig(),
+		db:          database.GetDB(),
+		cache:       caching.NewCacheService(),
+		ttl:         caching.GetTTLConfig(),
+		metricsSets: make(map[string]*metricsSet),
 	}
 }
 
@@ -49,6 +56,15 @@ type StatementRowWithDates struct {
 	Breakdown string             `json:"breakdown"`
 	Dates     map[string]float64 `json:"dates"`    // Date -> numeric value
 	RawDates  map[string]string  `json:"rawDates"` // Date -> raw string value
+	Values    []DatedValue       `json:"values"`   // Chronological (oldest first)
+}
+
+// DatedValue is a single statement value for one period
+// Value is nil when the raw value isn't numeric (e.g. "*")
+type DatedValue struct {
+	Date  string   `json:"date"`
+	Value *float64 `json:"value"`
+	Raw   string   `json:"raw"`
 }
 
 // FundamentalMetrics represents calculated metrics from financial data
@@ -66,9 +82,12 @@ type FundamentalMetrics struct {
 	GrossProfit       map[string]float64 `json:"grossProfit,omitempty"`
 	OperatingIncome   map[string]float64 `json:"operatingIncome,omitempty"`
 	NetIncome         map[string]float64 `json:"netIncome,omitempty"`
+	AverageShares     map[string]float64 `json:"averageShares,omitempty"`     // Income statements
+	DividendsPaid     map[string]float64 `json:"dividendsPaid,omitempty"`     // Cash flow statements; negative outflows
+	TotalEquity       map[string]float64 `json:"totalEquity,omitempty"`       // Balance sheets
+	SharesOutstanding map[string]float64 `json:"sharesOutstanding,omitempty"` // Balance sheets
 	ParsedStatement   *ParsedStatement   `json:"parsedStatement,omitempty"`
 }
-
 // GetAllFundamentalData fetches all fundamental data records
 func (s *FundamentalDataService) GetAllFundamentalData() ([]model.FundamentalData, error) {
 	cacheKey := caching.GenerateKeyFromPath("fundamental-data")
@@ -117,10 +136,16 @@ func (s *FundamentalDataService) GetFundamentalDataBySymbolAndType(symbol, state
 		return nil, errors.New("symbol and statement type are required")
 	}
 
+	dataCache := caching.NewDataCache()
+	if dataCache.IsFundamentalDataNotFound(symbol, statementType, "") {
+		return nil, errors.New("record not found")
+	}
+
 	var fundamentalData model.FundamentalData
 	result := s.db.Where("symbol = ? AND statement_type = ?", symbol, statementType).First(&fundamentalData)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			_ = dataCache.MarkFundamentalDataNotFound(symbol, statementType, "")
 			return nil, errors.New("record not found")
 		}
 		return nil, fmt.Errorf("failed to fetch fundamental data: %w", result.Error)
@@ -142,11 +167,17 @@ func (s *FundamentalDataService) GetFundamentalDataBySymbolTypeAndFrequency(symb
 		return fundamentalData, nil
 	}
 
+	// A recent lookup already found nothing; skip the database until the tombstone expires
+	if dataCache.IsFundamentalDataNotFound(symbol, statementType, frequency) {
+		return nil, errors.New("record not found")
+	}
+
 	// Redis miss - check database
 	var dbFundamentalData model.FundamentalData
 	result := s.db.Where("symbol = ? AND statement_type = ? AND frequency = ?", symbol, statementType, frequency).First(&dbFundamentalData)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			_ = dataCache.MarkFundamentalDataNotFound(symbol, statementType, frequency)
 			return nil, errors.New("record not found")
 		}
 		return nil, fmt.Errorf("failed to fetch fundamental data: %w", result.Error)
@@ -186,13 +217,101 @@ func (s *FundamentalDataService) GetFundamentalDataByFrequency(frequency string)
 }
 
 // GetFundamentalMetrics calculates metrics from fundamental data
+// Computed metrics are cached per symbol/type/frequency until the symbol is re-ingested or the TTL expires
 func (s *FundamentalDataService) GetFundamentalMetrics(symbol, statementType, frequency string) (*FundamentalMetrics, error) {
+	cacheKey := caching.FundamentalMetricsKey(symbol, statementType, frequency)
+	var cached FundamentalMetrics
+
+	found, err := s.cache.GetJSON(cacheKey, &cached)
+	if err == nil && found {
+		return &cached, nil
+	}
+
 	fundamentalData, err := s.GetFundamentalDataBySymbolTypeAndFrequency(symbol, statementType, frequency)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.calculateMetrics(fundamentalData)
+	metrics, err := s.calculateMetrics(fundamentalData)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.SetJSON(cacheKey, metrics, s.ttl.FundamentalMetrics)
+	return metrics, nil
+}
+
+// MaxMetricsBatchSymbols caps the number of symbols accepted by GetFundamentalMetricsBatch
+const MaxMetricsBatchSymbols = 100
+
+// metricsBatchWorkers is the number of symbols whose metrics are computed concurrently
+const metricsBatchWorkers = 8
+
+// FundamentalMetricsBatch holds metrics for a batch of symbols
+type FundamentalMetricsBatch struct {
+	Metrics []FundamentalMetrics `json:"metrics"`          // In request order
+	Missing []string             `json:"missing"`          // Symbols with no fundamental data
+	Errors  map[string]string    `json:"errors,omitempty"` // Symbols whose metrics failed to compute
+}
+
+// GetFundamentalMetricsBatch computes metrics for several symbols sharing a statement type and frequency.
+// Symbols are processed concurrently; those without data are reported in Missing rather than failing the batch.
+func (s *FundamentalDataService) GetFundamentalMetricsBatch(symbols []string, statementType, frequency string) (*FundamentalMetricsBatch, error) {
+	if statementType == "" || frequency == "" {
+		return nil, errors.New("statement type and frequency are required")
+	}
+	normalized, err := NormalizeFilterSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("at least one symbol is required")
+	}
+	if len(normalized) > MaxMetricsBatchSymbols {
+		return nil, fmt.Errorf("too many symbols: %d (max %d)", len(normalized), MaxMetricsBatchSymbols)
+	}
+
+	type outcome struct {
+		metrics *FundamentalMetrics
+		err     error
+	}
+	outcomes := make([]outcome, len(normalized))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < metricsBatchWorkers && w < len(normalized); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				metrics, err := s.GetFundamentalMetrics(normalized[i], statementType, frequency)
+				outcomes[i] = outcome{metrics: metrics, err: err}
+			}
+		}()
+	}
+	for i := range normalized {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	batch := &FundamentalMetricsBatch{
+		Metrics: make([]FundamentalMetrics, 0, len(normalized)),
+		Missing: make([]string, 0),
+		Errors:  make(map[string]string),
+	}
+	for i, o := range outcomes {
+		switch {
+		case o.err == nil:
+			batch.Metrics = append(batch.Metrics, *o.metrics)
+		case o.err.Error() == "record not found":
+			batch.Missing = append(batch.Missing, normalized[i])
+		default:
+			batch.Errors[normalized[i]] = o.err.Error()
+		}
+	}
+
+	return batch, nil
 }
 
 // calculateMetrics calculates various financial metrics from the statement data
@@ -211,20 +330,15 @@ func (s *FundamentalDataService) calculateMetrics(fundamentalData *model.Fundame
 	metrics.ParsedStatement = parsedStatement
 
 	// Extract key metrics
-	metrics.TotalRevenue = s.extractMetric(parsedStatement, "Total Revenue")
-	metrics.GrossProfit = s.extractMetric(parsedStatement, "Gross Profit")
-	metrics.OperatingIncome = s.extractMetric(parsedStatement, "Operating Income")
-	metrics.NetIncome = s.extractMetric(parsedStatement, "Net Income Common Stockholders")
-	if len(metrics.NetIncome) == 0 {
-		// Try alternative name
-		metrics.NetIncome = s.extractMetric(parsedStatement, "Net Income(Attributable to Parent Company Shareholders)")
-	}
-
-	// Extract EPS
-	metrics.EPS = s.extractMetric(parsedStatement, "Diluted EPS")
-	if len(metrics.EPS) == 0 {
-		metrics.EPS = s.extractMetric(parsedStatement, "Basic EPS")
-	}
+	metrics.TotalRevenue = s.extractLineItem(parsedStatement, LineItemTotalRevenue)
+	metrics.GrossProfit = s.extractLineItem(parsedStatement, LineItemGrossProfit)
+	metrics.OperatingIncome = s.extractLineItem(parsedStatement, LineItemOperatingIncome)
+	metrics.NetIncome = s.extractLineItem(parsedStatement, LineItemNetIncome)
+	metrics.EPS = s.extractLineItem(parsedStatement, LineItemEPS)
+	metrics.AverageShares = s.extractLineItem(parsedStatement, LineItemAverageShares)
+	metrics.DividendsPaid = s.extractLineItem(parsedStatement, LineItemDividendsPaid)
+	metrics.TotalEquity = s.extractLineItem(parsedStatement, LineItemTotalEquity)
+	metrics.SharesOutstanding = s.extractLineItem(parsedStatement, LineItemSharesOutstanding)
 
 	// Calculate margins
 	metrics.GrossProfitMargin = s.calculateMargin(metrics.GrossProfit, metrics.TotalRevenue)
@@ -251,7 +365,8 @@ func (s *FundamentalDataService) parseStatement(statementJSON string) (*ParsedSt
 		Rows: make([]StatementRowWithDates, 0),
 	}
 
-	for _, value := range rawStatement {
+	rowKeys := make([]string, 0, len(rawStatement))
+	for key, value := range rawStatement {
 		rowMap, ok := value.(map[string]interface{})
 		if !ok {
 			continue
@@ -279,20 +394,127 @@ func (s *FundamentalDataService) parseStatement(statementJSON string) (*ParsedSt
 			}
 		}
 
+		// Ordered view of the same values for chart consumers
+		dates := make([]string, 0, len(row.RawDates))
+		for date := range row.RawDates {
+			dates = append(dates, date)
+		}
+		sortStatementDates(dates)
+		row.Values = make([]DatedValue, 0, len(dates))
+		for _, date := range dates {
+			dv := DatedValue{Date: date, Raw: row.RawDates[date]}
+			if numVal, ok := row.Dates[date]; ok {
+				dv.Value = &numVal
+			}
+			row.Values = append(row.Values, dv)
+		}
+
 		parsed.Rows = append(parsed.Rows, row)
+		rowKeys = append(rowKeys, key)
 	}
 
-	// Keep original order for now
+	// Map iteration order is random; restore the statement's row order from its keys
+	sort.Sort(statementRowsByKey{rows: parsed.Rows, keys: rowKeys})
 
 	return parsed, nil
 }
 
-// parseNumericValue parses a string value to float64, handling "*" and other non-numeric values
-func (s *FundamentalDataService) parseNumericValue(value string) (float64, error) {
-	if value == "*" || value == "" || value == "N/A" {
-		return 0, fmt.Errorf("non-numeric value")
+// statementRowsByKey sorts parsed rows by their statement keys, numerically when both keys are integers
+type statementRowsByKey struct {
+	rows []StatementRowWithDates
+	keys []string
+}
+
+func (r statementRowsByKey) Len() int { return len(r.rows) }
+func (r statementRowsByKey) Swap(i, j int) {
+	r.rows[i], r.rows[j] = r.rows[j], r.rows[i]
+	r.keys[i], r.keys[j] = r.keys[j], r.keys[i]
+}
+func (r statementRowsByKey) Less(i, j int) bool {
+	a, errA := strconv.Atoi(r.keys[i])
+	b, errB := strconv.Atoi(r.keys[j])
+	if errA == nil && errB == nil {
+		return a < b
+	}
+	return r.keys[i] < r.keys[j]
+}
+
+// statementDateLayouts are the period formats seen in statement columns
+var statementDateLayouts = []string{"2006-01-02", "1/2/2006", "01/02/2006"}
+
+// parseStatementDate parses a statement period column; ok is false for labels like "TTM"
+func parseStatementDate(date string) (time.Time, bool) {
+	for _, layout := range statementDateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, true
+		}
 	}
-	return strconv.ParseFloat(value, 64)
+	return time.Time{}, false
+}
+
+// sortStatementDates orders period columns chronologically (oldest first)
+// Non-date labels such as "TTM" sort after every dated period
+func sortStatementDates(dates []string) {
+	sort.SliceStable(dates, func(i, j int) bool {
+		ti, okI := parseStatementDate(dates[i])
+		tj, okJ := parseStatementDate(dates[j])
+		switch {
+		case okI && okJ:
+			return ti.Before(tj)
+		case okI != okJ:
+			return okI
+		default:
+			return dates[i] < dates[j]
+		}
+	})
+}
+
+// OrderedStatement is a parsed statement with rows in statement order and values in date order
+type OrderedStatement struct {
+	Symbol        string                  `json:"symbol"`
+	StatementType string                  `json:"statement_type"`
+	Frequency     string                  `json:"frequency"`
+	Dates         []string                `json:"dates"` // Every period across rows, oldest first
+	Rows          []StatementRowWithDates `json:"rows"`
+}
+
+// GetOrderedStatement returns a symbol's statement with a stable row order and chronological values
+func (s *FundamentalDataService) GetOrderedStatement(symbol, statementType, frequency string) (*OrderedStatement, error) {
+	fundamentalData, err := s.GetFundamentalDataBySymbolTypeAndFrequency(symbol, statementType, frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := s.parseStatement(fundamentalData.Statement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statement: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	dates := make([]string, 0)
+	for _, row := range parsed.Rows {
+		for _, v := range row.Values {
+			if !seen[v.Date] {
+				seen[v.Date] = true
+				dates = append(dates, v.Date)
+			}
+		}
+	}
+	sortStatementDates(dates)
+
+	return &OrderedStatement{
+		Symbol:        fundamentalData.Symbol,
+		StatementType: fundamentalData.StatementType,
+		Frequency:     fundamentalData.Frequency,
+		Dates:         dates,
+		Rows:          parsed.Rows,
+	}, nil
+}
+
+// parseNumericValue parses a statement value to float64, handling "*" and other non-numeric values
+// as well as thousands separators, parenthesized negatives and K/M/B/T suffixes
+func (s *FundamentalDataService) parseNumericValue(value string) (float64, error) {
+	return model.ParseStatementNumber(value)
 }
 
 // extractMetric extracts a specific metric from the parsed statement
@@ -306,6 +528,43 @@ func (s *FundamentalDataService) extractMetric(statement *ParsedStatement, break
 	return result
 }
 
+// Line items read out of statements; each maps to the breakdown labels it appears under
+const (
+	LineItemTotalRevenue      = "totalRevenue"
+	LineItemGrossProfit       = "grossProfit"
+	LineItemOperatingIncome   = "operatingIncome"
+	LineItemNetIncome         = "netIncome"
+	LineItemEPS               = "eps"
+	LineItemAverageShares     = "averageShares"
+	LineItemDividendsPaid     = "dividendsPaid"
+	LineItemTotalEquity       = "totalEquity"
+	LineItemSharesOutstanding = "sharesOutstanding"
+)
+
+// lineItemAliases lists the breakdown labels for each line item, most preferred first.
+// Upstream labels vary by filer and statement vintage, so new spellings are added here.
+var lineItemAliases = map[string][]string{
+	LineItemTotalRevenue:      {"Total Revenue"},
+	LineItemGrossProfit:       {"Gross Profit"},
+	LineItemOperatingIncome:   {"Operating Income"},
+	LineItemNetIncome:         {"Net Income Common Stockholders", "Net Income(Attributable to Parent Company Shareholders)"},
+	LineItemEPS:               {"Diluted EPS", "Basic EPS"},
+	LineItemAverageShares:     {"Diluted Average Shares", "Basic Average Shares"},
+	LineItemDividendsPaid:     {"Cash Dividends Paid", "Common Stock Dividend Paid", "Payment of Dividends"},
+	LineItemTotalEquity:       {"Stockholders Equity", "Common Stock Equity", "Total Equity Gross Minority Interest"},
+	LineItemSharesOutstanding: {"Ordinary Shares Number", "Share Issued"},
+}
+
+// extractLineItem returns the first non-empty series among the line item's aliases
+func (s *FundamentalDataService) extractLineItem(statement *ParsedStatement, item string) map[string]float64 {
+	for _, breakdown := range lineItemAliases[item] {
+		if values := s.extractMetric(statement, breakdown); len(values) > 0 {
+			return values
+		}
+	}
+	return make(map[string]float64)
+}
+
 // calculateMargin calculates margin percentage (metric / revenue * 100)
 func (s *FundamentalDataService) calculateMargin(metric, revenue map[string]float64) map[string]float64 {
 	margin := make(map[string]float64)
@@ -419,51 +678,18 @@ type RevenueGrowthFilter struct {
 
 // GetStocksWithRevenueGrowth returns stocks that match revenue growth criteria
 func (s *FundamentalDataService) GetStocksWithRevenueGrowth(filter RevenueGrowthFilter) ([]FundamentalMetrics, error) {
-	// Get all income statements with the specified frequency
-	dbFilter := FundamentalDataFilter{
-		StatementTypes: []string{filter.StatementType},
-		Frequencies:    []string{filter.Frequency},
-	}
-
-	fundamentalData, err := s.FilterFundamentalData(dbFilter)
+	allMetrics, err := s.loadMetrics(filter.StatementType, filter.Frequency)
 	if err != nil {
 		return nil, err
 	}
 
 	var results []FundamentalMetrics
-
-	for _, fd := range fundamentalData {
-		metrics, err := s.calculateMetrics(&fd)
-		if err != nil {
-			continue // Skip if metrics calculation fails
-		}
-
-		// Check QoQ growth if filter specified
-		if filter.MinQoQGrowth != nil || filter.MaxQoQGrowth != nil {
-			if metrics.RevenueGrowthQoQ == nil {
-				continue // Skip if QoQ not available
-			}
-			if filter.MinQoQGrowth != nil && *metrics.RevenueGrowthQoQ < *filter.MinQoQGrowth {
-				continue
-			}
-			if filter.MaxQoQGrowth != nil && *metrics.RevenueGrowthQoQ > *filter.MaxQoQGrowth {
-				continue
-			}
-		}
-
-		// Check YoY growth if filter specified
-		if filter.MinYoYGrowth != nil || filter.MaxYoYGrowth != nil {
-			if metrics.RevenueGrowthYoY == nil {
-				continue // Skip if YoY not available
-			}
-			if filter.MinYoYGrowth != nil && *metrics.RevenueGrowthYoY < *filter.MinYoYGrowth {
-				continue
-			}
-			if filter.MaxYoYGrowth != nil && *metrics.RevenueGrowthYoY > *filter.MaxYoYGrowth {
-				continue
-			}
+	for i := range allMetrics {
+		metrics := &allMetrics[i]
+		if !matchesGrowth(metrics.RevenueGrowthQoQ, filter.MinQoQGrowth, filter.MaxQoQGrowth) ||
+			!matchesGrowth(metrics.RevenueGrowthYoY, filter.MinYoYGrowth, filter.MaxYoYGrowth) {
+			continue
 		}
-
 		results = append(results, *metrics)
 	}
 
@@ -481,54 +707,18 @@ type EPSFilter struct {
 
 // GetStocksWithEPSRange returns stocks that match EPS criteria
 func (s *FundamentalDataService) GetStocksWithEPSRange(filter EPSFilter) ([]FundamentalMetrics, error) {
-	dbFilter := FundamentalDataFilter{
-		StatementTypes: []string{filter.StatementType},
-		Frequencies:    []string{filter.Frequency},
-	}
-
-	fundamentalData, err := s.FilterFundamentalData(dbFilter)
+	allMetrics, err := s.loadMetrics(filter.StatementType, filter.Frequency)
 	if err != nil {
 		return nil, err
 	}
 
 	var results []FundamentalMetrics
-
-	for _, fd := range fundamentalData {
-		metrics, err := s.calculateMetrics(&fd)
-		if err != nil {
-			continue
-		}
-
-		var epsValue float64
-		var found bool
-
-		if filter.Date != "" {
-			epsValue, found = metrics.EPS[filter.Date]
-		} else {
-			// Get latest EPS
-			if len(metrics.EPS) > 0 {
-				dates := make([]string, 0, len(metrics.EPS))
-				for date := range metrics.EPS {
-					dates = append(dates, date)
-				}
-				sort.Strings(dates)
-				if len(dates) > 0 {
-					epsValue, found = metrics.EPS[dates[0]]
-				}
-			}
-		}
-
-		if !found {
+	for i := range allMetrics {
+		metrics := &allMetrics[i]
+		epsValue, found := periodValue(metrics.EPS, filter.Date)
+		if !found || !inRange(epsValue, filter.MinEPS, filter.MaxEPS) {
 			continue
 		}
-
-		if filter.MinEPS != nil && epsValue < *filter.MinEPS {
-			continue
-		}
-		if filter.MaxEPS != nil && epsValue > *filter.MaxEPS {
-			continue
-		}
-
 		results = append(results, *metrics)
 	}
 
@@ -547,67 +737,27 @@ type MarginFilter struct {
 
 // GetStocksWithMarginRange returns stocks that match margin criteria
 func (s *FundamentalDataService) GetStocksWithMarginRange(filter MarginFilter) ([]FundamentalMetrics, error) {
-	dbFilter := FundamentalDataFilter{
-		StatementTypes: []string{filter.StatementType},
-		Frequencies:    []string{filter.Frequency},
+	if marginSeries(&FundamentalMetrics{}, filter.MarginType) == nil {
+		return nil, nil // Unknown margin type matches nothing
 	}
 
-	fundamentalData, err := s.FilterFundamentalData(dbFilter)
+	allMetrics, err := s.loadMetrics(filter.StatementType, filter.Frequency)
 	if err != nil {
 		return nil, err
 	}
 
-	var results []FundamentalMetrics
-
-	for _, fd := range fundamentalData {
-		metrics, err := s.calculateMetrics(&fd)
-		if err != nil {
-			continue
-		}
+	criteria := []MarginCriterion{{
+		MarginType: filter.MarginType,
+		MinMargin:  filter.MinMargin,
+		MaxMargin:  filter.MaxMargin,
+		Date:       filter.Date,
+	}}
 
-		var marginMap map[string]float64
-		switch strings.ToLower(filter.MarginType) {
-		case "gross":
-			marginMap = metrics.GrossProfitMargin
-		case "operating":
-			marginMap = metrics.OperatingMargin
-		case "net":
-			marginMap = metrics.NetMargin
-		default:
-			continue
-		}
-
-		var marginValue float64
-		var found bool
-
-		if filter.Date != "" {
-			marginValue, found = marginMap[filter.Date]
-		} else {
-			// Get latest margin
-			if len(marginMap) > 0 {
-				dates := make([]string, 0, len(marginMap))
-				for date := range marginMap {
-					dates = append(dates, date)
-				}
-				sort.Strings(dates)
-				if len(dates) > 0 {
-					marginValue, found = marginMap[dates[0]]
-				}
-			}
-		}
-
-		if !found {
-			continue
-		}
-
-		if filter.MinMargin != nil && marginValue < *filter.MinMargin {
-			continue
-		}
-		if filter.MaxMargin != nil && marginValue > *filter.MaxMargin {
-			continue
+	var results []FundamentalMetrics
+	for i := range allMetrics {
+		if matchesMargins(&allMetrics[i], criteria) {
+			results = append(results, allMetrics[i])
 		}
-
-		results = append(results, *metrics)
 	}
 
 	return results, nil
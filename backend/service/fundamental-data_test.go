@@ -0,0 +1,77 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"screener/backend/model"
+	"screener/backend/service/caching"
+)
+
+// orderedStatementJSON has integer row keys that sort differently as strings ("10" < "2"),
+// mixed date layouts, a TTM column and a placeholder value
+const orderedStatementJSON = `{
+	"10": {"Breakdown": "Gross Profit", "2024-09-30": "180,683", "2023-06-30": "*"},
+	"0":  {"Breakdown": "Total Revenue", "TTM": "395,760", "2024-09-30": "391,035", "9/30/2023": "383,285"},
+	"2":  {"Breakdown": "Net Income", "2024-09-30": "93,736", "2022-09-24": "99,803"}
+}`
+
+func TestGetOrderedStatement(t *testing.T) {
+	newTestRedis(t)
+	data := &model.FundamentalData{Symbol: "AAPL", StatementType: "income", Frequency: "annual", Statement: orderedStatementJSON}
+	if err := caching.NewDataCache().CacheFundamentalData("AAPL", "income", "annual", data); err != nil {
+		t.Fatalf("failed to cache statement: %v", err)
+	}
+	s := &FundamentalDataService{}
+
+	// Row order comes from map iteration; repeat so a lucky order can't pass
+	for attempt := 0; attempt < 20; attempt++ {
+		statement, err := s.GetOrderedStatement("AAPL", "income", "annual")
+		if err != nil {
+			t.Fatalf("GetOrderedStatement returned error: %v", err)
+		}
+
+		wantDates := []string{"2022-09-24", "2023-06-30", "9/30/2023", "2024-09-30", "TTM"}
+		if !reflect.DeepEqual(statement.Dates, wantDates) {
+			t.Fatalf("Dates = %v, want %v", statement.Dates, wantDates)
+		}
+
+		breakdowns := make([]string, 0, len(statement.Rows))
+		for _, row := range statement.Rows {
+			breakdowns = append(breakdowns, row.Breakdown)
+		}
+		if want := []string{"Total Revenue", "Net Income", "Gross Profit"}; !reflect.DeepEqual(breakdowns, want) {
+			t.Fatalf("row order = %v, want %v (numeric key order)", breakdowns, want)
+		}
+
+		revenue := statement.Rows[0].Values
+		if got := datedValueDates(revenue); !reflect.DeepEqual(got, []string{"9/30/2023", "2024-09-30", "TTM"}) {
+			t.Fatalf("Total Revenue value order = %v, want oldest first with TTM last", got)
+		}
+		if revenue[0].Value == nil || *revenue[0].Value != 383285 {
+			t.Errorf("Total Revenue 9/30/2023 = %v, want 383285", revenue[0].Value)
+		}
+
+		grossProfit := statement.Rows[2].Values
+		if grossProfit[0].Date != "2023-06-30" || grossProfit[0].Value != nil || grossProfit[0].Raw != "*" {
+			t.Errorf("Gross Profit placeholder = %+v, want 2023-06-30 with a nil value and raw \"*\"", grossProfit[0])
+		}
+	}
+}
+
+func TestSortStatementDates(t *testing.T) {
+	dates := []string{"TTM", "2024-09-30", "12/31/2021", "2022-09-24", "Annual", "01/15/2023"}
+	sortStatementDates(dates)
+	want := []string{"12/31/2021", "2022-09-24", "01/15/2023", "2024-09-30", "Annual", "TTM"}
+	if !reflect.DeepEqual(dates, want) {
+		t.Errorf("sortStatementDates = %v, want %v", dates, want)
+	}
+}
+
+func datedValueDates(values []DatedValue) []string {
+	dates := make([]string, 0, len(values))
+	for _, v := range values {
+		dates = append(dates, v.Date)
+	}
+	return dates
+}
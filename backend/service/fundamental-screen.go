@@ -0,0 +1,339 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"screener/backend/model"
+	"strings"
+	"time"
+)
+
+// metricsSetTTL is how long parsed metrics for a statement type/frequency are reused in-process,
+// so the revenue-growth, EPS, margin and combined screens requested together share one load
+const metricsSetTTL = 30 * time.Second
+
+// metricsSet is one (possibly in-flight) load of parsed metrics
+type metricsSet struct {
+	done     chan struct{}
+	metrics  []FundamentalMetrics
+	err      error
+	loadedAt time.Time
+}
+
+// loadMetrics loads every statement for a type/frequency and computes its metrics, parsing once
+// and reusing the result for metricsSetTTL. Concurrent callers wait on the same load.
+// Statements whose metrics fail to compute are skipped. Callers must not modify the returned metrics.
+func (s *FundamentalDataService) loadMetrics(statementType, frequency string) ([]FundamentalMetrics, error) {
+	key := statementType + "|" + frequency
+
+	s.metricsMu.Lock()
+	set, ok := s.metricsSets[key]
+	if ok {
+		select {
+		case <-set.done:
+			if set.err != nil || time.Since(set.loadedAt) > metricsSetTTL {
+				ok = false
+			}
+		default:
+			// Load in flight; wait for it below
+		}
+	}
+	if !ok {
+		set = &metricsSet{done: make(chan struct{})}
+		s.metricsSets[key] = set
+		s.metricsMu.Unlock()
+
+		set.metrics, set.err = s.computeMetrics(statementType, frequency)
+		set.loadedAt = time.Now()
+		close(set.done)
+		return set.metrics, set.err
+	}
+	s.metricsMu.Unlock()
+
+	<-set.done
+	return set.metrics, set.err
+}
+
+// computeMetrics loads and parses all statements for a type/frequency
+func (s *FundamentalDataService) computeMetrics(statementType, frequency string) ([]FundamentalMetrics, error) {
+	fundamentalData, err := s.FilterFundamentalData(FundamentalDataFilter{
+		StatementTypes: []string{statementType},
+		Frequencies:    []string{frequency},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allMetrics := make([]FundamentalMetrics, 0, len(fundamentalData))
+	for i := range fundamentalData {
+		metrics, err := s.calculateMetrics(&fundamentalData[i])
+		if err != nil {
+			continue // Skip if metrics calculation fails
+		}
+		allMetrics = append(allMetrics, *metrics)
+	}
+
+	return allMetrics, nil
+}
+
+// MarginCriterion bounds one margin type ("gross", "operating" or "net")
+type MarginCriterion struct {
+	MarginType string   `json:"marginType"`
+	MinMargin  *float64 `json:"minMargin,omitempty"`
+	MaxMargin  *float64 `json:"maxMargin,omitempty"`
+	Date       string   `json:"date,omitempty"` // Specific date or latest if empty
+}
+
+// FundamentalScreenFilter combines revenue growth, EPS and margin criteria; every criterion set must match
+type FundamentalScreenFilter struct {
+	StatementType string            `json:"statementType"`
+	Frequency     string            `json:"frequency"`
+	MinQoQGrowth  *float64          `json:"minQoQGrowth,omitempty"`
+	MaxQoQGrowth  *float64          `json:"maxQoQGrowth,omitempty"`
+	MinYoYGrowth  *float64          `json:"minYoYGrowth,omitempty"`
+	MaxYoYGrowth  *float64          `json:"maxYoYGrowth,omitempty"`
+	MinEPS        *float64          `json:"minEPS,omitempty"`
+	MaxEPS        *float64          `json:"maxEPS,omitempty"`
+	EPSDate       string            `json:"epsDate,omitempty"` // Specific date or latest if empty
+	Margins       []MarginCriterion `json:"margins,omitempty"`
+}
+
+// Validate checks the statement selection and margin types
+func (f FundamentalScreenFilter) Validate() error {
+	if f.StatementType == "" || f.Frequency == "" {
+		return errors.New("statementType and frequency are required")
+	}
+	for _, m := range f.Margins {
+		if marginSeries(&FundamentalMetrics{}, m.MarginType) == nil {
+			return fmt.Errorf("invalid marginType: %s (expected gross, operating or net)", m.MarginType)
+		}
+	}
+	return nil
+}
+
+// ScreenFundamentals returns the metrics of every symbol matching all criteria in the filter.
+// Statements for the type/frequency are loaded and parsed once, then each criterion is
+// evaluated against the computed metrics.
+func (s *FundamentalDataService) ScreenFundamentals(filter FundamentalScreenFilter) ([]FundamentalMetrics, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	allMetrics, err := s.loadMetrics(filter.StatementType, filter.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FundamentalMetrics, 0)
+	for i := range allMetrics {
+		metrics := &allMetrics[i]
+
+		if !matchesGrowth(metrics.RevenueGrowthQoQ, filter.MinQoQGrowth, filter.MaxQoQGrowth) ||
+			!matchesGrowth(metrics.RevenueGrowthYoY, filter.MinYoYGrowth, filter.MaxYoYGrowth) {
+			continue
+		}
+
+		if filter.MinEPS != nil || filter.MaxEPS != nil {
+			eps, found := periodValue(metrics.EPS, filter.EPSDate)
+			if !found || !inRange(eps, filter.MinEPS, filter.MaxEPS) {
+				continue
+			}
+		}
+
+		if !matchesMargins(metrics, filter.Margins) {
+			continue
+		}
+
+		results = append(results, *metrics)
+	}
+
+	return results, nil
+}
+
+// FundamentalScreenValues holds every value the combined fundamental screen evaluates for a symbol
+// EPS and margins are taken at the filter's dates (latest period when unset); nil means no data
+type FundamentalScreenValues struct {
+	Symbol           string   `json:"symbol"`
+	RevenueGrowthQoQ *float64 `json:"revenueGrowthQoQ"`
+	RevenueGrowthYoY *float64 `json:"revenueGrowthYoY"`
+	EPS              *float64 `json:"eps"`
+	GrossMargin      *float64 `json:"grossMargin"`
+	OperatingMargin  *float64 `json:"operatingMargin"`
+	NetMargin        *float64 `json:"netMargin"`
+}
+
+// ExplainFundamentalScreen returns the evaluated values for each screen result, in the same order
+func ExplainFundamentalScreen(filter FundamentalScreenFilter, results []FundamentalMetrics) []FundamentalScreenValues {
+	marginDates := make(map[string]string, len(filter.Margins))
+	for _, m := range filter.Margins {
+		marginDates[strings.ToLower(m.MarginType)] = m.Date
+	}
+
+	explained := make([]FundamentalScreenValues, 0, len(results))
+	for i := range results {
+		metrics := &results[i]
+		explained = append(explained, FundamentalScreenValues{
+			Symbol:           metrics.Symbol,
+			RevenueGrowthQoQ: metrics.RevenueGrowthQoQ,
+			RevenueGrowthYoY: metrics.RevenueGrowthYoY,
+			EPS:              optionalPeriodValue(metrics.EPS, filter.EPSDate),
+			GrossMargin:      optionalPeriodValue(marginSeries(metrics, "gross"), marginDates["gross"]),
+			OperatingMargin:  optionalPeriodValue(marginSeries(metrics, "operating"), marginDates["operating"]),
+			NetMargin:        optionalPeriodValue(marginSeries(metrics, "net"), marginDates["net"]),
+		})
+	}
+	return explained
+}
+
+// optionalPeriodValue is periodValue returning nil when there is no value for the period
+func optionalPeriodValue(values map[string]float64, date string) *float64 {
+	value, found := periodValue(values, date)
+	if !found {
+		return nil
+	}
+	return &value
+}
+
+// matchesGrowth reports whether a growth rate satisfies optional bounds
+// A missing growth rate only matches when no bound is set
+func matchesGrowth(growth, min, max *float64) bool {
+	if min == nil && max == nil {
+		return true
+	}
+	if growth == nil {
+		return false
+	}
+	return inRange(*growth, min, max)
+}
+
+// matchesMargins reports whether every margin criterion is satisfied
+func matchesMargins(metrics *FundamentalMetrics, criteria []MarginCriterion) bool {
+	for _, c := range criteria {
+		margin, found := periodValue(marginSeries(metrics, c.MarginType), c.Date)
+		if !found || !inRange(margin, c.MinMargin, c.MaxMargin) {
+			return false
+		}
+	}
+	return true
+}
+
+// marginSeries returns the margin map for a margin type, or nil for an unknown type
+func marginSeries(metrics *FundamentalMetrics, marginType string) map[string]float64 {
+	switch strings.ToLower(marginType) {
+	case "gross":
+		if metrics.GrossProfitMargin == nil {
+			return map[string]float64{}
+		}
+		return metrics.GrossProfitMargin
+	case "operating":
+		if metrics.OperatingMargin == nil {
+			return map[string]float64{}
+		}
+		return metrics.OperatingMargin
+	case "net":
+		if metrics.NetMargin == nil {
+			return map[string]float64{}
+		}
+		return metrics.NetMargin
+	}
+	return nil
+}
+
+// periodValue returns the value for a specific date, or for the most recent dated period
+// (see latestDatedValue; labels such as "TTM" are skipped) when date is empty
+func periodValue(values map[string]float64, date string) (float64, bool) {
+	if date != "" {
+		value, found := values[date]
+		return value, found
+	}
+	_, value, found := latestDatedValue(values)
+	return value, found
+}
+
+// inRange reports whether value lies within optional inclusive bounds
+func inRange(value float64, min, max *float64) bool {
+	if min != nil && value < *min {
+		return false
+	}
+	if max != nil && value > *max {
+		return false
+	}
+	return true
+}
+
+// trailingPeriods is how many statement periods make up a trailing year at a frequency
+func trailingPeriods(frequency string) (int, error) {
+	switch frequency {
+	case "annual":
+		return 1, nil
+	case "quarterly":
+		return 4, nil
+	}
+	return 0, errors.New("frequency must be annual or quarterly")
+}
+
+// loadStatementMetrics loads the metrics for several statement types of one frequency, keyed by
+// statement type then symbol, for screens that join values across statements
+func (s *FundamentalDataService) loadStatementMetrics(frequency string, statementTypes ...string) (map[string]map[string]*FundamentalMetrics, error) {
+	statements := make(map[string]map[string]*FundamentalMetrics, len(statementTypes))
+	for _, statementType := range statementTypes {
+		metrics, err := s.loadMetrics(statementType, frequency)
+		if err != nil {
+			return nil, err
+		}
+		bySymbol := make(map[string]*FundamentalMetrics, len(metrics))
+		for i := range metrics {
+			bySymbol[metrics[i].Symbol] = &metrics[i]
+		}
+		statements[statementType] = bySymbol
+	}
+	return statements, nil
+}
+
+// screenerCloses returns the latest screener close per symbol
+func (s *FundamentalDataService) screenerCloses() (map[string]float64, error) {
+	var closes []model.Screener
+	if err := s.db.Select("symbol", "close").Find(&closes).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch prices: %w", err)
+	}
+	prices := make(map[string]float64, len(closes))
+	for _, row := range closes {
+		prices[row.Symbol] = row.Close
+	}
+	return prices, nil
+}
+
+// datedPeriods returns the dated periods of a series, oldest first; labels such as "TTM" are dropped
+func datedPeriods(values map[string]float64) []string {
+	dates := make([]string, 0, len(values))
+	for date := range values {
+		if _, ok := parseStatementDate(date); ok {
+			dates = append(dates, date)
+		}
+	}
+	sortStatementDates(dates)
+	return dates
+}
+
+// latestDatedValue returns the most recent dated period and its value
+func latestDatedValue(values map[string]float64) (string, float64, bool) {
+	dates := datedPeriods(values)
+	if len(dates) == 0 {
+		return "", 0, false
+	}
+	latest := dates[len(dates)-1]
+	return latest, values[latest], true
+}
+
+// trailingSum sums the latest n dated periods, returning the latest period; it fails with fewer than n
+func trailingSum(values map[string]float64, n int) (float64, string, bool) {
+	dates := datedPeriods(values)
+	if len(dates) < n {
+		return 0, "", false
+	}
+	sum := 0.0
+	for _, date := range dates[len(dates)-n:] {
+		sum += values[date]
+	}
+	return sum, dates[len(dates)-1], true
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// benchmarkStatementRows returns n quarterly income statements with eight periods each
+func benchmarkStatementRows(n int) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"symbol", "statement_type", "frequency", "statement"})
+	periods := []string{"2023-03-31", "2023-06-30", "2023-09-30", "2023-12-31", "2024-03-31", "2024-06-30", "2024-09-30", "2024-12-31"}
+	for i := 0; i < n; i++ {
+		statement := `{`
+		for j, breakdown := range []string{"Total Revenue", "Gross Profit", "Operating Income", "Net Income Common Stockholders", "Diluted EPS", "Diluted Average Shares"} {
+			if j > 0 {
+				statement += ","
+			}
+			statement += fmt.Sprintf(`"%d": {"Breakdown": %q`, j, breakdown)
+			for k, period := range periods {
+				statement += fmt.Sprintf(`, %q: "%d,%03d.%d"`, period, 10+i%50, 100*(j+1)+k, k)
+			}
+			statement += `}`
+		}
+		statement += `}`
+		rows.AddRow(fmt.Sprintf("SYM%d", i), "income", "quarterly", statement)
+	}
+	return rows
+}
+
+// runFundamentalScreens runs the four screens a screener page requests together for one statement selection
+func runFundamentalScreens(b *testing.B, s *FundamentalDataService, beforeEach func()) {
+	minGrowth, minEPS, minMargin := 0.0, 1.0, 10.0
+	screens := []func() error{
+		func() error {
+			_, err := s.GetStocksWithRevenueGrowth(RevenueGrowthFilter{MinYoYGrowth: &minGrowth, StatementType: "income", Frequency: "quarterly"})
+			return err
+		},
+		func() error {
+			_, err := s.GetStocksWithEPSRange(EPSFilter{MinEPS: &minEPS, StatementType: "income", Frequency: "quarterly"})
+			return err
+		},
+		func() error {
+			_, err := s.GetStocksWithMarginRange(MarginFilter{MarginType: "net", MinMargin: &minMargin, StatementType: "income", Frequency: "quarterly"})
+			return err
+		},
+		func() error {
+			_, err := s.ScreenFundamentals(FundamentalScreenFilter{StatementType: "income", Frequency: "quarterly", MinEPS: &minEPS,
+				Margins: []MarginCriterion{{MarginType: "gross", MinMargin: &minMargin}}})
+			return err
+		},
+	}
+	for _, screen := range screens {
+		beforeEach()
+		if err := screen(); err != nil {
+			b.Fatalf("screen returned error: %v", err)
+		}
+	}
+}
+
+// BenchmarkFundamentalScreens compares the four screens sharing one parsed-metrics load (loadMetrics)
+// against each screen loading and parsing every statement itself
+func BenchmarkFundamentalScreens(b *testing.B) {
+	const symbols = 500
+	query := regexp.QuoteMeta(`SELECT * FROM "fundamental_data" WHERE statement_type IN ($1) AND frequency IN ($2)`)
+
+	b.Run("shared load", func(b *testing.B) {
+		db, mock := newMockDB(b)
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			mock.ExpectQuery(query).WillReturnRows(benchmarkStatementRows(symbols))
+			s := &FundamentalDataService{db: db, metricsSets: make(map[string]*metricsSet)}
+			b.StartTimer()
+
+			runFundamentalScreens(b, s, func() {})
+		}
+	})
+
+	b.Run("load per filter", func(b *testing.B) {
+		db, mock := newMockDB(b)
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			for j := 0; j < 4; j++ {
+				mock.ExpectQuery(query).WillReturnRows(benchmarkStatementRows(symbols))
+			}
+			s := &FundamentalDataService{db: db, metricsSets: make(map[string]*metricsSet)}
+			b.StartTimer()
+
+			// Dropping the memoized set before each screen makes every screen reload and reparse
+			runFundamentalScreens(b, s, func() { s.metricsSets = make(map[string]*metricsSet) })
+		}
+	})
+}
+
+func TestPeriodValueDefaultsToTheLatestPeriod(t *testing.T) {
+	values := map[string]float64{"2024-12-31": 2.4, "2023-12-31": 1.1, "TTM": 9.9, "2024-06-30": 1.8}
+	tests := []struct {
+		date      string
+		want      float64
+		wantFound bool
+	}{
+		{"", 2.4, true}, // Latest dated period; TTM is not a period
+		{"2023-12-31", 1.1, true},
+		{"TTM", 9.9, true},
+		{"2022-12-31", 0, false},
+	}
+	for _, tt := range tests {
+		got, found := periodValue(values, tt.date)
+		if got != tt.want || found != tt.wantFound {
+			t.Errorf("periodValue(%q) = %v, %v; want %v, %v", tt.date, got, found, tt.want, tt.wantFound)
+		}
+	}
+	if _, found := periodValue(map[string]float64{"TTM": 1}, ""); found {
+		t.Error("periodValue of a series without dated periods found a value, want none")
+	}
+}
+
+func TestScreenFundamentalsFiltersOnTheLatestPeriod(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "fundamental_data" WHERE statement_type IN ($1) AND frequency IN ($2)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"symbol", "statement_type", "frequency", "statement"}).
+			// GROWN's EPS rose past the floor; FADED's fell below it
+			AddRow("GROWN", "income", "quarterly", `{"0": {"Breakdown": "Diluted EPS", "2024-03-31": "0.50", "2024-06-30": "0.80", "2024-09-30": "1.50"}}`).
+			AddRow("FADED", "income", "quarterly", `{"0": {"Breakdown": "Diluted EPS", "2024-03-31": "1.50", "2024-06-30": "0.80", "2024-09-30": "0.50"}}`))
+
+	s := &FundamentalDataService{db: db, metricsSets: make(map[string]*metricsSet)}
+	minEPS := 1.0
+	results, err := s.ScreenFundamentals(FundamentalScreenFilter{StatementType: "income", Frequency: "quarterly", MinEPS: &minEPS})
+	if err != nil {
+		t.Fatalf("ScreenFundamentals returned error: %v", err)
+	}
+	if len(results) != 1 || results[0].Symbol != "GROWN" {
+		t.Errorf("results = %+v, want only GROWN, whose latest EPS is 1.50", results)
+	}
+}
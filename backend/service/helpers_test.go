@@ -0,0 +1,103 @@
+package service
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"screener/backend/service/caching"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newMockDB returns a Postgres-dialect gorm.DB backed by sqlmock; unmet expectations fail the test
+func newMockDB(t testing.TB) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("failed to open gorm on sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet database expectations: %v", err)
+		}
+		_ = sqlDB.Close()
+	})
+	return db, mock
+}
+
+// newTestRedis points the caching package at an in-process Redis for the duration of the test.
+// Afterwards the client is closed, so later tests see Redis as down.
+func newTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+	server := miniredis.RunT(t)
+	t.Setenv("REDIS_URL", "redis://"+server.Addr())
+	if err := caching.InitRedis(); err != nil {
+		t.Fatalf("failed to connect to miniredis: %v", err)
+	}
+	t.Cleanup(func() { _ = caching.CloseRedis() })
+	return server
+}
+
+// float64Ptr returns a pointer to v
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+// historicalInsertColumns is how many parameters one bar takes in an INSERT INTO "historical"
+const historicalInsertColumns = 14
+
+// argCapture is a sqlmock argument matcher that accepts any value and records it
+type argCapture struct {
+	into *[]driver.Value
+}
+
+func (a argCapture) Match(v driver.Value) bool {
+	*a.into = append(*a.into, v)
+	return true
+}
+
+// captureArgs returns n matchers recording the statement's arguments, in order, into into
+func captureArgs(n int, into *[]driver.Value) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = argCapture{into: into}
+	}
+	return args
+}
+
+// expectHistoricalUpsert expects one INSERT INTO "historical" ... ON CONFLICT of rows bars and
+// records its arguments (14 per bar, then the deleted_at assignment)
+func expectHistoricalUpsert(mock sqlmock.Sqlmock, rows int, into *[]driver.Value) {
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "historical"`) + `.*` + regexp.QuoteMeta(`ON CONFLICT ("symbol","epoch","range","interval")`)).
+		WithArgs(captureArgs(rows*historicalInsertColumns+1, into)...).
+		WillReturnRows(returnedIDs(rows))
+}
+
+// returnedIDs is the RETURNING "id" result of an insert of n rows
+func returnedIDs(n int) *sqlmock.Rows {
+	ids := sqlmock.NewRows([]string{"id"})
+	for i := 0; i < n; i++ {
+		ids.AddRow(uuid.New())
+	}
+	return ids
+}
+
+// historicalArgColumn returns column col (0-based, in insert order) of every row in captured args
+func historicalArgColumn(args []driver.Value, col int) []driver.Value {
+	rows := (len(args) - 1) / historicalInsertColumns
+	values := make([]driver.Value, 0, rows)
+	for i := col; i < rows*historicalInsertColumns; i += historicalInsertColumns {
+		values = append(values, args[i])
+	}
+	return values
+}
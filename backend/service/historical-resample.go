@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"screener/backend/model"
+	"sort"
+	"time"
+)
+
+// IsResampleInterval reports whether the interval can be produced from 1d bars by ResampleHistorical
+func IsResampleInterval(interval string) bool {
+	return interval == "1wk" || interval == "1mo"
+}
+
+// ResampleHistorical aggregates daily bars into weekly (1wk) or monthly (1mo) OHLCV bars.
+// Bars are grouped by calendar week (Monday start) or month in the market timezone:
+// open is the first bar's open, high/low the extremes, close (and adjClose) the last bar's,
+// and volume the sum. Each resampled bar takes the epoch of its first daily bar.
+func ResampleHistorical(rows []model.Historical, targetInterval string) ([]model.Historical, error) {
+	if !IsResampleInterval(targetInterval) {
+		return nil, fmt.Errorf("unsupported resample interval: %s (supported: 1wk, 1mo)", targetInterval)
+	}
+	if len(rows) == 0 {
+		return []model.Historical{}, nil
+	}
+
+	sorted := make([]model.Historical, len(rows))
+	copy(sorted, rows)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Epoch < sorted[j].Epoch })
+
+	loc := marketLocation()
+	result := make([]model.Historical, 0)
+	var current *model.Historical
+	var currentPeriod time.Time
+
+	for _, row := range sorted {
+		period := resamplePeriodStart(time.Unix(row.Epoch, 0).In(loc), targetInterval)
+
+		if current == nil || !period.Equal(currentPeriod) {
+			if current != nil {
+				result = append(result, *current)
+			}
+			currentPeriod = period
+			current = &model.Historical{
+				Symbol:   row.Symbol,
+				Epoch:    row.Epoch,
+				Range:    row.Range,
+				Interval: targetInterval,
+				Open:     row.Open,
+				High:     row.High,
+				Low:      row.Low,
+				Close:    row.Close,
+				AdjClose: row.AdjClose,
+				Volume:   row.Volume,
+			}
+			continue
+		}
+
+		if row.High > current.High {
+			current.High = row.High
+		}
+		if row.Low < current.Low {
+			current.Low = row.Low
+		}
+		current.Close = row.Close
+		current.AdjClose = row.AdjClose
+		current.Volume += row.Volume
+	}
+	result = append(result, *current)
+
+	return result, nil
+}
+
+// resamplePeriodStart returns the start of the calendar week (Monday) or month containing t
+func resamplePeriodStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if interval == "1mo" {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	// time.Weekday is Sunday=0; shift so Monday starts the week
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"screener/backend/model"
+)
+
+// sessionEpoch returns the epoch of the 09:30 open in the market timezone on the given date
+func sessionEpoch(year int, month time.Month, day int) int64 {
+	return time.Date(year, month, day, 9, 30, 0, 0, marketLocation()).Unix()
+}
+
+// weekdayBars returns one 1d bar for every weekday from start through end; bar i opens at 100+i,
+// closes at 100.5+i, ranges ±10 around that and trades 1000 shares
+func weekdayBars(start, end time.Time) []model.Historical {
+	bars := make([]model.Historical, 0)
+	for day, i := start, 0; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		price := 100 + float64(i)
+		bars = append(bars, model.Historical{
+			Symbol: "AAPL", Epoch: sessionEpoch(day.Year(), day.Month(), day.Day()), Range: "1y", Interval: "1d",
+			Open: price, High: price + 10, Low: price - 10, Close: price + 0.5, AdjClose: float64Ptr(price + 0.25), Volume: 1000,
+		})
+		i++
+	}
+	return bars
+}
+
+type resampledBar struct {
+	epoch           int64
+	open, high, low float64
+	close, adjClose float64
+	volume          int64
+}
+
+func assertResampled(t *testing.T, got []model.Historical, interval string, want []resampledBar) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("resampled into %d bars, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Interval != interval || g.Symbol != "AAPL" || g.Range != "1y" {
+			t.Errorf("bar %d is %s %s/%s, want AAPL 1y/%s", i, g.Symbol, g.Range, g.Interval, interval)
+		}
+		if g.AdjClose == nil {
+			t.Errorf("bar %d AdjClose = nil, want %v", i, w.adjClose)
+			continue
+		}
+		if g.Epoch != w.epoch || g.Open != w.open || g.High != w.high || g.Low != w.low ||
+			g.Close != w.close || *g.AdjClose != w.adjClose || g.Volume != w.volume {
+			t.Errorf("bar %d = {epoch %s O %v H %v L %v C %v adj %v V %d}, want {epoch %s O %v H %v L %v C %v adj %v V %d}", i,
+				time.Unix(g.Epoch, 0).In(marketLocation()).Format("2006-01-02"), g.Open, g.High, g.Low, g.Close, *g.AdjClose, g.Volume,
+				time.Unix(w.epoch, 0).In(marketLocation()).Format("2006-01-02"), w.open, w.high, w.low, w.close, w.adjClose, w.volume)
+		}
+	}
+}
+
+func TestResampleHistoricalWeeks(t *testing.T) {
+	loc := marketLocation()
+	// Wed 2024-01-24 through Tue 2024-02-13: a partial first week, two full weeks (one spanning
+	// the month boundary) and a partial last week
+	bars := weekdayBars(time.Date(2024, 1, 24, 0, 0, 0, 0, loc), time.Date(2024, 2, 13, 0, 0, 0, 0, loc))
+	// A spike mid-week must become that week's high, and a dip its low
+	bars[5].High, bars[6].Low = 250, 20
+
+	// Input order doesn't matter
+	reversed := make([]model.Historical, len(bars))
+	for i := range bars {
+		reversed[len(bars)-1-i] = bars[i]
+	}
+
+	got, err := ResampleHistorical(reversed, "1wk")
+	if err != nil {
+		t.Fatalf("ResampleHistorical returned error: %v", err)
+	}
+	assertResampled(t, got, "1wk", []resampledBar{
+		// Wed 01-24 .. Fri 01-26 (bars 0-2)
+		{sessionEpoch(2024, 1, 24), 100, 112, 90, 102.5, 102.25, 3000},
+		// Mon 01-29 .. Fri 02-02 (bars 3-7), across the month boundary
+		{sessionEpoch(2024, 1, 29), 103, 250, 20, 107.5, 107.25, 5000},
+		// Mon 02-05 .. Fri 02-09 (bars 8-12)
+		{sessionEpoch(2024, 2, 5), 108, 122, 98, 112.5, 112.25, 5000},
+		// Mon 02-12 .. Tue 02-13 (bars 13-14)
+		{sessionEpoch(2024, 2, 12), 113, 124, 103, 114.5, 114.25, 2000},
+	})
+}
+
+func TestResampleHistoricalMonths(t *testing.T) {
+	loc := marketLocation()
+	// Mon 2024-01-29 through Fri 2024-03-01
+	bars := weekdayBars(time.Date(2024, 1, 29, 0, 0, 0, 0, loc), time.Date(2024, 3, 1, 0, 0, 0, 0, loc))
+
+	got, err := ResampleHistorical(bars, "1mo")
+	if err != nil {
+		t.Fatalf("ResampleHistorical returned error: %v", err)
+	}
+	assertResampled(t, got, "1mo", []resampledBar{
+		// 01-29 .. 01-31 (bars 0-2)
+		{sessionEpoch(2024, 1, 29), 100, 112, 90, 102.5, 102.25, 3000},
+		// 02-01 .. 02-29, leap day included (bars 3-23)
+		{sessionEpoch(2024, 2, 1), 103, 133, 93, 123.5, 123.25, 21000},
+		// 03-01 (bar 24)
+		{sessionEpoch(2024, 3, 1), 124, 134, 114, 124.5, 124.25, 1000},
+	})
+}
+
+func TestResampleHistoricalUsesMarketTimezone(t *testing.T) {
+	// 00:00 UTC on Mon 04-01 and Tue 04-02 share a week and a month in UTC, but in New York they are
+	// Sun 03-31 20:00 (March, week of 03-25) and Mon 04-01 20:00 (April, week of 04-01)
+	utcMidnight := func(month time.Month, day int) int64 {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC).Unix()
+	}
+	bars := []model.Historical{
+		{Symbol: "AAPL", Range: "1y", Interval: "1d", Epoch: utcMidnight(4, 1), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1},
+		{Symbol: "AAPL", Range: "1y", Interval: "1d", Epoch: utcMidnight(4, 2), Open: 2, High: 2, Low: 2, Close: 2, Volume: 1},
+	}
+
+	for _, interval := range []string{"1wk", "1mo"} {
+		got, err := ResampleHistorical(bars, interval)
+		if err != nil {
+			t.Fatalf("ResampleHistorical(%s) returned error: %v", interval, err)
+		}
+		if len(got) != 2 {
+			t.Errorf("ResampleHistorical(%s) produced %d bars, want the two New York dates in separate periods", interval, len(got))
+		}
+	}
+
+	if start := resamplePeriodStart(time.Unix(utcMidnight(4, 1), 0).In(marketLocation()), "1mo"); start.Month() != time.March {
+		t.Errorf("period of 2024-04-01 00:00 UTC starts in %s, want March in New York", start.Month())
+	}
+}
+
+func TestResampleHistoricalRejectsUnsupportedInterval(t *testing.T) {
+	if _, err := ResampleHistorical(weekdayBars(time.Now(), time.Now().AddDate(0, 0, 7)), "1h"); err == nil {
+		t.Error("ResampleHistorical(1h) returned nil error, want unsupported interval")
+	}
+	got, err := ResampleHistorical(nil, "1wk")
+	if err != nil || len(got) != 0 {
+		t.Errorf("ResampleHistorical(nil) = %v, %v; want an empty result", got, err)
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historicalPruneBatchSize bounds each DELETE so pruning never holds long row locks
+const historicalPruneBatchSize = 5000
+
+// ErrNoRetentionPolicy is returned when pruning an interval that has no retention and no explicit age
+var ErrNoRetentionPolicy = errors.New("no retention policy")
+
+// defaultHistoricalRetention is how long intraday bars are kept per interval. Daily and longer
+// bars have no default: the 10y backfill re-caches them on every ingestion, so they are only
+// pruned with an explicit older_than (and come back on the next backfill within its range).
+var defaultHistoricalRetention = map[string]time.Duration{
+	"1m":  7 * 24 * time.Hour,
+	"5m":  30 * 24 * time.Hour,
+	"15m": 60 * 24 * time.Hour,
+	"30m": 90 * 24 * time.Hour,
+	"1h":  180 * 24 * time.Hour,
+}
+
+// HistoricalPruneResult reports what one prune removed, per interval
+type HistoricalPruneResult struct {
+	Intervals []HistoricalIntervalPrune `json:"intervals"`
+	Removed   int64                     `json:"removed"`
+}
+
+// HistoricalIntervalPrune is the outcome of pruning one interval
+type HistoricalIntervalPrune struct {
+	Interval  string    `json:"interval"`
+	OlderThan string    `json:"older_than"`
+	Cutoff    time.Time `json:"cutoff"` // Bars with an epoch before this were deleted
+	Removed   int64     `json:"removed"`
+}
+
+// ParseRetentionAge parses an age like "90d" (days) or any time.ParseDuration value ("720h")
+func ParseRetentionAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q: must be a positive number of days like 90d", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("invalid age %q: use days (90d) or a duration (720h)", value)
+	}
+	return age, nil
+}
+
+// HistoricalRetentionPolicies returns the retention per interval: the defaults, overridden by
+// HISTORICAL_RETENTION (comma-separated interval=age pairs, e.g. "1m=3d,30m=180d,1d=3650d")
+func HistoricalRetentionPolicies() map[string]time.Duration {
+	policies := make(map[string]time.Duration, len(defaultHistoricalRetention))
+	for interval, age := range defaultHistoricalRetention {
+		policies[interval] = age
+	}
+
+	for _, entry := range strings.Split(os.Getenv("HISTORICAL_RETENTION"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		interval, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			log.Printf("Warning: ignoring HISTORICAL_RETENTION entry %q: expected interval=age", entry)
+			continue
+		}
+		age, err := ParseRetentionAge(value)
+		if err != nil {
+			log.Printf("Warning: ignoring HISTORICAL_RETENTION entry %q: %v", entry, err)
+			continue
+		}
+		policies[strings.TrimSpace(interval)] = age
+	}
+	return policies
+}
+
+// PruneHistorical hard-deletes bars older than their retention. With an interval, only that
+// interval is pruned, using olderThan when set and its retention policy otherwise; without one,
+// every interval with a policy is pruned (olderThan must then be zero). Rows are deleted in
+// batches of historicalPruneBatchSize, stopping early when ctx is done.
+func (s *HistoricalService) PruneHistorical(ctx context.Context, interval string, olderThan time.Duration) (*HistoricalPruneResult, error) {
+	policies := HistoricalRetentionPolicies()
+
+	targets := make(map[string]time.Duration)
+	switch {
+	case interval != "" && olderThan > 0:
+		targets[interval] = olderThan
+	case interval != "":
+		age, ok := policies[interval]
+		if !ok {
+			return nil, fmt.Errorf("%w for interval %s; pass older_than", ErrNoRetentionPolicy, interval)
+		}
+		targets[interval] = age
+	case olderThan > 0:
+		return nil, errors.New("older_than requires an interval")
+	default:
+		targets = policies
+	}
+
+	intervals := make([]string, 0, len(targets))
+	for name := range targets {
+		intervals = append(intervals, name)
+	}
+	sort.Strings(intervals)
+
+	result := &HistoricalPruneResult{Intervals: make([]HistoricalIntervalPrune, 0, len(intervals))}
+	now := time.Now().UTC()
+	for _, name := range intervals {
+		age := targets[name]
+		prune := HistoricalIntervalPrune{Interval: name, OlderThan: age.String(), Cutoff: now.Add(-age)}
+		removed, err := s.deleteHistoricalBefore(ctx, name, prune.Cutoff.Unix())
+		prune.Removed = removed
+		result.Intervals = append(result.Intervals, prune)
+		result.Removed += removed
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if result.Removed > 0 {
+		log.Printf("Pruned %d historical rows", result.Removed)
+	}
+	return result, nil
+}
+
+// deleteHistoricalBefore deletes interval's bars with an epoch before cutoff, one batch at a time
+func (s *HistoricalService) deleteHistoricalBefore(ctx context.Context, interval string, cutoff int64) (int64, error) {
+	var removed int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return removed, err
+		}
+		batch := s.db.WithContext(ctx).Exec(`
+			DELETE FROM historical
+			WHERE id IN (
+				SELECT id FROM historical
+				WHERE "interval" = ? AND epoch < ?
+				LIMIT ?
+			)`, interval, cutoff, historicalPruneBatchSize)
+		if batch.Error != nil {
+			return removed, fmt.Errorf("failed to prune %s historical rows: %w", interval, batch.Error)
+		}
+		removed += batch.RowsAffected
+		if batch.RowsAffected < historicalPruneBatchSize {
+			return removed, nil
+		}
+	}
+}
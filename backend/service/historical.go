@@ -1,10 +1,10 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"screener/backend/database"
 	"screener/backend/model"
 	"screener/backend/service/caching"
@@ -12,22 +12,28 @@ import (
 	"time"
 
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // HistoricalService contains business logic for historical price operations
 type HistoricalService struct {
-	db    *gorm.DB
-	cache *caching.CacheService
-	ttl   *caching.CacheTTLConfig
+	db          *gorm.DB
+	cache       *caching.CacheService
+	ttl         *caching.CacheTTLConfig
+	writeBehind historicalWriteBehind
+}
+
+// historicalWriteBehind queues bars for the persistence worker (caching.DataCache in production)
+type historicalWriteBehind interface {
+	CacheHistorical(symbol, rangeParam, interval string, data []model.Historical) error
 }
 
 // NewHistoricalService creates a new instance of HistoricalService
 func NewHistoricalService() *HistoricalService {
 	return &HistoricalService{
-		db:    database.GetDB(),
-		cache: caching.NewCacheService(),
-		ttl:   caching.GetTTLConfig(),
+		db:          database.GetDB(),
+		cache:       caching.NewCacheService(),
+		ttl:         caching.GetTTLConfig(),
+		writeBehind: caching.NewDataCache(),
 	}
 }
 
@@ -97,6 +103,40 @@ func (s *HistoricalService) GetHistoricalBySymbolRangeInterval(symbol, rangePara
 	return []model.Historical{}, nil
 }
 
+// GetLatestHistorical returns the newest bar for symbol, range, and interval
+// Bars written by ingestion live in Redis until the persistence job saves them, so a cached series
+// is checked first; otherwise one ORDER BY epoch DESC LIMIT 1 query (backed by the symbol/epoch
+// index) avoids loading the whole series. Returns "no historical data" when nothing is stored.
+func (s *HistoricalService) GetLatestHistorical(symbol, rangeParam, interval string) (*model.Historical, error) {
+	if symbol == "" || rangeParam == "" || interval == "" {
+		return nil, errors.New("symbol, range, and interval are required")
+	}
+
+	dataCache := caching.NewDataCache()
+	if cached, found, err := dataCache.GetHistorical(symbol, rangeParam, interval); err == nil && found && len(cached) > 0 {
+		latest := cached[0]
+		for _, bar := range cached[1:] {
+			if bar.Epoch > latest.Epoch {
+				latest = bar
+			}
+		}
+		return &latest, nil
+	}
+
+	var latest model.Historical
+	err := s.db.Where("symbol = ? AND range = ? AND interval = ?", symbol, rangeParam, interval).
+		Order("epoch DESC").
+		Limit(1).
+		Take(&latest).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("no historical data")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &latest, nil
+}
+
 // CreateHistorical creates a new historical record
 func (s *HistoricalService) CreateHistorical(historical *model.Historical) error {
 	if historical == nil {
@@ -111,6 +151,9 @@ func (s *HistoricalService) CreateHistorical(historical *model.Historical) error
 	return nil
 }
 
+// MaxHistoricalBatchSize caps how many records one batch create/upsert request may carry
+const MaxHistoricalBatchSize = 10000
+
 // CreateHistoricalBatch creates multiple historical records in a single transaction
 func (s *HistoricalService) CreateHistoricalBatch(historical []model.Historical) error {
 	if len(historical) == 0 {
@@ -131,16 +174,21 @@ func (s *HistoricalService) UpsertHistorical(historical *model.Historical) error
 		return errors.New("historical record cannot be nil")
 	}
 
+	updates := map[string]interface{}{
+		"open":   historical.Open,
+		"high":   historical.High,
+		"low":    historical.Low,
+		"close":  historical.Close,
+		"volume": historical.Volume,
+	}
+	// Same rule as HistoricalUpsertClause: a missing adjusted close keeps the stored value
+	if historical.AdjClose != nil {
+		updates["adj_close"] = historical.AdjClose
+	}
+
 	result := s.db.Where("symbol = ? AND epoch = ? AND range = ? AND interval = ?",
 		historical.Symbol, historical.Epoch, historical.Range, historical.Interval).
-		Assign(map[string]interface{}{
-			"open":      historical.Open,
-			"high":      historical.High,
-			"low":       historical.Low,
-			"close":     historical.Close,
-			"adj_close": historical.AdjClose,
-			"volume":    historical.Volume,
-		}).
+		Assign(updates).
 		FirstOrCreate(historical)
 
 	if result.Error != nil {
@@ -151,53 +199,49 @@ func (s *HistoricalService) UpsertHistorical(historical *model.Historical) error
 }
 
 // UpsertHistoricalBatch saves historical records to Redis ONLY (no immediate database write)
-// Background worker will persist to database later
+// Background worker will persist to database later; while Redis is down the batch goes straight to the database
 func (s *HistoricalService) UpsertHistoricalBatch(historical []model.Historical) error {
 	if len(historical) == 0 {
 		return errors.New("historical records cannot be empty")
 	}
 
-	// Group by symbol, range, and interval
-	grouped := make(map[string][]model.Historical)
+	// Group by symbol, range, and interval, keeping first-seen order
+	type groupKey struct{ symbol, rangeParam, interval string }
+	grouped := make(map[groupKey][]model.Historical)
+	order := make([]groupKey, 0)
 	for _, h := range historical {
-		key := fmt.Sprintf("%s:%s:%s", h.Symbol, h.Range, h.Interval)
+		key := groupKey{h.Symbol, h.Range, h.Interval}
+		if _, ok := grouped[key]; !ok {
+			order = append(order, key)
+		}
 		grouped[key] = append(grouped[key], h)
 	}
 
-	// Cache each group in Redis
-	dataCache := caching.NewDataCache()
-	for key, batch := range grouped {
-		// Extract symbol, range, interval from key
-		parts := strings.Split(key, ":")
-		if len(parts) != 3 {
-			continue
-		}
-		symbol, rangeParam, interval := parts[0], parts[1], parts[2]
-		
+	// Cache each group in Redis. The request is all-or-nothing: if any group fails, the whole
+	// batch (including groups already cached) is written to the database in one transaction,
+	// so a crash can't leave part of the request only in Redis and the rest nowhere.
+	for i, key := range order {
 		// Save to Redis ONLY
-		if err := dataCache.CacheHistorical(symbol, rangeParam, interval, batch); err != nil {
-			// If Redis fails, fallback to database
-			log.Printf("Warning: Failed to cache historical data, falling back to database: %v", err)
-			return s.db.Clauses(clause.OnConflict{
-				Columns: []clause.Column{
-					{Name: "symbol"}, {Name: "epoch"}, {Name: "range"}, {Name: "interval"},
-				},
-				DoUpdates: clause.Assignments(map[string]interface{}{
-					"open":       gorm.Expr("excluded.open"),
-					"high":       gorm.Expr("excluded.high"),
-					"low":        gorm.Expr("excluded.low"),
-					"close":      gorm.Expr("excluded.close"),
-					"adj_close":  gorm.Expr("excluded.adj_close"),
-					"volume":     gorm.Expr("excluded.volume"),
-					"updated_at": gorm.Expr("NOW()"),
-				}),
-			}).CreateInBatches(batch, 100).Error
+		if err := s.writeBehind.CacheHistorical(key.symbol, key.rangeParam, key.interval, grouped[key]); err != nil {
+			log.Printf("Warning: Failed to cache historical data for %s %s/%s (group %d of %d), writing the whole batch to the database: %v",
+				key.symbol, key.rangeParam, key.interval, i+1, len(order), err)
+			return s.upsertHistoricalBatchToDB(historical)
 		}
 	}
 
 	return nil
 }
 
+// upsertHistoricalBatchToDB upserts every record in a single transaction
+func (s *HistoricalService) upsertHistoricalBatchToDB(historical []model.Historical) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(model.HistoricalUpsertClause()).CreateInBatches(historical, 100).Error; err != nil {
+			return fmt.Errorf("failed to upsert historical batch: %w", err)
+		}
+		return nil
+	})
+}
+
 // UpdateHistorical updates an existing historical record
 func (s *HistoricalService) UpdateHistorical(id string, historical *model.Historical) error {
 	if historical == nil {
@@ -294,3 +338,46 @@ func (s *HistoricalService) GetScreenerResults(resultType string, period string)
 
 	return symbols, nil
 }
+
+// DedupeHistorical removes duplicate (symbol, epoch, range, interval) rows, keeping the row with
+// the latest updated_at (preferring rows that aren't soft-deleted), then ensures the unique index
+// exists so duplicates can't reappear. Duplicates are hard-deleted since the index covers
+// soft-deleted rows too. Returns the number of rows removed.
+func (s *HistoricalService) DedupeHistorical(ctx context.Context) (int64, error) {
+	var removed int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec(`
+			DELETE FROM historical
+			WHERE id IN (
+				SELECT id FROM (
+					SELECT id,
+					       ROW_NUMBER() OVER (
+					           PARTITION BY symbol, epoch, "range", "interval"
+					           ORDER BY (deleted_at IS NULL) DESC, updated_at DESC, created_at DESC, id
+					       ) AS rn
+					FROM historical
+				) ranked
+				WHERE ranked.rn > 1
+			)`)
+		if result.Error != nil {
+			return fmt.Errorf("failed to remove duplicate historical rows: %w", result.Error)
+		}
+		removed = result.RowsAffected
+
+		if err := tx.Exec(fmt.Sprintf(
+			`CREATE UNIQUE INDEX IF NOT EXISTS %s ON historical (symbol, epoch, "range", "interval")`,
+			model.HistoricalUniqueIndex,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to ensure historical unique index: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if removed > 0 {
+		log.Printf("Removed %d duplicate historical rows", removed)
+	}
+	return removed, nil
+}
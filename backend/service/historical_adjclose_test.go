@@ -0,0 +1,122 @@
+package service
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"screener/backend/database"
+	"screener/backend/model"
+	"screener/backend/service/caching"
+)
+
+// historicalAdjCloseColumn is adj_close's position in the historical insert
+const historicalAdjCloseColumn = 8
+
+// persistThroughRedis runs bars through UpsertHistoricalBatch into Redis, reads them back, then
+// lets the persistence worker flush them, returning what came back from Redis and the insert args
+func persistThroughRedis(t *testing.T, bars []model.Historical) ([]model.Historical, []driver.Value) {
+	t.Helper()
+	newTestRedis(t)
+	db, mock := newMockDB(t)
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	s := &HistoricalService{db: db, writeBehind: caching.NewDataCache()}
+	if err := s.UpsertHistoricalBatch(bars); err != nil {
+		t.Fatalf("UpsertHistoricalBatch returned error: %v", err)
+	}
+
+	cached, found, err := caching.NewDataCache().GetHistorical(bars[0].Symbol, bars[0].Range, bars[0].Interval)
+	if err != nil || !found {
+		t.Fatalf("GetHistorical found=%v err=%v, want the batch in Redis", found, err)
+	}
+
+	var args []driver.Value
+	mock.ExpectBegin()
+	expectHistoricalUpsert(mock, len(bars), &args)
+	mock.ExpectCommit()
+	if err := caching.NewPersistenceService().PersistHistoricalData(); err != nil {
+		t.Fatalf("PersistHistoricalData returned error: %v", err)
+	}
+	return cached, args
+}
+
+func TestHistoricalAdjCloseSurvivesRedisJSON(t *testing.T) {
+	bars := []model.Historical{
+		{Symbol: "AAPL", Epoch: 1700000000, Range: "1y", Interval: "1d", Close: 190},
+		{Symbol: "AAPL", Epoch: 1700086400, Range: "1y", Interval: "1d", Close: 191, AdjClose: float64Ptr(0)},
+		{Symbol: "AAPL", Epoch: 1700172800, Range: "1y", Interval: "1d", Close: 192, AdjClose: float64Ptr(187.5)},
+	}
+
+	cached, args := persistThroughRedis(t, bars)
+
+	if len(cached) != len(bars) {
+		t.Fatalf("Redis returned %d bars, want %d", len(cached), len(bars))
+	}
+	if cached[0].AdjClose != nil {
+		t.Errorf("nil AdjClose came back from Redis as %v, want nil", *cached[0].AdjClose)
+	}
+	if cached[1].AdjClose == nil || *cached[1].AdjClose != 0 {
+		t.Errorf("AdjClose 0 came back from Redis as %v, want a non-nil 0", cached[1].AdjClose)
+	}
+	if cached[2].AdjClose == nil || *cached[2].AdjClose != 187.5 {
+		t.Errorf("AdjClose 187.5 came back from Redis as %v, want 187.5", cached[2].AdjClose)
+	}
+
+	adjCloses := historicalArgColumn(args, historicalAdjCloseColumn)
+	want := []driver.Value{nil, 0.0, 187.5}
+	if len(adjCloses) != len(want) {
+		t.Fatalf("persisted %d bars, want %d", len(adjCloses), len(want))
+	}
+	for i := range want {
+		if adjCloses[i] != want[i] {
+			t.Errorf("persisted adj_close for bar %d = %#v, want %#v", i, adjCloses[i], want[i])
+		}
+	}
+}
+
+func TestHistoricalUpsertAdjCloseAssignments(t *testing.T) {
+	tests := []struct {
+		name     string
+		adjClose *float64
+		want     driver.Value
+	}{
+		// COALESCE(excluded.adj_close, historical.adj_close) keeps the stored value for NULL
+		{"nil over non-nil keeps the stored value", nil, nil},
+		// ...and takes the incoming value otherwise, replacing a stored NULL
+		{"non-nil over nil replaces it", float64Ptr(187.5), 187.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			var args []driver.Value
+			mock.ExpectBegin()
+			expectHistoricalUpsert(mock, 1, &args)
+			mock.ExpectCommit()
+
+			s := &HistoricalService{db: db}
+			bar := model.Historical{Symbol: "AAPL", Epoch: 1700000000, Range: "1y", Interval: "1d", Close: 190, AdjClose: tt.adjClose}
+			if err := s.upsertHistoricalBatchToDB([]model.Historical{bar}); err != nil {
+				t.Fatalf("upsertHistoricalBatchToDB returned error: %v", err)
+			}
+			if got := historicalArgColumn(args, historicalAdjCloseColumn); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("adj_close arg = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoricalUpsertClauseCoalescesAdjClose(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`"adj_close"=COALESCE(excluded.adj_close, historical.adj_close)`)).
+		WillReturnRows(returnedIDs(1))
+	mock.ExpectCommit()
+
+	bar := model.Historical{Symbol: "AAPL", Epoch: 1700000000, Range: "1y", Interval: "1d", Close: 190}
+	if err := db.Clauses(model.HistoricalUpsertClause()).Create(&bar).Error; err != nil {
+		t.Fatalf("Create returned error: %v", err)
+	}
+}
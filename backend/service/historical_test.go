@@ -0,0 +1,97 @@
+package service
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"screener/backend/model"
+)
+
+// failingWriteBehind accepts the first failAfter groups and then fails, like Redis going away mid-batch
+type failingWriteBehind struct {
+	failAfter int
+	cached    []string
+}
+
+func (f *failingWriteBehind) CacheHistorical(symbol, rangeParam, interval string, data []model.Historical) error {
+	if len(f.cached) >= f.failAfter {
+		return errors.New("redis: connection refused")
+	}
+	f.cached = append(f.cached, symbol+":"+rangeParam+":"+interval)
+	return nil
+}
+
+// historicalBatch returns two bars each for three symbols (three cache groups)
+func historicalBatch() []model.Historical {
+	bars := make([]model.Historical, 0, 6)
+	for _, symbol := range []string{"AAPL", "MSFT", "NVDA"} {
+		for _, epoch := range []int64{1700000000, 1700086400} {
+			bars = append(bars, model.Historical{Symbol: symbol, Epoch: epoch, Range: "1y", Interval: "1d", Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 100})
+		}
+	}
+	return bars
+}
+
+func TestUpsertHistoricalBatchWritesWholeBatchWhenCachingFailsMidway(t *testing.T) {
+	db, mock := newMockDB(t)
+	writeBehind := &failingWriteBehind{failAfter: 1}
+	s := &HistoricalService{db: db, writeBehind: writeBehind}
+
+	var args []driver.Value
+	mock.ExpectBegin()
+	expectHistoricalUpsert(mock, 6, &args)
+	mock.ExpectCommit()
+
+	if err := s.UpsertHistoricalBatch(historicalBatch()); err != nil {
+		t.Fatalf("UpsertHistoricalBatch returned error: %v", err)
+	}
+
+	if len(writeBehind.cached) != 1 || writeBehind.cached[0] != "AAPL:1y:1d" {
+		t.Errorf("cached groups = %v, want only AAPL:1y:1d before the failure", writeBehind.cached)
+	}
+
+	// Every bar, including the already-cached AAPL group, is in the single transactional insert
+	symbols := historicalArgColumn(args, 0)
+	want := []driver.Value{"AAPL", "AAPL", "MSFT", "MSFT", "NVDA", "NVDA"}
+	if len(symbols) != len(want) {
+		t.Fatalf("inserted %d bars (%v), want %d", len(symbols), symbols, len(want))
+	}
+	for i := range want {
+		if symbols[i] != want[i] {
+			t.Errorf("inserted bar %d symbol = %v, want %v", i, symbols[i], want[i])
+		}
+	}
+}
+
+func TestUpsertHistoricalBatchRollsBackWhenTheDatabaseFails(t *testing.T) {
+	db, mock := newMockDB(t)
+	s := &HistoricalService{db: db, writeBehind: &failingWriteBehind{failAfter: 2}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "historical"`).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if err := s.UpsertHistoricalBatch(historicalBatch()); err == nil {
+		t.Fatal("UpsertHistoricalBatch returned nil, want the database error")
+	}
+}
+
+func TestUpsertHistoricalBatchCachesEveryGroupWithoutTouchingTheDatabase(t *testing.T) {
+	db, _ := newMockDB(t) // No expectations: any query fails the test
+	writeBehind := &failingWriteBehind{failAfter: 3}
+	s := &HistoricalService{db: db, writeBehind: writeBehind}
+
+	if err := s.UpsertHistoricalBatch(historicalBatch()); err != nil {
+		t.Fatalf("UpsertHistoricalBatch returned error: %v", err)
+	}
+	want := []string{"AAPL:1y:1d", "MSFT:1y:1d", "NVDA:1y:1d"}
+	if len(writeBehind.cached) != len(want) {
+		t.Fatalf("cached groups = %v, want %v", writeBehind.cached, want)
+	}
+	for i := range want {
+		if writeBehind.cached[i] != want[i] {
+			t.Errorf("cached group %d = %s, want %s (first-seen order)", i, writeBehind.cached[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"screener/backend/service/caching"
+)
+
+// newCancelledRunFetcher returns a FetcherService whose symbol list (120 symbols, three batches
+// of 50) comes from Redis and whose upstream counts the requests it receives
+func newCancelledRunFetcher(t *testing.T) (*FetcherService, *atomic.Int64) {
+	t.Helper()
+	redis := newTestRedis(t)
+	symbols := `[`
+	for i := 0; i < 120; i++ {
+		if i > 0 {
+			symbols += ","
+		}
+		symbols += `"SYM` + string(rune('A'+i/26%26)) + string(rune('A'+i%26)) + `"`
+	}
+	if err := redis.Set("cache:symbols:all", symbols+`]`); err != nil {
+		t.Fatalf("failed to seed symbols: %v", err)
+	}
+
+	var requests atomic.Int64
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(upstream.Close)
+	t.Setenv("FINANCE_QUERY_URLS", upstream.URL)
+
+	return &FetcherService{
+		httpClient:  upstream.Client(),
+		histService: &HistoricalService{writeBehind: caching.NewDataCache()},
+		cache:       caching.NewCacheService(),
+		ttl:         &caching.CacheTTLConfig{},
+	}, &requests
+}
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestRunIngestionWithCancelledContext(t *testing.T) {
+	s, requests := newCancelledRunFetcher(t)
+
+	jobID, err := s.RunIngestion(cancelledContext(), 4)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunIngestion returned %v, want context.Canceled", err)
+	}
+	if jobID != "" {
+		t.Errorf("jobID = %q, want none for a run that never started", jobID)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("made %d upstream requests, want none once the context is cancelled", n)
+	}
+}
+
+func TestRunMarketAggregationWithCancelledContext(t *testing.T) {
+	for _, mode := range []string{MarketAggregationModeSimple, MarketAggregationModeDetailed} {
+		s, requests := newCancelledRunFetcher(t)
+
+		jobID, err := s.RunMarketAggregation(cancelledContext(), mode)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: RunMarketAggregation returned %v, want context.Canceled", mode, err)
+		}
+		if jobID != "" {
+			t.Errorf("%s: jobID = %q, want none for a cancelled aggregation", mode, jobID)
+		}
+		if n := requests.Load(); n != 0 {
+			t.Errorf("%s: made %d upstream requests, want none once the context is cancelled", mode, n)
+		}
+	}
+}
+
+func TestRunCompanyInfoIngestionWithCancelledContext(t *testing.T) {
+	s, requests := newCancelledRunFetcher(t)
+
+	jobID, err := s.RunCompanyInfoIngestion(cancelledContext())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunCompanyInfoIngestion returned %v, want context.Canceled", err)
+	}
+	// The job ID is kept so partial progress from earlier batches can be traced
+	if jobID == "" {
+		t.Error("jobID is empty, want the run's job ID")
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("made %d upstream requests, want none once the context is cancelled", n)
+	}
+}
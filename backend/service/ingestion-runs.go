@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"screener/backend/database"
+	"screener/backend/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ingestion run types recorded in ingestion_runs
+const (
+	IngestionTypeHistoricals       = "historicals"
+	IngestionTypeCompanyInfo       = "company_info"
+	IngestionTypeFundamentalData   = "fundamental_data"
+	IngestionTypeWatchlistPrices   = "watchlist_prices"
+	IngestionTypeMarketAggregation = "market_aggregation"
+)
+
+// Limits on the per-run error summary
+const (
+	maxIngestionRunErrors     = 10
+	maxIngestionErrorSymbols  = 5
+	DefaultIngestionRunsLimit = 20
+	MaxIngestionRunsLimit     = 100
+)
+
+// ingestionTriggerKey carries who started an ingestion run
+type ingestionTriggerKey struct{}
+
+// WithIngestionTrigger records who triggered the ingestion runs started with ctx (e.g. "service_role")
+func WithIngestionTrigger(ctx context.Context, triggeredBy string) context.Context {
+	return context.WithValue(ctx, ingestionTriggerKey{}, triggeredBy)
+}
+
+// ingestionTrigger returns the trigger stored by WithIngestionTrigger, or "unknown"
+func ingestionTrigger(ctx context.Context) string {
+	if triggeredBy, ok := ctx.Value(ingestionTriggerKey{}).(string); ok && triggeredBy != "" {
+		return triggeredBy
+	}
+	return "unknown"
+}
+
+// ingestionRunRecorder accumulates counts and errors for one run and persists them to ingestion_runs
+// Recording is best-effort: database failures are logged and never fail the ingestion itself
+type ingestionRunRecorder struct {
+	db     *gorm.DB
+	mu     sync.Mutex
+	run    model.IngestionRun
+	errors map[string]*model.IngestionRunError
+}
+
+// ingestionRunKey carries the recorder of the ingestion run a context belongs to
+type ingestionRunKey struct{}
+
+// startIngestionRun inserts a "running" row for a new run. The returned context carries the
+// recorder, so upstream fetches made with it are counted in the run's upstream stats.
+func (s *FetcherService) startIngestionRun(ctx context.Context, runType string) (context.Context, *ingestionRunRecorder) {
+	r := &ingestionRunRecorder{
+		db: s.db,
+		run: model.IngestionRun{
+			ID:          uuid.New(),
+			Type:        runType,
+			Status:      "running",
+			TriggeredBy: ingestionTrigger(ctx),
+			StartedAt:   time.Now().UTC(),
+		},
+		errors: make(map[string]*model.IngestionRunError),
+	}
+	if r.db != nil {
+		if err := r.db.Create(&r.run).Error; err != nil {
+			log.Printf("Warning: Failed to record %s ingestion run: %v", runType, err)
+		}
+	}
+	return context.WithValue(ctx, ingestionRunKey{}, r), r
+}
+
+// recordUpstreamOutcome adds one upstream fetch to the ingestion run in ctx, if any
+func recordUpstreamOutcome(ctx context.Context, outcome upstreamOutcome) {
+	if r, ok := ctx.Value(ingestionRunKey{}).(*ingestionRunRecorder); ok {
+		r.upstream(outcome)
+	}
+}
+
+// attempted sets how many symbols the run will process
+func (r *ingestionRunRecorder) attempted(n int) {
+	r.mu.Lock()
+	r.run.SymbolsAttempted = n
+	r.mu.Unlock()
+}
+
+// success counts n symbols as successfully ingested
+func (r *ingestionRunRecorder) success(n int) {
+	r.mu.Lock()
+	r.run.Succeeded += n
+	r.mu.Unlock()
+}
+
+// upstream counts one upstream fetch: a cache hit, or a request with its failover and latency
+func (r *ingestionRunRecorder) upstream(outcome upstreamOutcome) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.UpstreamRequests++
+	if outcome.Cached {
+		r.run.UpstreamCacheHits++
+		return
+	}
+	if outcome.Failover {
+		r.run.UpstreamFailovers++
+	}
+	r.run.UpstreamLatencyMs += outcome.Latency.Milliseconds()
+}
+
+// failure counts symbols as failed with err, grouping identical error messages
+func (r *ingestionRunRecorder) failure(symbols []string, err error) {
+	message := "unknown error"
+	if err != nil {
+		message = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.Failed += len(symbols)
+	entry, ok := r.errors[message]
+	if !ok {
+		entry = &model.IngestionRunError{Message: message}
+		r.errors[message] = entry
+	}
+	entry.Count += len(symbols)
+	for _, symbol := range symbols {
+		if len(entry.Symbols) >= maxIngestionErrorSymbols {
+			break
+		}
+		entry.Symbols = append(entry.Symbols, symbol)
+	}
+}
+
+// finish records the outcome of the run; err is the error that ended it, if any
+func (r *ingestionRunRecorder) finish(jobID string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	finishedAt := time.Now().UTC()
+	r.run.JobID = jobID
+	r.run.FinishedAt = &finishedAt
+	r.run.DurationMs = finishedAt.Sub(r.run.StartedAt).Milliseconds()
+	switch {
+	case err == nil:
+		r.run.Status = "completed"
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		r.run.Status = "cancelled"
+		r.run.Error = err.Error()
+	default:
+		r.run.Status = "failed"
+		r.run.Error = err.Error()
+	}
+
+	topErrors := make(model.IngestionRunErrors, 0, len(r.errors))
+	for _, entry := range r.errors {
+		topErrors = append(topErrors, *entry)
+	}
+	sort.Slice(topErrors, func(i, j int) bool {
+		if topErrors[i].Count != topErrors[j].Count {
+			return topErrors[i].Count > topErrors[j].Count
+		}
+		return topErrors[i].Message < topErrors[j].Message
+	})
+	if len(topErrors) > maxIngestionRunErrors {
+		topErrors = topErrors[:maxIngestionRunErrors]
+	}
+	r.run.TopErrors = topErrors
+
+	if r.db != nil {
+		if err := r.db.Save(&r.run).Error; err != nil {
+			log.Printf("Warning: Failed to record %s ingestion run result: %v", r.run.Type, err)
+		}
+	}
+}
+
+// IngestionRunPage represents a paginated page of ingestion runs
+type IngestionRunPage struct {
+	Data       []model.IngestionRun `json:"data"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	Total      int64                `json:"total"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+// GetIngestionRuns returns recorded ingestion runs, newest first, optionally filtered by type
+func GetIngestionRuns(runType string, pagination PaginationOptions) (*IngestionRunPage, error) {
+	db := database.GetDB()
+	if db == nil {
+		return nil, fmt.Errorf("database connection not initialized")
+	}
+
+	if pagination.Page <= 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit <= 0 {
+		pagination.Limit = DefaultIngestionRunsLimit
+	}
+	if pagination.Limit > MaxIngestionRunsLimit {
+		pagination.Limit = MaxIngestionRunsLimit
+	}
+
+	query := db.Model(&model.IngestionRun{})
+	if runType != "" {
+		query = query.Where("type = ?", runType)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count ingestion runs: %w", err)
+	}
+
+	runs := make([]model.IngestionRun, 0)
+	offset := (pagination.Page - 1) * pagination.Limit
+	if err := query.Order("started_at DESC").Offset(offset).Limit(pagination.Limit).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch ingestion runs: %w", err)
+	}
+
+	return &IngestionRunPage{
+		Data:       runs,
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(pagination.Limit) - 1) / int64(pagination.Limit)),
+	}, nil
+}
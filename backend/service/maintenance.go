@@ -0,0 +1,98 @@
+package service
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"screener/backend/service/caching"
+)
+
+// maintenanceKey is the Redis key holding the runtime maintenance state, so every instance agrees
+// It lives outside the cache: prefix since it is state, not cached data
+const maintenanceKey = "maintenance:mode"
+
+// maintenanceRefreshInterval is how long an instance reuses the maintenance state before re-reading Redis
+const maintenanceRefreshInterval = 5 * time.Second
+
+// defaultMaintenanceRetryAfter is the Retry-After sent when none is configured
+const defaultMaintenanceRetryAfter = 300
+
+// MaintenanceState describes whether writes are currently rejected
+type MaintenanceState struct {
+	Enabled           bool      `json:"enabled"`
+	Message           string    `json:"message,omitempty"`
+	RetryAfterSeconds int       `json:"retry_after_seconds"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+}
+
+var (
+	maintenanceMu       sync.Mutex
+	maintenanceState    MaintenanceState
+	maintenanceLoadedAt time.Time
+)
+
+// defaultMaintenanceState returns the startup state from MAINTENANCE_MODE and MAINTENANCE_RETRY_AFTER_SECONDS
+// Used whenever no runtime state has been set via the admin endpoint
+func defaultMaintenanceState() MaintenanceState {
+	value := os.Getenv("MAINTENANCE_MODE")
+	retryAfter := defaultMaintenanceRetryAfter
+	if v, err := strconv.Atoi(os.Getenv("MAINTENANCE_RETRY_AFTER_SECONDS")); err == nil && v > 0 {
+		retryAfter = v
+	}
+	return MaintenanceState{
+		Enabled:           value == "true" || value == "1",
+		RetryAfterSeconds: retryAfter,
+	}
+}
+
+// GetMaintenanceState returns the current maintenance state, refreshed from Redis at most every few seconds
+// Redis is read outside maintenanceMu so a slow read doesn't stall every other request on the lock
+func GetMaintenanceState() MaintenanceState {
+	maintenanceMu.Lock()
+	if !maintenanceLoadedAt.IsZero() && time.Since(maintenanceLoadedAt) < maintenanceRefreshInterval {
+		state := maintenanceState
+		maintenanceMu.Unlock()
+		return state
+	}
+	maintenanceMu.Unlock()
+
+	readAt := time.Now()
+	state := defaultMaintenanceState()
+	var stored MaintenanceState
+	if found, err := caching.NewCacheService().GetJSON(maintenanceKey, &stored); err == nil && found {
+		state = stored
+	}
+
+	maintenanceMu.Lock()
+	defer maintenanceMu.Unlock()
+	// A concurrent refresh or SetMaintenanceState that started after this read is newer; keep it
+	if maintenanceLoadedAt.After(readAt) {
+		return maintenanceState
+	}
+	maintenanceState = state
+	maintenanceLoadedAt = readAt
+	return state
+}
+
+// SetMaintenanceState stores the maintenance state in Redis (no expiry) and applies it locally
+func SetMaintenanceState(state MaintenanceState) (MaintenanceState, error) {
+	if state.RetryAfterSeconds <= 0 {
+		state.RetryAfterSeconds = defaultMaintenanceState().RetryAfterSeconds
+	}
+	state.UpdatedAt = time.Now().UTC()
+
+	if err := caching.NewCacheService().SetJSON(maintenanceKey, state, 0); err != nil {
+		return MaintenanceState{}, err
+	}
+
+	maintenanceMu.Lock()
+	maintenanceState = state
+	maintenanceLoadedAt = time.Now()
+	maintenanceMu.Unlock()
+
+	log.Printf("Maintenance mode set to %v", state.Enabled)
+	return state, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"screener/backend/format"
+)
+
+// BreadthCounts holds advance/decline/unchanged counts for a universe of stocks
+type BreadthCounts struct {
+	Advances  int `json:"advances"`
+	Decliners int `json:"decliners"`
+	Unchanged int `json:"unchanged"`
+	Total     int `json:"total"`
+}
+
+// add records a single categorized stock
+func (b *BreadthCounts) add(category string) {
+	switch category {
+	case "up":
+		b.Advances++
+	case "down":
+		b.Decliners++
+	default:
+		b.Unchanged++
+	}
+	b.Total++
+}
+
+// BreadthGroup is the breadth of one bucket (a sector or market-cap bucket)
+type BreadthGroup struct {
+	Name string `json:"name"`
+	BreadthCounts
+}
+
+// BreadthComparison compares whole-market breadth with breadth grouped by a dimension
+type BreadthComparison struct {
+	GroupBy   string         `json:"group_by"`
+	Threshold float64        `json:"unchanged_threshold"`
+	Market    BreadthCounts  `json:"market"`
+	Groups    []BreadthGroup `json:"groups"`
+}
+
+// Supported breadth grouping dimensions
+const (
+	BreadthGroupBySector    = "sector"
+	BreadthGroupByMarketCap = "market_cap"
+)
+
+// Market-cap bucket names, using the conventional USD cut-offs
+const (
+	marketCapMega  = "mega"    // >= $200B
+	marketCapLarge = "large"   // $10B - $200B
+	marketCapMid   = "mid"     // $2B - $10B
+	marketCapSmall = "small"   // $300M - $2B
+	marketCapMicro = "micro"   // < $300M
+	breadthUnknown = "unknown" // missing sector or unparseable market cap
+)
+
+// GetBreadth computes advance/decline breadth on demand from company_info percent changes,
+// for the whole market and grouped by sector or market-cap bucket. When value is non-empty
+// only the matching group (case-insensitive) is returned alongside the market totals.
+//
+// These counts are a point-in-time snapshot of company_info, so they will not match
+// /market-statistics/live exactly: the live aggregator counts quotes for screener symbols
+// as they are fetched, while company_info is only updated by the company-data ingestion.
+// Both use the same MARKET_UNCHANGED_THRESHOLD band.
+func (s *MarketStatisticsService) GetBreadth(ctx context.Context, groupBy, value string) (*BreadthComparison, error) {
+	if groupBy != BreadthGroupBySector && groupBy != BreadthGroupByMarketCap {
+		return nil, fmt.Errorf("group_by must be one of: %s, %s", BreadthGroupBySector, BreadthGroupByMarketCap)
+	}
+
+	type breadthRow struct {
+		Sector        string
+		MarketCap     string
+		PercentChange string
+	}
+
+	var rows []breadthRow
+	err := s.db.WithContext(ctx).
+		Table("company_info").
+		Select("sector, market_cap, percent_change").
+		Where("deleted_at IS NULL AND percent_change IS NOT NULL AND percent_change <> ''").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load company info for breadth: %w", err)
+	}
+
+	result := &BreadthComparison{
+		GroupBy:   groupBy,
+		Threshold: s.unchangedThreshold,
+	}
+	groups := make(map[string]*BreadthGroup)
+
+	for _, row := range rows {
+		category := categorizeStock(row.PercentChange, s.unchangedThreshold)
+		result.Market.add(category)
+
+		var name string
+		if groupBy == BreadthGroupBySector {
+			name = strings.TrimSpace(row.Sector)
+			if name == "" {
+				name = breadthUnknown
+			}
+		} else {
+			name = marketCapBucket(row.MarketCap)
+		}
+
+		if value != "" && !strings.EqualFold(name, value) {
+			continue
+		}
+
+		group, ok := groups[name]
+		if !ok {
+			group = &BreadthGroup{Name: name}
+			groups[name] = group
+		}
+		group.add(category)
+	}
+
+	result.Groups = make([]BreadthGroup, 0, len(groups))
+	for _, group := range groups {
+		result.Groups = append(result.Groups, *group)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		return result.Groups[i].Name < result.Groups[j].Name
+	})
+
+	return result, nil
+}
+
+// marketCapBucket maps a market cap string such as "2.85T", "412.3B" or "950M" to a bucket name
+func marketCapBucket(marketCap string) string {
+	value, ok := parseMarketCap(marketCap)
+	if !ok {
+		return breadthUnknown
+	}
+
+	switch {
+	case value >= 200e9:
+		return marketCapMega
+	case value >= 10e9:
+		return marketCapLarge
+	case value >= 2e9:
+		return marketCapMid
+	case value >= 300e6:
+		return marketCapSmall
+	default:
+		return marketCapMicro
+	}
+}
+
+// parseMarketCap converts a market cap string with an optional T/B/M/K suffix to dollars
+func parseMarketCap(marketCap string) (float64, bool) {
+	value, err := format.ParseMarketCap(marketCap)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	return value, true
+}
@@ -2,14 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"screener/backend/database"
+	"screener/backend/format"
 	"screener/backend/model"
 	"screener/backend/service/caching"
 
@@ -48,6 +50,26 @@ type MarketStatisticsService struct {
 	aggregator *DailyAggregator
 	cache      *caching.CacheService
 	ttl        *caching.CacheTTLConfig
+	// unchangedThreshold is the absolute percent change below which a stock counts as unchanged
+	unchangedThreshold float64
+}
+
+// defaultUnchangedThreshold is the ±percent band treated as unchanged when MARKET_UNCHANGED_THRESHOLD is unset
+const defaultUnchangedThreshold = 0.01
+
+// getUnchangedThreshold reads MARKET_UNCHANGED_THRESHOLD (percent, default: 0.01)
+// Negative or unparseable values fall back to the default
+func getUnchangedThreshold() float64 {
+	value := os.Getenv("MARKET_UNCHANGED_THRESHOLD")
+	if value == "" {
+		return defaultUnchangedThreshold
+	}
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 {
+		log.Printf("Warning: invalid MARKET_UNCHANGED_THRESHOLD %q, using %v", value, defaultUnchangedThreshold)
+		return defaultUnchangedThreshold
+	}
+	return threshold
 }
 
 // NewMarketStatisticsService constructs a new MarketStatisticsService
@@ -58,26 +80,28 @@ func NewMarketStatisticsService() *MarketStatisticsService {
 		aggregator: getGlobalAggregator(),
 		cache:      caching.NewCacheService(),
 		ttl:        caching.GetTTLConfig(),
+
+		unchangedThreshold: getUnchangedThreshold(),
 	}
 }
 
 // parsePercentChange converts "-5.06%" or "+0.01%" string to float64
 func parsePercentChange(percentStr string) (float64, error) {
-	percentStr = strings.TrimSpace(percentStr)
-	percentStr = strings.TrimSuffix(percentStr, "%")
-	return strconv.ParseFloat(percentStr, 64)
+	return format.ParsePercent(percentStr)
 }
 
-// categorizeStock determines if stock is up, down, or unchanged based on +0.01% threshold
-func categorizeStock(percentChange string) string {
+// categorizeStock determines if stock is up, down, or unchanged based on a ±threshold percent band
+// A change exactly at +threshold counts as up and exactly at -threshold counts as down;
+// a zero change is unchanged even with a zero threshold
+func categorizeStock(percentChange string, threshold float64) string {
 	percent, err := parsePercentChange(percentChange)
 	if err != nil {
 		return "unchanged" // Default if parsing fails
 	}
 
-	if percent >= 0.01 {
+	if percent > 0 && percent >= threshold {
 		return "up"
-	} else if percent <= -0.01 {
+	} else if percent < 0 && percent <= -threshold {
 		return "down"
 	}
 	return "unchanged"
@@ -100,12 +124,12 @@ func (s *MarketStatisticsService) AggregateQuotes(ctx context.Context, quotes in
 	switch q := quotes.(type) {
 	case []simpleQuote:
 		for _, quote := range q {
-			category := categorizeStock(quote.PercentChange)
+			category := categorizeStock(quote.PercentChange, s.unchangedThreshold)
 			s.aggregator.counts[category]++
 		}
 	case []detailedQuote:
 		for _, quote := range q {
-			category := categorizeStock(quote.PercentChange)
+			category := categorizeStock(quote.PercentChange, s.unchangedThreshold)
 			s.aggregator.counts[category]++
 		}
 	default:
@@ -152,6 +176,49 @@ func (s *MarketStatisticsService) GetMarketStatsForFrontend() (map[string]interf
 	return stats, nil
 }
 
+// ResetCurrentDayStats clears today's in-memory aggregation counts
+func (s *MarketStatisticsService) ResetCurrentDayStats() {
+	s.aggregator.mu.Lock()
+	defer s.aggregator.mu.Unlock()
+
+	s.aggregator.today = time.Now().Truncate(24 * time.Hour)
+	s.aggregator.counts = make(map[string]int)
+	s.aggregator.lastUpdated = time.Now()
+}
+
+// SeedCurrentDayStats loads today's stored market statistics back into the in-memory aggregator
+// Checks Redis first (pending persistence), then database
+func (s *MarketStatisticsService) SeedCurrentDayStats(ctx context.Context) (*model.MarketStatistics, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	dataCache := caching.NewDataCache()
+	stored, found, err := dataCache.GetMarketStatistics(today.Format("2006-01-02"))
+	if err != nil || !found {
+		var dbStats model.MarketStatistics
+		result := s.db.WithContext(ctx).Where("date = ?", today).First(&dbStats)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil, errors.New("record not found")
+			}
+			return nil, fmt.Errorf("failed to fetch market statistics: %w", result.Error)
+		}
+		stored = &dbStats
+	}
+
+	s.aggregator.mu.Lock()
+	defer s.aggregator.mu.Unlock()
+
+	s.aggregator.today = today
+	s.aggregator.counts = map[string]int{
+		"up":        stored.StocksUp,
+		"down":      stored.StocksDown,
+		"unchanged": stored.StocksUnchanged,
+	}
+	s.aggregator.lastUpdated = time.Now()
+
+	return stored, nil
+}
+
 // StoreEndOfDayStats saves today's aggregated stats to Redis ONLY (no immediate database write)
 // Background worker will persist to database later
 func (s *MarketStatisticsService) StoreEndOfDayStats(ctx context.Context) error {
@@ -188,6 +255,89 @@ func (s *MarketStatisticsService) StoreEndOfDayStats(ctx context.Context) error
 	return nil
 }
 
+// BackfillStats reconstructs daily market statistics for [from, to] from stored historical
+// 10y/1d bars, comparing each symbol's close to its prior daily close, and upserts the
+// resulting MarketStatistics rows using the same unchanged band as live aggregation
+// (categorizeStock): a flat close is unchanged even with a zero threshold.
+// Only days with bars (trading days) produce rows.
+func (s *MarketStatisticsService) BackfillStats(ctx context.Context, from, to time.Time) ([]model.MarketStatistics, error) {
+	from = from.Truncate(24 * time.Hour)
+	to = to.Truncate(24 * time.Hour)
+	if to.Before(from) {
+		return nil, errors.New("from must be on or before to")
+	}
+
+	// Look back a couple of weeks before "from" so the first day has a prior close
+	// even across weekends and holidays
+	minEpoch := from.AddDate(0, 0, -14).Unix()
+	maxEpoch := to.Add(24*time.Hour).Unix() - 1
+
+	type dailyBreadth struct {
+		Day       time.Time
+		Up        int
+		Down      int
+		Unchanged int
+	}
+
+	var rows []dailyBreadth
+	err := s.db.WithContext(ctx).Raw(`
+		WITH bars AS (
+			SELECT symbol,
+			       (to_timestamp(epoch) AT TIME ZONE 'UTC')::date AS day,
+			       close,
+			       LAG(close) OVER (PARTITION BY symbol ORDER BY epoch) AS prev_close
+			FROM historical
+			WHERE range = '10y' AND interval = '1d' AND deleted_at IS NULL
+			  AND epoch BETWEEN ? AND ?
+		), changes AS (
+			SELECT day, (close - prev_close) / prev_close * 100 AS pct
+			FROM bars
+			WHERE prev_close IS NOT NULL AND prev_close <> 0
+		)
+		SELECT day,
+		       COUNT(*) FILTER (WHERE pct > 0 AND pct >= ?) AS up,
+		       COUNT(*) FILTER (WHERE pct < 0 AND pct <= ?) AS down,
+		       COUNT(*) FILTER (WHERE NOT ((pct > 0 AND pct >= ?) OR (pct < 0 AND pct <= ?))) AS unchanged
+		FROM changes
+		WHERE day BETWEEN ? AND ?
+		GROUP BY day
+		ORDER BY day ASC`,
+		minEpoch, maxEpoch,
+		s.unchangedThreshold, -s.unchangedThreshold, s.unchangedThreshold, -s.unchangedThreshold,
+		from.Format("2006-01-02"), to.Format("2006-01-02"),
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute historical breadth: %w", err)
+	}
+
+	stats := make([]model.MarketStatistics, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, model.MarketStatistics{
+			Date:            row.Day,
+			StocksUp:        row.Up,
+			StocksDown:      row.Down,
+			StocksUnchanged: row.Unchanged,
+			TotalStocks:     row.Up + row.Down + row.Unchanged,
+		})
+	}
+
+	if len(stats) == 0 {
+		return stats, nil
+	}
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"stocks_up", "stocks_down", "stocks_unchanged", "total_stocks", "updated_at",
+		}),
+	}).CreateInBatches(&stats, 100)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to upsert market statistics: %w", result.Error)
+	}
+
+	return stats, nil
+}
+
 // GetHistoricalStats fetches market statistics for charting
 // Checks Redis first, then database
 func (s *MarketStatisticsService) GetHistoricalStats(ctx context.Context, startDate, endDate time.Time) ([]model.MarketStatistics, error) {
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCategorizeStockAtTheThreshold(t *testing.T) {
+	tests := []struct {
+		percentChange string
+		threshold     float64
+		want          string
+	}{
+		{"+0.01%", 0.01, "up"}, // Exactly at +threshold
+		{"-0.01%", 0.01, "down"},
+		{"+0.00%", 0.01, "unchanged"},
+		{"0.009%", 0.01, "unchanged"}, // Just inside the band
+		{"-0.009%", 0.01, "unchanged"},
+		{"+0.011%", 0.01, "up"},
+		{"+0.50%", 0.5, "up"},
+		{"-0.50%", 0.5, "down"},
+		{"+0.49%", 0.5, "unchanged"},
+		// A zero threshold still leaves a flat stock unchanged
+		{"+0.00%", 0, "unchanged"},
+		{"-0.00%", 0, "unchanged"},
+		{"+0.01%", 0, "up"},
+		{"-0.01%", 0, "down"},
+		{"N/A", 0.01, "unchanged"},
+		{"", 0.01, "unchanged"},
+	}
+	for _, tt := range tests {
+		if got := categorizeStock(tt.percentChange, tt.threshold); got != tt.want {
+			t.Errorf("categorizeStock(%q, %v) = %s, want %s", tt.percentChange, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateQuotesCountsAStockAtTheThreshold(t *testing.T) {
+	s := &MarketStatisticsService{aggregator: &DailyAggregator{counts: make(map[string]int)}, unchangedThreshold: 0.25}
+
+	quotes := []simpleQuote{
+		{Symbol: "AT", PercentChange: "+0.25%"},
+		{Symbol: "BELOW", PercentChange: "+0.24%"},
+		{Symbol: "NEG_AT", PercentChange: "-0.25%"},
+		{Symbol: "FLAT", PercentChange: "0.00%"},
+	}
+	if err := s.AggregateQuotes(context.Background(), quotes); err != nil {
+		t.Fatalf("AggregateQuotes returned error: %v", err)
+	}
+	if up, down, unchanged := s.aggregator.counts["up"], s.aggregator.counts["down"], s.aggregator.counts["unchanged"]; up != 1 || down != 1 || unchanged != 2 {
+		t.Errorf("counts = up %d, down %d, unchanged %d; want 1, 1, 2", up, down, unchanged)
+	}
+}
+
+func TestGetUnchangedThreshold(t *testing.T) {
+	for value, want := range map[string]float64{"": defaultUnchangedThreshold, "0.25": 0.25, "0": 0, "-1": defaultUnchangedThreshold, "abc": defaultUnchangedThreshold} {
+		t.Setenv("MARKET_UNCHANGED_THRESHOLD", value)
+		if got := getUnchangedThreshold(); got != want {
+			t.Errorf("MARKET_UNCHANGED_THRESHOLD=%q gives %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestBackfillStatsBandMatchesCategorizeStock(t *testing.T) {
+	db, mock := newMockDB(t)
+	s := &MarketStatisticsService{db: db, unchangedThreshold: 0}
+
+	// Up and down need a nonzero move as in categorizeStock, and unchanged is exactly the rest,
+	// so at a zero threshold a flat stock is counted once, as unchanged
+	mock.ExpectQuery(regexp.QuoteMeta(`COUNT(*) FILTER (WHERE pct > 0 AND pct >= $3) AS up,`)+`\s*`+
+		regexp.QuoteMeta(`COUNT(*) FILTER (WHERE pct < 0 AND pct <= $4) AS down,`)+`\s*`+
+		regexp.QuoteMeta(`COUNT(*) FILTER (WHERE NOT ((pct > 0 AND pct >= $5) OR (pct < 0 AND pct <= $6))) AS unchanged`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0.0, 0.0, 0.0, 0.0, "2025-11-28", "2025-11-28").
+		WillReturnRows(sqlmock.NewRows([]string{"day", "up", "down", "unchanged"}).
+			AddRow(time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC), 310, 150, 40))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "market_statistics"`)).WillReturnRows(returnedIDs(1))
+	mock.ExpectCommit()
+
+	day := time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC)
+	stats, err := s.BackfillStats(context.Background(), day, day)
+	if err != nil {
+		t.Fatalf("BackfillStats returned error: %v", err)
+	}
+	if len(stats) != 1 || stats[0].StocksUnchanged != 40 || stats[0].TotalStocks != 500 {
+		t.Errorf("stats = %+v, want one day with 40 unchanged of 500", stats)
+	}
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Market session states reported by MarketCalendar.Status
+const (
+	MarketSessionPreMarket  = "pre_market"
+	MarketSessionOpen       = "open"
+	MarketSessionAfterHours = "after_hours"
+	MarketSessionClosed     = "closed"
+)
+
+// Session boundaries as minutes after midnight in the market timezone
+const (
+	preMarketOpenMinute   = 4 * 60       // 04:00
+	regularOpenMinute     = 9*60 + 30    // 09:30
+	regularCloseMinute    = 16 * 60      // 16:00
+	earlyCloseMinute      = 13 * 60      // 13:00 on early-close days
+	afterHoursCloseMinute = 20 * 60      // 20:00
+	earlyAfterHoursClose  = 17 * 60      // 17:00 on early-close days
+	marketDateLayout      = "2006-01-02" // Calendar dates are market-local
+)
+
+// MarketHoliday is a full-day closure or an early (13:00) close
+type MarketHoliday struct {
+	Date       string `json:"date"` // YYYY-MM-DD in the market timezone
+	Name       string `json:"name"`
+	EarlyClose bool   `json:"early_close"`
+}
+
+// usMarketHolidays is the NYSE/Nasdaq holiday and early-close calendar
+// Extend it each year; MARKET_HOLIDAYS adds ad-hoc closures without a release
+var usMarketHolidays = []MarketHoliday{
+	{Date: "2025-01-01", Name: "New Year's Day"},
+	{Date: "2025-01-09", Name: "National Day of Mourning"},
+	{Date: "2025-01-20", Name: "Martin Luther King Jr. Day"},
+	{Date: "2025-02-17", Name: "Presidents' Day"},
+	{Date: "2025-04-18", Name: "Good Friday"},
+	{Date: "2025-05-26", Name: "Memorial Day"},
+	{Date: "2025-06-19", Name: "Juneteenth"},
+	{Date: "2025-07-03", Name: "Independence Day (early close)", EarlyClose: true},
+	{Date: "2025-07-04", Name: "Independence Day"},
+	{Date: "2025-09-01", Name: "Labor Day"},
+	{Date: "2025-11-27", Name: "Thanksgiving Day"},
+	{Date: "2025-11-28", Name: "Day after Thanksgiving (early close)", EarlyClose: true},
+	{Date: "2025-12-24", Name: "Christmas Eve (early close)", EarlyClose: true},
+	{Date: "2025-12-25", Name: "Christmas Day"},
+	{Date: "2026-01-01", Name: "New Year's Day"},
+	{Date: "2026-01-19", Name: "Martin Luther King Jr. Day"},
+	{Date: "2026-02-16", Name: "Presidents' Day"},
+	{Date: "2026-04-03", Name: "Good Friday"},
+	{Date: "2026-05-25", Name: "Memorial Day"},
+	{Date: "2026-06-19", Name: "Juneteenth"},
+	{Date: "2026-07-03", Name: "Independence Day (observed)"},
+	{Date: "2026-09-07", Name: "Labor Day"},
+	{Date: "2026-11-26", Name: "Thanksgiving Day"},
+	{Date: "2026-11-27", Name: "Day after Thanksgiving (early close)", EarlyClose: true},
+	{Date: "2026-12-24", Name: "Christmas Eve (early close)", EarlyClose: true},
+	{Date: "2026-12-25", Name: "Christmas Day"},
+	{Date: "2027-01-01", Name: "New Year's Day"},
+	{Date: "2027-01-18", Name: "Martin Luther King Jr. Day"},
+	{Date: "2027-02-15", Name: "Presidents' Day"},
+	{Date: "2027-03-26", Name: "Good Friday"},
+	{Date: "2027-05-31", Name: "Memorial Day"},
+	{Date: "2027-06-18", Name: "Juneteenth (observed)"},
+	{Date: "2027-07-05", Name: "Independence Day (observed)"},
+	{Date: "2027-09-06", Name: "Labor Day"},
+	{Date: "2027-11-25", Name: "Thanksgiving Day"},
+	{Date: "2027-11-26", Name: "Day after Thanksgiving (early close)", EarlyClose: true},
+	{Date: "2027-12-24", Name: "Christmas Day (observed)"},
+}
+
+// MarketCalendar answers market-hours questions for a timezone and holiday list
+type MarketCalendar struct {
+	loc      *time.Location
+	holidays map[string]MarketHoliday
+}
+
+// NewMarketCalendar builds a calendar from a holiday list; dates that don't parse are skipped
+func NewMarketCalendar(loc *time.Location, holidays []MarketHoliday) *MarketCalendar {
+	calendar := &MarketCalendar{loc: loc, holidays: make(map[string]MarketHoliday, len(holidays))}
+	for _, h := range holidays {
+		if _, err := time.ParseInLocation(marketDateLayout, h.Date, loc); err != nil {
+			log.Printf("Warning: skipping market holiday with invalid date %q: %v", h.Date, err)
+			continue
+		}
+		calendar.holidays[h.Date] = h
+	}
+	return calendar
+}
+
+var (
+	defaultCalendar     *MarketCalendar
+	defaultCalendarOnce sync.Once
+)
+
+// DefaultMarketCalendar returns the US market calendar, plus any MARKET_HOLIDAYS closures
+// (comma-separated YYYY-MM-DD dates)
+func DefaultMarketCalendar() *MarketCalendar {
+	defaultCalendarOnce.Do(func() {
+		holidays := append([]MarketHoliday{}, usMarketHolidays...)
+		for _, date := range strings.Split(os.Getenv("MARKET_HOLIDAYS"), ",") {
+			if date = strings.TrimSpace(date); date != "" {
+				holidays = append(holidays, MarketHoliday{Date: date, Name: "Market closed"})
+			}
+		}
+		defaultCalendar = NewMarketCalendar(marketLocation(), holidays)
+	})
+	return defaultCalendar
+}
+
+// MarketStatus describes the market session at a point in time
+type MarketStatus struct {
+	Session    string     `json:"session"` // pre_market, open, after_hours or closed
+	IsOpen     bool       `json:"is_open"` // Regular session only
+	Timezone   string     `json:"timezone"`
+	Now        time.Time  `json:"now"`
+	Holiday    string     `json:"holiday,omitempty"` // Today's holiday or early-close name
+	EarlyClose bool       `json:"early_close"`
+	NextOpen   time.Time  `json:"next_open"`            // Next regular session open
+	NextClose  *time.Time `json:"next_close,omitempty"` // Close of the current session when open
+}
+
+// IsTradingDay reports whether the market has a regular session on t's market-local date
+func (m *MarketCalendar) IsTradingDay(t time.Time) bool {
+	local := t.In(m.loc)
+	if local.Weekday() == time.Saturday || local.Weekday() == time.Sunday {
+		return false
+	}
+	h, ok := m.holidays[local.Format(marketDateLayout)]
+	return !ok || h.EarlyClose
+}
+
+// Status reports the session at now
+func (m *MarketCalendar) Status(now time.Time) MarketStatus {
+	local := now.In(m.loc)
+	status := MarketStatus{
+		Session:  MarketSessionClosed,
+		Timezone: m.loc.String(),
+		Now:      local,
+	}
+	if h, ok := m.holidays[local.Format(marketDateLayout)]; ok {
+		status.Holiday = h.Name
+		status.EarlyClose = h.EarlyClose
+	}
+
+	if m.IsTradingDay(local) {
+		closeMinute, afterHoursEnd := regularCloseMinute, afterHoursCloseMinute
+		if status.EarlyClose {
+			closeMinute, afterHoursEnd = earlyCloseMinute, earlyAfterHoursClose
+		}
+
+		minute := local.Hour()*60 + local.Minute()
+		switch {
+		case minute >= preMarketOpenMinute && minute < regularOpenMinute:
+			status.Session = MarketSessionPreMarket
+		case minute >= regularOpenMinute && minute < closeMinute:
+			status.Session = MarketSessionOpen
+			status.IsOpen = true
+			closeAt := atMinute(local, closeMinute)
+			status.NextClose = &closeAt
+		case minute >= closeMinute && minute < afterHoursEnd:
+			status.Session = MarketSessionAfterHours
+		}
+	}
+
+	status.NextOpen = m.nextOpen(local)
+	return status
+}
+
+// InRegularSession reports whether t falls in a regular session: 09:30 to 16:00 market-local time
+// (13:00 on early-close days) on trading days. Pre-market and after-hours times are outside it.
+func (m *MarketCalendar) InRegularSession(t time.Time) bool {
+	local := t.In(m.loc)
+	if !m.IsTradingDay(local) {
+		return false
+	}
+	closeMinute := regularCloseMinute
+	if h, ok := m.holidays[local.Format(marketDateLayout)]; ok && h.EarlyClose {
+		closeMinute = earlyCloseMinute
+	}
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= regularOpenMinute && minute < closeMinute
+}
+
+// AggregationWindow reports whether live-quote aggregation should run at now: during the regular
+// session and after-hours on trading days. When it shouldn't, reason says why.
+func (m *MarketCalendar) AggregationWindow(now time.Time) (bool, string) {
+	status := m.Status(now)
+	switch {
+	case status.Session == MarketSessionOpen || status.Session == MarketSessionAfterHours:
+		return true, ""
+	case status.Holiday != "" && !status.EarlyClose:
+		return false, fmt.Sprintf("market closed for %s", status.Holiday)
+	case !m.IsTradingDay(now):
+		return false, "market closed for the weekend"
+	case status.Session == MarketSessionPreMarket:
+		return false, "regular session has not opened yet"
+	default:
+		return false, "outside market hours"
+	}
+}
+
+// nextOpen returns the next regular-session open strictly after t
+func (m *MarketCalendar) nextOpen(t time.Time) time.Time {
+	day := t
+	for i := 0; i < 14; i++ {
+		if m.IsTradingDay(day) {
+			openAt := atMinute(day, regularOpenMinute)
+			if openAt.After(t) {
+				return openAt
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, m.loc)
+	}
+	return time.Time{}
+}
+
+// atMinute returns t's market-local date at the given minute after midnight
+func atMinute(t time.Time, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), minute/60, minute%60, 0, 0, t.Location())
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+// marketTime returns the given market-local date and time
+func marketTime(t *testing.T, date string, hour, minute int) time.Time {
+	t.Helper()
+	day, err := time.ParseInLocation(marketDateLayout, date, marketLocation())
+	if err != nil {
+		t.Fatalf("failed to parse %q: %v", date, err)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, marketLocation())
+}
+
+func TestMarketCalendarStatus(t *testing.T) {
+	calendar := NewMarketCalendar(marketLocation(), usMarketHolidays)
+	tests := []struct {
+		name       string
+		now        time.Time
+		session    string
+		holiday    string
+		earlyClose bool
+		nextOpen   time.Time
+		nextClose  time.Time // Zero when the regular session isn't open
+	}{
+		{
+			name:     "saturday",
+			now:      marketTime(t, "2025-11-29", 12, 0),
+			session:  MarketSessionClosed,
+			nextOpen: marketTime(t, "2025-12-01", 9, 30),
+		},
+		{
+			name:     "full holiday",
+			now:      marketTime(t, "2025-11-27", 11, 0),
+			session:  MarketSessionClosed,
+			holiday:  "Thanksgiving Day",
+			nextOpen: marketTime(t, "2025-11-28", 9, 30),
+		},
+		{
+			name:       "early close before 13:00",
+			now:        marketTime(t, "2025-11-28", 12, 0),
+			session:    MarketSessionOpen,
+			holiday:    "Day after Thanksgiving (early close)",
+			earlyClose: true,
+			nextOpen:   marketTime(t, "2025-12-01", 9, 30),
+			nextClose:  marketTime(t, "2025-11-28", 13, 0),
+		},
+		{
+			name:       "early close at 13:30",
+			now:        marketTime(t, "2025-11-28", 13, 30),
+			session:    MarketSessionAfterHours,
+			holiday:    "Day after Thanksgiving (early close)",
+			earlyClose: true,
+			nextOpen:   marketTime(t, "2025-12-01", 9, 30),
+		},
+		{
+			name:       "early close at 16:30",
+			now:        marketTime(t, "2025-11-28", 16, 30),
+			session:    MarketSessionAfterHours,
+			holiday:    "Day after Thanksgiving (early close)",
+			earlyClose: true,
+			nextOpen:   marketTime(t, "2025-12-01", 9, 30),
+		},
+		{
+			name:       "early close after after-hours",
+			now:        marketTime(t, "2025-11-28", 17, 30),
+			session:    MarketSessionClosed,
+			holiday:    "Day after Thanksgiving (early close)",
+			earlyClose: true,
+			nextOpen:   marketTime(t, "2025-12-01", 9, 30),
+		},
+		{
+			name:     "pre-market",
+			now:      marketTime(t, "2025-12-01", 8, 0),
+			session:  MarketSessionPreMarket,
+			nextOpen: marketTime(t, "2025-12-01", 9, 30),
+		},
+		{
+			name:      "regular session",
+			now:       marketTime(t, "2025-12-01", 10, 0),
+			session:   MarketSessionOpen,
+			nextOpen:  marketTime(t, "2025-12-02", 9, 30),
+			nextClose: marketTime(t, "2025-12-01", 16, 0),
+		},
+		{
+			name:     "regular after-hours",
+			now:      marketTime(t, "2025-12-01", 16, 30),
+			session:  MarketSessionAfterHours,
+			nextOpen: marketTime(t, "2025-12-02", 9, 30),
+		},
+		{
+			name:     "before a holiday weekend",
+			now:      marketTime(t, "2026-07-02", 20, 30),
+			session:  MarketSessionClosed,
+			nextOpen: marketTime(t, "2026-07-06", 9, 30),
+		},
+		{
+			name:     "holiday friday",
+			now:      marketTime(t, "2026-07-03", 10, 0),
+			session:  MarketSessionClosed,
+			holiday:  "Independence Day (observed)",
+			nextOpen: marketTime(t, "2026-07-06", 9, 30),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := calendar.Status(tt.now)
+			if status.Session != tt.session {
+				t.Errorf("Session = %q, want %q", status.Session, tt.session)
+			}
+			if status.IsOpen != (tt.session == MarketSessionOpen) {
+				t.Errorf("IsOpen = %v for session %q", status.IsOpen, tt.session)
+			}
+			if status.Holiday != tt.holiday || status.EarlyClose != tt.earlyClose {
+				t.Errorf("Holiday, EarlyClose = %q, %v, want %q, %v", status.Holiday, status.EarlyClose, tt.holiday, tt.earlyClose)
+			}
+			if !status.NextOpen.Equal(tt.nextOpen) {
+				t.Errorf("NextOpen = %v, want %v", status.NextOpen, tt.nextOpen)
+			}
+			switch {
+			case tt.nextClose.IsZero() && status.NextClose != nil:
+				t.Errorf("NextClose = %v, want none", *status.NextClose)
+			case !tt.nextClose.IsZero() && (status.NextClose == nil || !status.NextClose.Equal(tt.nextClose)):
+				t.Errorf("NextClose = %v, want %v", status.NextClose, tt.nextClose)
+			}
+		})
+	}
+}
+
+func TestMarketCalendarAggregationWindow(t *testing.T) {
+	calendar := NewMarketCalendar(marketLocation(), usMarketHolidays)
+	tests := []struct {
+		name   string
+		now    time.Time
+		run    bool
+		reason string
+	}{
+		{"regular session", marketTime(t, "2025-12-01", 10, 0), true, ""},
+		{"after-hours", marketTime(t, "2025-12-01", 19, 0), true, ""},
+		{"early-close after-hours", marketTime(t, "2025-11-28", 16, 30), true, ""},
+		{"pre-market", marketTime(t, "2025-12-01", 8, 0), false, "regular session has not opened yet"},
+		{"overnight", marketTime(t, "2025-12-01", 21, 0), false, "outside market hours"},
+		{"weekend", marketTime(t, "2025-11-29", 12, 0), false, "market closed for the weekend"},
+		{"holiday", marketTime(t, "2025-12-25", 12, 0), false, "market closed for Christmas Day"},
+	}
+	for _, tt := range tests {
+		run, reason := calendar.AggregationWindow(tt.now)
+		if run != tt.run || reason != tt.reason {
+			t.Errorf("%s: AggregationWindow = %v, %q, want %v, %q", tt.name, run, reason, tt.run, tt.reason)
+		}
+	}
+}
+
+func TestNewMarketCalendarSkipsInvalidDates(t *testing.T) {
+	calendar := NewMarketCalendar(marketLocation(), []MarketHoliday{
+		{Date: "2025-13-01", Name: "Not a date"},
+		{Date: "2025-12-01", Name: "Market closed"},
+	})
+	if len(calendar.holidays) != 1 {
+		t.Fatalf("calendar has %d holidays, want 1", len(calendar.holidays))
+	}
+	if calendar.IsTradingDay(marketTime(t, "2025-12-01", 12, 0)) {
+		t.Error("IsTradingDay on a configured closure = true, want false")
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// marketTimezone is the exchange timezone used for trading-day, week and month boundaries
+const marketTimezone = "America/New_York"
+
+var (
+	marketLoc     *time.Location
+	marketLocOnce sync.Once
+)
+
+// marketLocation returns the market timezone location
+// Falls back to a fixed UTC-5 offset if the tz database is unavailable
+func marketLocation() *time.Location {
+	marketLocOnce.Do(func() {
+		loc, err := time.LoadLocation(marketTimezone)
+		if err != nil {
+			log.Printf("Warning: failed to load %s timezone, using fixed UTC-5: %v", marketTimezone, err)
+			loc = time.FixedZone("EST", -5*60*60)
+		}
+		marketLoc = loc
+	})
+	return marketLoc
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ScreenerFields maps the JSON fields a screener list may be projected to onto their columns
+var ScreenerFields = map[string]string{
+	"id":         "id",
+	"symbol":     "symbol",
+	"open":       "open",
+	"high":       "high",
+	"low":        "low",
+	"close":      "close",
+	"volume":     "volume",
+	"logo":       "logo",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// CompanyInfoFields maps the JSON fields a company info list may be projected to onto their columns
+var CompanyInfoFields = map[string]string{
+	"symbol":           "symbol",
+	"name":             "name",
+	"price":            "price",
+	"afterHoursPrice":  "after_hours_price",
+	"change":           "change",
+	"percentChange":    "percent_change",
+	"open":             "open",
+	"high":             "high",
+	"low":              "low",
+	"yearHigh":         "year_high",
+	"yearLow":          "year_low",
+	"volume":           "volume",
+	"avgVolume":        "avg_volume",
+	"marketCap":        "market_cap",
+	"beta":             "beta",
+	"pe":               "pe",
+	"earningsDate":     "earnings_date",
+	"sector":           "sector",
+	"industry":         "industry",
+	"about":            "about",
+	"employees":        "employees",
+	"fiveDaysReturn":   "five_days_return",
+	"oneMonthReturn":   "one_month_return",
+	"threeMonthReturn": "three_month_return",
+	"sixMonthReturn":   "six_month_return",
+	"ytdReturn":        "ytd_return",
+	"yearReturn":       "year_return",
+	"threeYearReturn":  "three_year_return",
+	"fiveYearReturn":   "five_year_return",
+	"tenYearReturn":    "ten_year_return",
+	"maxReturn":        "max_return",
+	"logo":             "logo",
+	"created_at":       "created_at",
+	"updated_at":       "updated_at",
+}
+
+// ParseFields parses a comma-separated ?fields= value against an allowlist
+// Returns nil for an empty value (no projection); duplicates are dropped, order is kept
+func ParseFields(raw string, allowed map[string]string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	fields := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		field := strings.TrimSpace(part)
+		if field == "" || seen[field] {
+			continue
+		}
+		if _, ok := allowed[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q. Allowed fields: %s", field, strings.Join(allowedFieldNames(allowed), ", "))
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// allowedFieldNames returns the allowlist's field names, sorted
+func allowedFieldNames(allowed map[string]string) []string {
+	names := make([]string, 0, len(allowed))
+	for name := range allowed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// projectionColumns returns the columns to SELECT for the requested fields
+// updated_at is always included so responses can still report freshness
+func projectionColumns(fields []string, allowed map[string]string) []string {
+	columns := make([]string, 0, len(fields)+1)
+	hasUpdatedAt := false
+	for _, field := range fields {
+		column := allowed[field]
+		if column == "updated_at" {
+			hasUpdatedAt = true
+		}
+		columns = append(columns, column)
+	}
+	if !hasUpdatedAt {
+		columns = append(columns, "updated_at")
+	}
+	return columns
+}
+
+// ProjectFields converts a slice of structs into maps holding only the requested JSON fields
+// Fields are matched by their json tag; omitempty is ignored so requested fields are always present
+func ProjectFields(rows interface{}, fields []string) []map[string]interface{} {
+	value := reflect.ValueOf(rows)
+	if value.Kind() != reflect.Slice {
+		return []map[string]interface{}{}
+	}
+
+	projected := make([]map[string]interface{}, 0, value.Len())
+	if value.Len() == 0 {
+		return projected
+	}
+
+	// Resolve field indexes once from the element type
+	elemType := value.Type().Elem()
+	indexes := make(map[string]int, elemType.NumField())
+	for i := 0; i < elemType.NumField(); i++ {
+		name := strings.Split(elemType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			indexes[name] = i
+		}
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		item := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if idx, ok := indexes[field]; ok {
+				item[field] = row.Field(idx).Interface()
+			}
+		}
+		projected = append(projected, item)
+	}
+	return projected
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"screener/backend/model"
+
+	"gorm.io/gorm/schema"
+)
+
+func TestParseFieldsRejectsInvalidNames(t *testing.T) {
+	tests := []struct {
+		raw     string
+		allowed map[string]string
+		bad     string
+	}{
+		{"symbol,price", ScreenerFields, "price"},                     // Company info field on the screener
+		{"Symbol", ScreenerFields, "Symbol"},                          // Field names are case-sensitive
+		{"after_hours_price", CompanyInfoFields, "after_hours_price"}, // Column name, not the JSON field
+		{"symbol;DROP TABLE screener", ScreenerFields, "symbol;DROP TABLE screener"},
+		{"*", CompanyInfoFields, "*"},
+		{"symbol, close ,bogus", ScreenerFields, "bogus"},
+		{"symbol.close", ScreenerFields, "symbol.close"},
+	}
+	for _, tt := range tests {
+		fields, err := ParseFields(tt.raw, tt.allowed)
+		if err == nil {
+			t.Errorf("ParseFields(%q) = %v, want an unknown field error", tt.raw, fields)
+			continue
+		}
+		if !strings.Contains(err.Error(), `"`+tt.bad+`"`) || !strings.Contains(err.Error(), "Allowed fields: ") {
+			t.Errorf("ParseFields(%q) error = %q, want it to name %q and list the allowed fields", tt.raw, err, tt.bad)
+		}
+	}
+}
+
+func TestParseFieldsAcceptsValidNames(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{",,", nil},
+		{"symbol", []string{"symbol"}},
+		{" close , symbol,close,", []string{"close", "symbol"}}, // Trimmed, deduplicated, order kept
+	}
+	for _, tt := range tests {
+		got, err := ParseFields(tt.raw, ScreenerFields)
+		if err != nil {
+			t.Errorf("ParseFields(%q) returned error: %v", tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseFields(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+// TestProjectionAllowlistsMatchTheModels guards against fields that would parse but project nothing,
+// or select a column that doesn't exist
+func TestProjectionAllowlistsMatchTheModels(t *testing.T) {
+	for name, tt := range map[string]struct {
+		allowed map[string]string
+		model   interface{}
+	}{
+		"screener":     {ScreenerFields, &model.Screener{}},
+		"company info": {CompanyInfoFields, &model.CompanyInfo{}},
+	} {
+		s, err := schema.Parse(tt.model, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatalf("%s: failed to parse schema: %v", name, err)
+		}
+		jsonFields := make(map[string]string)
+		elemType := reflect.TypeOf(tt.model).Elem()
+		for i := 0; i < elemType.NumField(); i++ {
+			if jsonName := strings.Split(elemType.Field(i).Tag.Get("json"), ",")[0]; jsonName != "" {
+				jsonFields[jsonName] = elemType.Field(i).Name
+			}
+		}
+
+		for field, column := range tt.allowed {
+			goName, ok := jsonFields[field]
+			if !ok {
+				t.Errorf("%s: allowed field %q is not a JSON field of the model", name, field)
+				continue
+			}
+			if f := s.LookUpField(column); f == nil || f.Name != goName {
+				t.Errorf("%s: field %q maps to column %q, which isn't the column of %s", name, field, column, goName)
+			}
+		}
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	rows := []model.Screener{{Symbol: "AAPL", Close: 190.5, Volume: 1000}, {Symbol: "MSFT", Close: 410}}
+
+	got := ProjectFields(rows, []string{"symbol", "close", "not_a_field"})
+	want := []map[string]interface{}{
+		{"symbol": "AAPL", "close": 190.5},
+		{"symbol": "MSFT", "close": 410.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ProjectFields = %v, want %v", got, want)
+	}
+
+	if got := ProjectFields("not a slice", []string{"symbol"}); len(got) != 0 {
+		t.Errorf("ProjectFields of a non-slice = %v, want empty", got)
+	}
+	if columns := projectionColumns([]string{"symbol", "close"}, ScreenerFields); !reflect.DeepEqual(columns, []string{"symbol", "close", "updated_at"}) {
+		t.Errorf("projectionColumns = %v, want updated_at appended", columns)
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"screener/backend/format"
+	"screener/backend/service/caching"
+)
+
+// ErrQuoteNotFound is returned when the upstream has no quote for a symbol
+var ErrQuoteNotFound = errors.New("quote not found")
+
+// Quote is a normalized simple quote fetched live from the upstream, never persisted
+type Quote struct {
+	Symbol          string    `json:"symbol"`
+	Name            string    `json:"name"`
+	Price           *float64  `json:"price"`
+	AfterHoursPrice *float64  `json:"after_hours_price"`
+	Change          *float64  `json:"change"`
+	PercentChange   *float64  `json:"percent_change"`
+	Logo            string    `json:"logo,omitempty"`
+	FetchedAt       time.Time `json:"fetched_at"`
+}
+
+// GetQuote fetches the simple quote for one symbol through the failover endpoints, bypassing the DB.
+// Results are cached for the CACHE_TTL_QUOTE window (default 5s) to absorb bursts of preview lookups.
+func (s *FetcherService) GetQuote(ctx context.Context, symbol string) (*Quote, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return nil, errors.New("symbol cannot be empty")
+	}
+
+	cacheKey := caching.QuoteKey(symbol)
+	var quote Quote
+	if found, err := s.cache.GetJSON(cacheKey, &quote); err == nil && found {
+		return &quote, nil
+	}
+
+	// The raw upstream cache holds responses for minutes; live quotes always go upstream
+	quotes, err := s.fetchSimpleQuotes(WithoutUpstreamCache(ctx), []string{symbol})
+	if err != nil {
+		if errors.Is(err, ErrEmptyUpstreamPayload) {
+			return nil, ErrQuoteNotFound
+		}
+		return nil, err
+	}
+
+	for _, q := range quotes {
+		if !strings.EqualFold(q.Symbol, symbol) {
+			continue
+		}
+		quote = Quote{
+			Symbol:          strings.ToUpper(q.Symbol),
+			Name:            q.Name,
+			Price:           parseQuoteNumber(q.Price),
+			AfterHoursPrice: parseQuoteNumber(q.AfterHoursPrice),
+			Change:          parseQuoteNumber(q.Change),
+			Logo:            q.Logo,
+			FetchedAt:       time.Now().UTC(),
+		}
+		if percent, err := parsePercentChange(q.PercentChange); err == nil {
+			quote.PercentChange = &percent
+		}
+		_ = s.cache.SetJSON(cacheKey, quote, s.ttl.Quote)
+		return &quote, nil
+	}
+
+	return nil, ErrQuoteNotFound
+}
+
+// parseQuoteNumber parses a quote price or change string, returning nil when it is empty or malformed
+func parseQuoteNumber(value string) *float64 {
+	number, err := format.ParsePrice(value)
+	if err != nil {
+		return nil
+	}
+	return &number
+}
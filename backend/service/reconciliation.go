@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultCloseTolerancePct is the default allowed divergence (percent) between the aggregated
+// 1m close and the upstream's reported daily close
+const defaultCloseTolerancePct = 0.5
+
+// CloseDiscrepancy records a symbol whose aggregated close diverged from the reported daily close
+type CloseDiscrepancy struct {
+	Symbol          string    `json:"symbol"`
+	AggregatedClose float64   `json:"aggregated_close"`
+	ReportedClose   float64   `json:"reported_close"`
+	DiffPct         float64   `json:"diff_pct"`
+	CheckedAt       time.Time `json:"checked_at"`
+}
+
+// CloseReconciliationReport lists the current discrepancies, largest divergence first
+type CloseReconciliationReport struct {
+	Enabled       bool               `json:"enabled"`
+	TolerancePct  float64            `json:"tolerance_pct"`
+	Checked       int64              `json:"checked"`
+	Discrepancies []CloseDiscrepancy `json:"discrepancies"`
+}
+
+// closeReconciler holds the latest discrepancy per symbol for this process
+// A symbol is removed once a later check is back within tolerance
+type closeReconciler struct {
+	mu            sync.Mutex
+	discrepancies map[string]CloseDiscrepancy
+	checked       int64
+}
+
+var globalCloseReconciler = &closeReconciler{discrepancies: make(map[string]CloseDiscrepancy)}
+
+// isCloseReconciliationEnabled reports whether processSymbol cross-checks aggregated closes
+// Controlled by RECONCILE_SCREENER_CLOSE (default: false, since it costs one extra request per symbol)
+func isCloseReconciliationEnabled() bool {
+	value := os.Getenv("RECONCILE_SCREENER_CLOSE")
+	return value == "true" || value == "1"
+}
+
+// getCloseTolerancePct returns the allowed close divergence in percent (RECONCILE_CLOSE_TOLERANCE_PCT)
+func getCloseTolerancePct() float64 {
+	value := os.Getenv("RECONCILE_CLOSE_TOLERANCE_PCT")
+	if value == "" {
+		return defaultCloseTolerancePct
+	}
+	tolerance, err := strconv.ParseFloat(value, 64)
+	if err != nil || tolerance < 0 {
+		log.Printf("Warning: invalid RECONCILE_CLOSE_TOLERANCE_PCT %q, using %v", value, defaultCloseTolerancePct)
+		return defaultCloseTolerancePct
+	}
+	return tolerance
+}
+
+// reconcileDailyClose fetches the upstream's 1d/1d bar and compares its close to the close
+// aggregated from 1m bars. Fetch failures are ignored; the check is advisory only.
+func (s *FetcherService) reconcileDailyClose(ctx context.Context, symbol string, aggregated *dailyOHLCV) {
+	bars, err := s.fetchBars(ctx, symbol, "1d", "1d")
+	if err != nil || len(bars) == 0 {
+		return
+	}
+	reported := bars[len(bars)-1].Close
+	globalCloseReconciler.record(symbol, aggregated.Close, reported, getCloseTolerancePct())
+}
+
+// record compares an aggregated close to the reported close, keeping or clearing the symbol's discrepancy
+func (r *closeReconciler) record(symbol string, aggregatedClose, reportedClose, tolerancePct float64) {
+	if reportedClose == 0 {
+		return
+	}
+	diffPct := (aggregatedClose - reportedClose) / reportedClose * 100
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checked++
+	if math.Abs(diffPct) <= tolerancePct {
+		delete(r.discrepancies, symbol)
+		return
+	}
+
+	log.Printf("Warning: %s aggregated close %.4f differs from reported close %.4f by %.2f%%",
+		symbol, aggregatedClose, reportedClose, diffPct)
+	r.discrepancies[symbol] = CloseDiscrepancy{
+		Symbol:          symbol,
+		AggregatedClose: aggregatedClose,
+		ReportedClose:   reportedClose,
+		DiffPct:         diffPct,
+		CheckedAt:       time.Now().UTC(),
+	}
+}
+
+// GetCloseReconciliationReport returns the discrepancies found since startup, largest first
+func GetCloseReconciliationReport() CloseReconciliationReport {
+	r := globalCloseReconciler
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	discrepancies := make([]CloseDiscrepancy, 0, len(r.discrepancies))
+	for _, d := range r.discrepancies {
+		discrepancies = append(discrepancies, d)
+	}
+	sort.Slice(discrepancies, func(i, j int) bool {
+		return math.Abs(discrepancies[i].DiffPct) > math.Abs(discrepancies[j].DiffPct)
+	})
+
+	return CloseReconciliationReport{
+		Enabled:       isCloseReconciliationEnabled(),
+		TolerancePct:  getCloseTolerancePct(),
+		Checked:       r.checked,
+		Discrepancies: discrepancies,
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+type regularHoursKey struct{}
+
+// WithRegularHoursOnly returns a context whose historicals ingestion aggregates the screener's
+// daily OHLCV from regular-session 1m bars only, dropping pre-market and after-hours bars
+func WithRegularHoursOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, regularHoursKey{}, true)
+}
+
+// regularHoursOnly reports whether ctx opted in to regular-hours aggregation, or
+// INTRADAY_REGULAR_HOURS_ONLY enables it for every ingestion (default: false, extended hours included)
+func regularHoursOnly(ctx context.Context) bool {
+	if enabled, _ := ctx.Value(regularHoursKey{}).(bool); enabled {
+		return true
+	}
+	value := os.Getenv("INTRADAY_REGULAR_HOURS_ONLY")
+	return value == "true" || value == "1"
+}
+
+// regularSessionBars keeps the bars that start within the regular session, 09:30 up to the
+// 16:00 close (13:00 on early-close days) in America/New_York; a bar's epoch is its start time.
+// The result is empty before the open, in which case there is nothing to aggregate yet.
+func regularSessionBars(bars []externalBar) []externalBar {
+	calendar := DefaultMarketCalendar()
+	regular := make([]externalBar, 0, len(bars))
+	for _, b := range bars {
+		if calendar.InRegularSession(time.Unix(b.Epoch, 0)) {
+			regular = append(regular, b)
+		}
+	}
+	return regular
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+// minuteBar returns a 1m bar starting at hh:mm market time on the given date
+func minuteBar(t *testing.T, date string, hour, minute int) externalBar {
+	t.Helper()
+	start := marketTime(t, date, hour, minute)
+	return externalBar{Epoch: start.Unix(), Open: 1, High: 1, Low: 1, Close: 1, Volume: 1}
+}
+
+func TestRegularSessionBars(t *testing.T) {
+	tests := []struct {
+		name string
+		bar  externalBar
+		keep bool
+	}{
+		{"pre-market 04:00", minuteBar(t, "2025-11-26", 4, 0), false},
+		{"pre-market 09:29", minuteBar(t, "2025-11-26", 9, 29), false},
+		{"open 09:30", minuteBar(t, "2025-11-26", 9, 30), true},
+		{"midday 12:00", minuteBar(t, "2025-11-26", 12, 0), true},
+		{"last regular bar 15:59", minuteBar(t, "2025-11-26", 15, 59), true},
+		{"after-hours 16:00", minuteBar(t, "2025-11-26", 16, 0), false},
+		{"after-hours 19:59", minuteBar(t, "2025-11-26", 19, 59), false},
+		{"holiday 10:00", minuteBar(t, "2025-11-27", 10, 0), false},
+		{"early close open 09:30", minuteBar(t, "2025-11-28", 9, 30), true},
+		{"early close last bar 12:59", minuteBar(t, "2025-11-28", 12, 59), true},
+		{"early close 13:00", minuteBar(t, "2025-11-28", 13, 0), false},
+		{"early close 15:00", minuteBar(t, "2025-11-28", 15, 0), false},
+		{"weekend 10:00", minuteBar(t, "2025-11-29", 10, 0), false},
+	}
+
+	bars := make([]externalBar, 0, len(tests))
+	want := make([]string, 0)
+	for _, tt := range tests {
+		bars = append(bars, tt.bar)
+		if tt.keep {
+			want = append(want, tt.name)
+		}
+	}
+
+	got := regularSessionBars(bars)
+	kept := make(map[int64]bool, len(got))
+	for _, b := range got {
+		kept[b.Epoch] = true
+	}
+	for _, tt := range tests {
+		if kept[tt.bar.Epoch] != tt.keep {
+			t.Errorf("%s: kept = %v, want %v", tt.name, kept[tt.bar.Epoch], tt.keep)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("regularSessionBars kept %d bars, want %d (%v)", len(got), len(want), want)
+	}
+}
+
+func TestRegularSessionBarsBeforeTheOpen(t *testing.T) {
+	bars := []externalBar{minuteBar(t, "2025-11-26", 7, 0), minuteBar(t, "2025-11-26", 9, 15)}
+	if got := regularSessionBars(bars); len(got) != 0 {
+		t.Errorf("regularSessionBars kept %d pre-market bars, want none", len(got))
+	}
+}
+
+func TestRegularHoursOnly(t *testing.T) {
+	t.Setenv("INTRADAY_REGULAR_HOURS_ONLY", "")
+	if regularHoursOnly(context.Background()) {
+		t.Error("regularHoursOnly = true by default, want extended hours included")
+	}
+	if !regularHoursOnly(WithRegularHoursOnly(context.Background())) {
+		t.Error("regularHoursOnly = false for a WithRegularHoursOnly context")
+	}
+	for value, want := range map[string]bool{"true": true, "1": true, "false": false, "yes": false} {
+		t.Setenv("INTRADAY_REGULAR_HOURS_ONLY", value)
+		if got := regularHoursOnly(context.Background()); got != want {
+			t.Errorf("INTRADAY_REGULAR_HOURS_ONLY=%q gives %v, want %v", value, got, want)
+		}
+	}
+}
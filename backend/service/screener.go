@@ -3,9 +3,11 @@ package service
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"screener/backend/database"
 	"screener/backend/model"
 	"screener/backend/service/caching"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -19,18 +21,21 @@ type ScreenerService struct {
 
 // FilterOptions represents filtering options for screener queries
 type FilterOptions struct {
-	MinPrice  *float64
-	MaxPrice  *float64
-	MinVolume *int64
-	MaxVolume *int64
-	MinOpen   *float64
-	MaxOpen   *float64
-	MinHigh   *float64
-	MaxHigh   *float64
-	MinLow    *float64
-	MaxLow    *float64
-	MinClose  *float64
-	MaxClose  *float64
+	MinPrice        *float64
+	MaxPrice        *float64
+	MinVolume       *int64
+	MaxVolume       *int64
+	MinOpen         *float64
+	MaxOpen         *float64
+	MinHigh         *float64
+	MaxHigh         *float64
+	MinLow          *float64
+	MaxLow          *float64
+	MinClose        *float64
+	MaxClose        *float64
+	MinDollarVolume *float64 // close * volume
+	MaxDollarVolume *float64 // close * volume
+	Symbols         []string // Restrict results to this symbol universe
 }
 
 // SortOptions represents sorting options for screener queries
@@ -39,6 +44,48 @@ type SortOptions struct {
 	Direction string // "asc" or "desc"
 }
 
+// screenerSortFields lists the columns screener queries may be sorted by
+var screenerSortFields = map[string]bool{
+	"symbol":     true,
+	"open":       true,
+	"high":       true,
+	"low":        true,
+	"close":      true,
+	"volume":     true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// ParseSortOptions parses a comma-separated sort param (e.g. "volume:desc,symbol:asc")
+// into an ordered list of SortOptions. Direction defaults to "asc" when omitted.
+// Returns an error for unknown fields or directions.
+func ParseSortOptions(sortParam string) ([]SortOptions, error) {
+	sorts := make([]SortOptions, 0)
+	for _, part := range strings.Split(sortParam, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field, direction, _ := strings.Cut(part, ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		if direction == "" {
+			direction = "asc"
+		}
+
+		if !screenerSortFields[field] {
+			return nil, fmt.Errorf("invalid sort field: %s", field)
+		}
+		if direction != "asc" && direction != "desc" {
+			return nil, fmt.Errorf("invalid sort direction for %s: %s", field, direction)
+		}
+
+		sorts = append(sorts, SortOptions{Field: field, Direction: direction})
+	}
+	return sorts, nil
+}
+
 // PaginationOptions represents pagination options
 type PaginationOptions struct {
 	Page  int // 1-indexed page number
@@ -54,6 +101,87 @@ type QueryResult struct {
 	TotalPages int              `json:"total_pages"`
 }
 
+// MaxFilterSymbols caps the number of symbols accepted by the screener symbol filter
+const MaxFilterSymbols = 500
+
+// symbolPattern matches valid ticker symbols (e.g. AAPL, BRK.B, BF-B, ^GSPC)
+var symbolPattern = regexp.MustCompile(`^[A-Z0-9^][A-Z0-9.\-=^]{0,19}$`)
+
+// NormalizeFilterSymbols uppercases, trims and de-duplicates a symbol list,
+// rejecting malformed symbols and lists longer than MaxFilterSymbols
+func NormalizeFilterSymbols(symbols []string) ([]string, error) {
+	seen := make(map[string]bool, len(symbols))
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if symbol == "" || seen[symbol] {
+			continue
+		}
+		if !symbolPattern.MatchString(symbol) {
+			return nil, fmt.Errorf("invalid symbol: %s", symbol)
+		}
+		seen[symbol] = true
+		normalized = append(normalized, symbol)
+	}
+
+	if len(normalized) > MaxFilterSymbols {
+		return nil, fmt.Errorf("too many symbols: %d (max %d)", len(normalized), MaxFilterSymbols)
+	}
+	return normalized, nil
+}
+
+// ScreenerDTO is a Screener record enriched with computed fields for clients
+type ScreenerDTO struct {
+	model.Screener
+	ChangePct    float64 `json:"change_pct"`    // (close - open) / open * 100
+	RangePct     float64 `json:"range_pct"`     // (high - low) / close * 100
+	DollarVolume float64 `json:"dollar_volume"` // close * volume
+}
+
+// EnrichedQueryResult represents a paginated query result with enriched records
+type EnrichedQueryResult struct {
+	Data       []ScreenerDTO `json:"data"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+	Total      int64         `json:"total"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// EnrichScreener computes the derived fields for a single screener record
+func EnrichScreener(screener model.Screener) ScreenerDTO {
+	dto := ScreenerDTO{
+		Screener:     screener,
+		DollarVolume: screener.Close * float64(screener.Volume),
+	}
+	if screener.Open != 0 {
+		dto.ChangePct = (screener.Close - screener.Open) / screener.Open * 100.0
+	}
+	if screener.Close != 0 {
+		dto.RangePct = (screener.High - screener.Low) / screener.Close * 100.0
+	}
+	return dto
+}
+
+// EnrichScreeners computes the derived fields for a list of screener records
+func EnrichScreeners(screeners []model.Screener) []ScreenerDTO {
+	dtos := make([]ScreenerDTO, 0, len(screeners))
+	for _, screener := range screeners {
+		dtos = append(dtos, EnrichScreener(screener))
+	}
+	return dtos
+}
+
+// EnrichQueryResult converts a paginated query result into its enriched form
+func EnrichQueryResult(result *QueryResult) *EnrichedQueryResult {
+	return &EnrichedQueryResult{
+		Data:       EnrichScreeners(result.Data),
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+}
+
 // NewScreenerService creates a new instance of ScreenerService
 func NewScreenerService() *ScreenerService {
 	return &ScreenerService{
@@ -78,10 +206,43 @@ func (s *ScreenerService) GetAllScreeners() ([]model.Screener, error) {
 		return nil, result.Error
 	}
 
+	_, _ = s.cache.SetJSONWithETag(cacheKey, screeners, s.ttl.Screener)
+	return screeners, nil
+}
+
+// GetAllScreenersFields is GetAllScreeners selecting only the given fields (validated with
+// ParseFields against ScreenerFields); other fields are left zero so callers should ProjectFields
+func (s *ScreenerService) GetAllScreenersFields(fields []string) ([]model.Screener, error) {
+	if len(fields) == 0 {
+		return s.GetAllScreeners()
+	}
+
+	cacheKey := caching.ScreenerProjectionKey(fields)
+	var screeners []model.Screener
+
+	found, err := s.cache.GetJSON(cacheKey, &screeners)
+	if err == nil && found {
+		return screeners, nil
+	}
+
+	result := s.db.Select(projectionColumns(fields, ScreenerFields)).Find(&screeners)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
 	_ = s.cache.SetJSON(cacheKey, screeners, s.ttl.Screener)
 	return screeners, nil
 }
 
+// GetAllScreenersETag returns the ETag of the cached all-screeners list, if present
+func (s *ScreenerService) GetAllScreenersETag() (string, bool) {
+	etag, found, err := s.cache.GetETag(caching.GenerateKeyFromPath("screener"))
+	if err != nil {
+		return "", false
+	}
+	return etag, found
+}
+
 // GetScreenerByID fetches a screener record by ID
 func (s *ScreenerService) GetScreenerByID(id string) (*model.Screener, error) {
 	var screener model.Screener
@@ -132,7 +293,7 @@ func (s *ScreenerService) GetScreenersBySymbols(symbols []string) ([]model.Scree
 // GetScreenersWithFilters fetches screener records with filtering, sorting, and pagination
 func (s *ScreenerService) GetScreenersWithFilters(
 	filters *FilterOptions,
-	sort *SortOptions,
+	sorts []SortOptions,
 	pagination *PaginationOptions,
 ) (*QueryResult, error) {
 	query := s.db.Model(&model.Screener{})
@@ -175,6 +336,15 @@ func (s *ScreenerService) GetScreenersWithFilters(
 		if filters.MaxClose != nil {
 			query = query.Where("close <= ?", *filters.MaxClose)
 		}
+		if filters.MinDollarVolume != nil {
+			query = query.Where("close * volume >= ?", *filters.MinDollarVolume)
+		}
+		if filters.MaxDollarVolume != nil {
+			query = query.Where("close * volume <= ?", *filters.MaxDollarVolume)
+		}
+		if len(filters.Symbols) > 0 {
+			query = query.Where("symbol IN ?", filters.Symbols)
+		}
 	}
 
 	// Get total count before pagination
@@ -184,27 +354,21 @@ func (s *ScreenerService) GetScreenersWithFilters(
 		return nil, fmt.Errorf("failed to count records: %w", err)
 	}
 
-	// Apply sorting
-	if sort != nil && sort.Field != "" {
+	// Apply sorting as successive ORDER BY clauses
+	sorted := false
+	for _, sort := range sorts {
+		// Validate field name to prevent SQL injection
+		if sort.Field == "" || !screenerSortFields[sort.Field] {
+			continue
+		}
 		direction := "ASC"
 		if sort.Direction == "desc" {
 			direction = "DESC"
 		}
-		// Validate field name to prevent SQL injection
-		validFields := map[string]bool{
-			"symbol":     true,
-			"open":       true,
-			"high":       true,
-			"low":        true,
-			"close":      true,
-			"volume":     true,
-			"created_at": true,
-			"updated_at": true,
-		}
-		if validFields[sort.Field] {
-			query = query.Order(fmt.Sprintf("%s %s", sort.Field, direction))
-		}
-	} else {
+		query = query.Order(fmt.Sprintf("%s %s", sort.Field, direction))
+		sorted = true
+	}
+	if !sorted {
 		// Default sorting by symbol
 		query = query.Order("symbol ASC")
 	}
@@ -321,6 +485,42 @@ func (s *ScreenerService) GetTopGainers(limit int) ([]model.Screener, error) {
 	return screeners, nil
 }
 
+// Recently updated screener feed bounds
+const (
+	DefaultScreenerRecentLimit = 50
+	MaxScreenerRecentLimit     = 200
+)
+
+// GetRecentlyUpdated fetches the most recently refreshed screener rows, newest first
+// The limit defaults to DefaultScreenerRecentLimit and is capped at MaxScreenerRecentLimit
+func (s *ScreenerService) GetRecentlyUpdated(limit int) ([]model.Screener, error) {
+	if limit <= 0 {
+		limit = DefaultScreenerRecentLimit
+	}
+	if limit > MaxScreenerRecentLimit {
+		limit = MaxScreenerRecentLimit
+	}
+
+	cacheKey := caching.ScreenerRecentKey(limit)
+	var screeners []model.Screener
+
+	found, err := s.cache.GetJSON(cacheKey, &screeners)
+	if err == nil && found {
+		return screeners, nil
+	}
+
+	result := s.db.Order("updated_at DESC").
+		Limit(limit).
+		Find(&screeners)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch recently updated screeners: %w", result.Error)
+	}
+
+	_ = s.cache.SetJSON(cacheKey, screeners, s.ttl.ScreenerRecent)
+	return screeners, nil
+}
+
 // GetMostActive fetches most active stocks by volume
 func (s *ScreenerService) GetMostActive(limit int) ([]model.Screener, error) {
 	if limit <= 0 {
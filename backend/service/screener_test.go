@@ -0,0 +1,61 @@
+package service
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestGetScreenersWithFiltersDollarVolumeBounds(t *testing.T) {
+	minDV, maxDV := 1e6, 5e6
+	tests := []struct {
+		name    string
+		filters *FilterOptions
+		where   string
+		args    []driver.Value
+	}{
+		{"min only", &FilterOptions{MinDollarVolume: &minDV}, `WHERE close * volume >= $1`, []driver.Value{minDV}},
+		{"max only", &FilterOptions{MaxDollarVolume: &maxDV}, `WHERE close * volume <= $1`, []driver.Value{maxDV}},
+		{"both, inclusive", &FilterOptions{MinDollarVolume: &minDV, MaxDollarVolume: &maxDV}, `WHERE close * volume >= $1 AND close * volume <= $2`, []driver.Value{minDV, maxDV}},
+		// min == max selects exactly that dollar volume
+		{"equal bounds", &FilterOptions{MinDollarVolume: &maxDV, MaxDollarVolume: &maxDV}, `WHERE close * volume >= $1 AND close * volume <= $2`, []driver.Value{maxDV, maxDV}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "screener" ` + tt.where + ` AND "screener"."deleted_at" IS NULL`)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "screener" ` + tt.where + ` AND "screener"."deleted_at" IS NULL ORDER BY symbol ASC LIMIT`)).
+				WillReturnRows(sqlmock.NewRows([]string{"symbol", "close", "volume"}).AddRow("AAPL", 100.0, int64(10000)))
+
+			s := &ScreenerService{db: db}
+			result, err := s.GetScreenersWithFilters(tt.filters, nil, nil)
+			if err != nil {
+				t.Fatalf("GetScreenersWithFilters returned error: %v", err)
+			}
+			if result.Total != 1 {
+				t.Errorf("Total = %d, want 1", result.Total)
+			}
+		})
+	}
+}
+
+func TestGetScreenersWithFiltersWithoutDollarVolume(t *testing.T) {
+	db, mock := newMockDB(t)
+	minVolume := int64(1000)
+
+	// Volume bounds alone must not add a dollar-volume clause
+	mock.ExpectQuery(`^` + regexp.QuoteMeta(`SELECT count(*) FROM "screener" WHERE volume >= $1 AND "screener"."deleted_at" IS NULL`) + `$`).
+		WithArgs(minVolume).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "screener" WHERE volume >= $1 AND "screener"."deleted_at" IS NULL ORDER BY`)).
+		WillReturnRows(sqlmock.NewRows([]string{"symbol"}))
+
+	s := &ScreenerService{db: db}
+	if _, err := s.GetScreenersWithFilters(&FilterOptions{MinVolume: &minVolume}, nil, nil); err != nil {
+		t.Fatalf("GetScreenersWithFilters returned error: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// symbolLocks serializes processSymbol per symbol, so a single-symbol refresh can't interleave
+// its screener update and historical writes with the same symbol in a bulk run (or a symbol
+// queued twice). Locks are process-local and removed once nothing holds or waits on them.
+var symbolLocks = &symbolLockSet{locks: make(map[string]*symbolLock)}
+
+type symbolLockSet struct {
+	mu    sync.Mutex
+	locks map[string]*symbolLock
+}
+
+type symbolLock struct {
+	held chan struct{} // Buffered (1): holding the lock means having sent into it
+	refs int           // Holders plus waiters; the entry is deleted at zero
+}
+
+// lock blocks until symbol is free or ctx is done, and returns the function that releases it
+func (s *symbolLockSet) lock(ctx context.Context, symbol string) (func(), error) {
+	symbol = strings.ToUpper(symbol)
+
+	s.mu.Lock()
+	l, ok := s.locks[symbol]
+	if !ok {
+		l = &symbolLock{held: make(chan struct{}, 1)}
+		s.locks[symbol] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	select {
+	case l.held <- struct{}{}:
+		return func() {
+			<-l.held
+			s.release(symbol, l)
+		}, nil
+	case <-ctx.Done():
+		s.release(symbol, l)
+		return nil, ctx.Err()
+	}
+}
+
+// release drops one reference to symbol's lock, deleting the entry when it was the last
+func (s *symbolLockSet) release(symbol string, l *symbolLock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l.refs--
+	if l.refs == 0 {
+		delete(s.locks, symbol)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"screener/backend/service/caching"
+	"time"
+)
+
+// SymbolDetail is one symbol of the tradeable universe (screener) with its company metadata
+// Company fields are empty when the symbol has no company_info row yet
+type SymbolDetail struct {
+	Symbol          string    `json:"symbol"`
+	Name            string    `json:"name"`
+	Sector          string    `json:"sector"`
+	Industry        string    `json:"industry"`
+	MarketCap       string    `json:"market_cap"`
+	MarketCapBucket string    `json:"market_cap_bucket"` // mega, large, mid, small, micro or unknown
+	Close           float64   `json:"close"`
+	Volume          int64     `json:"volume"`
+	Logo            string    `json:"logo,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"` // Screener row update time
+}
+
+// SymbolDetailPage is a paginated page of the symbol universe
+type SymbolDetailPage struct {
+	Data       []SymbolDetail `json:"data"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	Total      int64          `json:"total"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// GetSymbolsDetailed returns a page of screener symbols, ordered by symbol, joined with their
+// company info. Pages use the company-info list defaults and cap.
+func (s *ScreenerService) GetSymbolsDetailed(pagination *PaginationOptions) (*SymbolDetailPage, error) {
+	normalized := normalizeCompanyInfoPagination(pagination)
+
+	// Try to get from cache
+	cacheKey := caching.SymbolsDetailedKey(normalized.Page, normalized.Limit)
+	var page SymbolDetailPage
+
+	found, err := s.cache.GetJSON(cacheKey, &page)
+	if err == nil && found {
+		return &page, nil
+	}
+
+	// Cache miss - query database
+	var total int64
+	if err := s.db.Table("screener").Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count symbols: %w", err)
+	}
+
+	details := make([]SymbolDetail, 0, normalized.Limit)
+	err = s.db.Table("screener").
+		Select("screener.symbol, COALESCE(company_info.name, '') AS name, COALESCE(company_info.sector, '') AS sector, " +
+			"COALESCE(company_info.industry, '') AS industry, COALESCE(company_info.market_cap, '') AS market_cap, " +
+			"screener.close, screener.volume, COALESCE(NULLIF(screener.logo, ''), company_info.logo, '') AS logo, screener.updated_at").
+		Joins("LEFT JOIN company_info ON company_info.symbol = screener.symbol AND company_info.deleted_at IS NULL").
+		Where("screener.deleted_at IS NULL").
+		Order("screener.symbol ASC").
+		Offset((normalized.Page - 1) * normalized.Limit).
+		Limit(normalized.Limit).
+		Scan(&details).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch symbols: %w", err)
+	}
+	for i := range details {
+		details[i].MarketCapBucket = marketCapBucket(details[i].MarketCap)
+	}
+
+	result := &SymbolDetailPage{
+		Data:       details,
+		Page:       normalized.Page,
+		Limit:      normalized.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(normalized.Limit) - 1) / int64(normalized.Limit)),
+	}
+
+	// Store in cache
+	_ = s.cache.SetJSON(cacheKey, result, s.ttl.CompanyInfo)
+
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Endpoint selection strategies for fetchWithFailover, set via FINANCE_QUERY_STRATEGY
+const (
+	upstreamStrategyPrimaryFirst = "primary-first"
+	upstreamStrategyRoundRobin   = "round-robin"
+)
+
+// upstreamBalancer orders upstream endpoints for each request and tracks per-host health
+// A host whose circuit is open (too many consecutive failures) is moved to the back of the
+// order until its cooldown expires, so it is only tried when every healthy host has failed
+type upstreamBalancer struct {
+	strategy    string
+	maxFailures int
+	cooldown    time.Duration
+	next        uint64
+
+	mu    sync.Mutex
+	hosts map[string]*upstreamHostHealth
+}
+
+// upstreamHostHealth is the circuit breaker state for one upstream host
+type upstreamHostHealth struct {
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+var (
+	balancerOnce sync.Once
+	balancer     *upstreamBalancer
+)
+
+// getUpstreamBalancer returns the shared balancer, configured from the environment on first use
+func getUpstreamBalancer() *upstreamBalancer {
+	balancerOnce.Do(func() {
+		strategy := strings.ToLower(strings.TrimSpace(os.Getenv("FINANCE_QUERY_STRATEGY")))
+		if strategy != upstreamStrategyRoundRobin {
+			strategy = upstreamStrategyPrimaryFirst
+		}
+		balancer = &upstreamBalancer{
+			strategy:    strategy,
+			maxFailures: intFromEnv("FETCHER_BREAKER_FAILURES", 3),
+			cooldown:    time.Duration(intFromEnv("FETCHER_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+			hosts:       make(map[string]*upstreamHostHealth),
+		}
+	})
+	return balancer
+}
+
+// order returns the request URLs in the order they should be tried
+// Round-robin rotates the starting endpoint per call; hosts with an open circuit go last
+func (b *upstreamBalancer) order(requestURLs []string) []string {
+	ordered := requestURLs
+	if b.strategy == upstreamStrategyRoundRobin && len(requestURLs) > 1 {
+		start := int(atomic.AddUint64(&b.next, 1)-1) % len(requestURLs)
+		ordered = make([]string, 0, len(requestURLs))
+		ordered = append(ordered, requestURLs[start:]...)
+		ordered = append(ordered, requestURLs[:start]...)
+	}
+
+	if b.maxFailures <= 0 {
+		return ordered
+	}
+
+	now := time.Now()
+	healthy := make([]string, 0, len(ordered))
+	open := make([]string, 0)
+
+	b.mu.Lock()
+	for _, requestURL := range ordered {
+		if h, ok := b.hosts[upstreamHost(requestURL)]; ok && now.Before(h.openUntil) {
+			open = append(open, requestURL)
+			continue
+		}
+		healthy = append(healthy, requestURL)
+	}
+	b.mu.Unlock()
+
+	return append(healthy, open...)
+}
+
+// recordSuccess closes the circuit for the host that served a request
+func (b *upstreamBalancer) recordSuccess(requestURL string) {
+	host := upstreamHost(requestURL)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.hosts, host)
+}
+
+// recordFailure counts a failed request and opens the host's circuit once the threshold is reached
+func (b *upstreamBalancer) recordFailure(requestURL string) {
+	if b.maxFailures <= 0 {
+		return
+	}
+	host := upstreamHost(requestURL)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h, ok := b.hosts[host]
+	if !ok {
+		h = &upstreamHostHealth{}
+		b.hosts[host] = h
+	}
+	h.consecutiveFailures++
+	if h.consecutiveFailures >= b.maxFailures {
+		if time.Now().After(h.openUntil) {
+			log.Printf("Upstream circuit opened for %s after %d consecutive failures (cooldown %s)", host, h.consecutiveFailures, b.cooldown)
+		}
+		h.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// upstreamHost returns the host of a request URL, used as the circuit breaker key
+func upstreamHost(requestURL string) string {
+	parsed, err := url.Parse(requestURL)
+	if err != nil || parsed.Host == "" {
+		return requestURL
+	}
+	return parsed.Host
+}
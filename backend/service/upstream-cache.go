@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"screener/backend/service/caching"
+)
+
+// upstreamCacheBypassKey marks a context whose upstream fetches must skip the response cache
+type upstreamCacheBypassKey struct{}
+
+// WithoutUpstreamCache returns a context whose fetcher requests always go to the upstream
+// and don't read from the upstream response cache (fresh responses are still cached)
+func WithoutUpstreamCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, upstreamCacheBypassKey{}, true)
+}
+
+// upstreamCacheBypassed reports whether ctx was created by WithoutUpstreamCache
+func upstreamCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(upstreamCacheBypassKey{}).(bool)
+	return bypass
+}
+
+// upstreamCacheKey builds the cache key from the request path and query, ignoring the host
+func upstreamCacheKey(requestURL string) string {
+	parsed, err := url.Parse(requestURL)
+	if err != nil {
+		return caching.UpstreamResponseKey(requestURL)
+	}
+	return caching.UpstreamResponseKey(parsed.RequestURI())
+}
+
+// fetchUpstream fetches a finance-query request and passes the size-checked body to decode, serving
+// it from the upstream response cache when a copy younger than CACHE_TTL_UPSTREAM_RESPONSE exists.
+// decode unmarshals and checks the payload; a body is only cached once decode accepts it, so a
+// malformed or empty 200 is never replayed, and a cached copy decode rejects is refetched.
+// The outcome is also added to the ingestion run in ctx, if any.
+func (s *FetcherService) fetchUpstream(ctx context.Context, requestURLs []string, jobID string, batchNum, totalBatches int, decode func(body []byte) error) (upstreamOutcome, error) {
+	if len(requestURLs) == 0 {
+		return upstreamOutcome{}, errors.New("no upstream endpoints configured")
+	}
+	ttl := s.ttl.UpstreamResponse
+	cacheKey := upstreamCacheKey(requestURLs[0])
+
+	if ttl > 0 && !upstreamCacheBypassed(ctx) {
+		if body, err := s.cache.Get(cacheKey); err == nil && len(body) > 0 && decode(body) == nil {
+			outcome := upstreamOutcome{Cached: true}
+			recordUpstreamOutcome(ctx, outcome)
+			return outcome, nil
+		}
+	}
+
+	resp, outcome, err := s.fetchWithFailover(ctx, requestURLs, jobID, batchNum, totalBatches)
+	recordUpstreamOutcome(ctx, outcome)
+	if err != nil {
+		return outcome, err
+	}
+	defer resp.Body.Close()
+
+	body, err := readUpstreamBody(resp.Body)
+	if err != nil {
+		return outcome, err
+	}
+	if err := decode(body); err != nil {
+		return outcome, err
+	}
+
+	if ttl > 0 {
+		_ = s.cache.Set(cacheKey, body, ttl)
+	}
+	return outcome, nil
+}
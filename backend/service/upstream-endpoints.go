@@ -0,0 +1,75 @@
+package service
+
+import (
+	"net/url"
+	"os"
+	"strings"
+)
+
+// upstreamAPIConfig holds the finance-query API version and endpoint path segments
+// Each is configurable so an upstream path change doesn't require code edits
+type upstreamAPIConfig struct {
+	Version      string // FINANCE_QUERY_API_VERSION (default: v1)
+	Historical   string // FINANCE_QUERY_HISTORICAL_PATH (default: historical)
+	SimpleQuotes string // FINANCE_QUERY_SIMPLE_QUOTES_PATH (default: simple-quotes)
+	Quotes       string // FINANCE_QUERY_QUOTES_PATH (default: quotes)
+	Financials   string // FINANCE_QUERY_FINANCIALS_PATH (default: financials)
+}
+
+// getUpstreamAPIConfig reads the endpoint configuration from the environment
+func getUpstreamAPIConfig() upstreamAPIConfig {
+	return upstreamAPIConfig{
+		Version:      pathSegmentFromEnv("FINANCE_QUERY_API_VERSION", "v1"),
+		Historical:   pathSegmentFromEnv("FINANCE_QUERY_HISTORICAL_PATH", "historical"),
+		SimpleQuotes: pathSegmentFromEnv("FINANCE_QUERY_SIMPLE_QUOTES_PATH", "simple-quotes"),
+		Quotes:       pathSegmentFromEnv("FINANCE_QUERY_QUOTES_PATH", "quotes"),
+		Financials:   pathSegmentFromEnv("FINANCE_QUERY_FINANCIALS_PATH", "financials"),
+	}
+}
+
+// pathSegmentFromEnv reads a path segment, trimming surrounding slashes
+func pathSegmentFromEnv(name, defaultValue string) string {
+	value := strings.Trim(strings.TrimSpace(os.Getenv(name)), "/")
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// path joins the API version, an endpoint and escaped path parameters,
+// e.g. path(cfg.Financials, "AAPL") -> "/v1/financials/AAPL"
+func (c upstreamAPIConfig) path(endpoint string, params ...string) string {
+	segments := []string{c.Version, endpoint}
+	for _, p := range params {
+		segments = append(segments, url.PathEscape(p))
+	}
+
+	var b strings.Builder
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		b.WriteString("/")
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// buildUpstreamURL joins a base URL, path and query into a request URL
+func buildUpstreamURL(base, path string, query url.Values) string {
+	u := strings.TrimRight(base, "/") + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// upstreamURLs builds the request URL for a path and query on every configured endpoint, in failover order
+func upstreamURLs(path string, query url.Values) []string {
+	bases := getBaseURLs()
+	urls := make([]string, 0, len(bases))
+	for _, base := range bases {
+		urls = append(urls, buildUpstreamURL(base, path, query))
+	}
+	return urls
+}
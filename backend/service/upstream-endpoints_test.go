@@ -0,0 +1,78 @@
+package service
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestUpstreamURLsUseTheBasePathConfig(t *testing.T) {
+	t.Setenv("FINANCE_QUERY_URLS", "https://primary.example.com/, https://primary.example.com,https://fallback.example.com/api")
+	t.Setenv("FINANCE_QUERY_API_VERSION", "/v2/")
+	t.Setenv("FINANCE_QUERY_FINANCIALS_PATH", "statements")
+	t.Setenv("FINANCE_QUERY_HISTORICAL_PATH", "")
+
+	api := getUpstreamAPIConfig()
+	tests := []struct {
+		path  string
+		query url.Values
+		want  []string
+	}{
+		{
+			api.path(api.Historical),
+			url.Values{"symbol": {"AAPL"}, "range": {"1y"}, "interval": {"1d"}},
+			[]string{
+				"https://primary.example.com/v2/historical?interval=1d&range=1y&symbol=AAPL",
+				"https://fallback.example.com/api/v2/historical?interval=1d&range=1y&symbol=AAPL",
+			},
+		},
+		{
+			api.path(api.Financials, "BRK/B", "income"),
+			nil,
+			[]string{
+				"https://primary.example.com/v2/statements/BRK%2FB/income",
+				"https://fallback.example.com/api/v2/statements/BRK%2FB/income",
+			},
+		},
+		{
+			api.path(api.SimpleQuotes),
+			url.Values{"symbols": {"AAPL,MSFT"}},
+			[]string{
+				"https://primary.example.com/v2/simple-quotes?symbols=AAPL%2CMSFT",
+				"https://fallback.example.com/api/v2/simple-quotes?symbols=AAPL%2CMSFT",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := upstreamURLs(tt.path, tt.query)
+		if len(got) != len(tt.want) {
+			t.Errorf("upstreamURLs(%q) = %v, want %v", tt.path, got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("upstreamURLs(%q)[%d] = %q, want %q", tt.path, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestUpstreamURLsWithPrimaryAndFallbackHosts(t *testing.T) {
+	t.Setenv("FINANCE_QUERY_URLS", "")
+	t.Setenv("FINANCE_QUERY_PRIMARY_URL", "http://localhost:8000")
+	t.Setenv("FINANCE_QUERY_FALLBACK_URL", "http://localhost:8001")
+	t.Setenv("FINANCE_QUERY_API_VERSION", "")
+	t.Setenv("FINANCE_QUERY_QUOTES_PATH", "v1-detailed")
+
+	api := getUpstreamAPIConfig()
+	got := upstreamURLs(api.path(api.Quotes), url.Values{"symbols": {"AAPL"}})
+	want := []string{"http://localhost:8000/v1/v1-detailed?symbols=AAPL", "http://localhost:8001/v1/v1-detailed?symbols=AAPL"}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("upstreamURLs = %v, want %v", got, want)
+	}
+
+	// The same host twice collapses to a single endpoint
+	t.Setenv("FINANCE_QUERY_FALLBACK_URL", "http://localhost:8000")
+	if got := upstreamURLs(api.path(api.Quotes), nil); len(got) != 1 || got[0] != "http://localhost:8000/v1/v1-detailed" {
+		t.Errorf("upstreamURLs with identical hosts = %v, want one endpoint", got)
+	}
+}
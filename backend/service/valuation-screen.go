@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"screener/backend/format"
+	"screener/backend/model"
+	"sort"
+)
+
+// ValuationFilter bounds price-to-book and price-to-sales for the valuation screen
+type ValuationFilter struct {
+	MinPB     *float64 `json:"minPB,omitempty"`
+	MaxPB     *float64 `json:"maxPB,omitempty"`
+	MinPS     *float64 `json:"minPS,omitempty"`
+	MaxPS     *float64 `json:"maxPS,omitempty"`
+	Frequency string   `json:"frequency"` // "annual" (latest year) or "quarterly" (latest balance sheet, sum of the latest four quarters of revenue)
+}
+
+// ValuationMetrics is one symbol's P/B and P/S with the inputs they were computed from.
+// A ratio is nil when any of its inputs is missing or its denominator isn't positive
+// (negative book equity or no reported revenue), since the ratio isn't meaningful then.
+type ValuationMetrics struct {
+	Symbol            string   `json:"symbol"`
+	Price             *float64 `json:"price"`
+	MarketCap         *float64 `json:"marketCap"`
+	SharesOutstanding *float64 `json:"sharesOutstanding"`
+	TotalEquity       *float64 `json:"totalEquity"`
+	Revenue           *float64 `json:"revenue"` // Trailing year
+	EquityPeriod      string   `json:"equityPeriod,omitempty"`
+	RevenuePeriod     string   `json:"revenuePeriod,omitempty"`
+	PriceToBook       *float64 `json:"priceToBook"`  // price * shares / total equity
+	PriceToSales      *float64 `json:"priceToSales"` // market cap / revenue
+}
+
+// GetStocksByValuation returns stocks whose P/B and P/S fall within the filter bounds.
+// Equity and share counts come from balance sheets, revenue from income statements and price/market
+// cap from company_info. Missing inputs are handled as follows:
+//   - shares fall back to the income statement's average shares when the balance sheet has none
+//   - market cap falls back to price * shares, and price to the latest screener close
+//   - a symbol whose ratio can't be computed is excluded when that ratio is bounded, and returned
+//     with the ratio nil otherwise
+//
+// Results are sorted by symbol.
+func (s *FundamentalDataService) GetStocksByValuation(filter ValuationFilter) ([]ValuationMetrics, error) {
+	periods, err := trailingPeriods(filter.Frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := s.loadStatementMetrics(filter.Frequency, "balance", "income")
+	if err != nil {
+		return nil, err
+	}
+	balance, income := statements["balance"], statements["income"]
+
+	var companies []model.CompanyInfo
+	if err := s.db.Select("symbol", "price", "market_cap").Find(&companies).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch company info: %w", err)
+	}
+	companyInfo := make(map[string]model.CompanyInfo, len(companies))
+	for _, info := range companies {
+		companyInfo[info.Symbol] = info
+	}
+	closes, err := s.screenerCloses()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every symbol with either statement is a candidate
+	symbols := make(map[string]struct{}, len(balance)+len(income))
+	for symbol := range balance {
+		symbols[symbol] = struct{}{}
+	}
+	for symbol := range income {
+		symbols[symbol] = struct{}{}
+	}
+
+	results := make([]ValuationMetrics, 0)
+	for symbol := range symbols {
+		result := ValuationMetrics{Symbol: symbol}
+		info := companyInfo[symbol]
+
+		if price, err := format.ParsePrice(info.Price); err == nil && price > 0 {
+			result.Price = &price
+		} else if lastClose, ok := closes[symbol]; ok && lastClose > 0 {
+			result.Price = &lastClose
+		}
+
+		if metrics, ok := balance[symbol]; ok {
+			if period, equity, ok := latestDatedValue(metrics.TotalEquity); ok {
+				result.TotalEquity = &equity
+				result.EquityPeriod = period
+			}
+			if _, shares, ok := latestDatedValue(metrics.SharesOutstanding); ok && shares > 0 {
+				result.SharesOutstanding = &shares
+			}
+		}
+		if metrics, ok := income[symbol]; ok {
+			if result.SharesOutstanding == nil {
+				if _, shares, ok := latestDatedValue(metrics.AverageShares); ok && shares > 0 {
+					result.SharesOutstanding = &shares
+				}
+			}
+			if revenue, period, ok := trailingSum(metrics.TotalRevenue, periods); ok {
+				result.Revenue = &revenue
+				result.RevenuePeriod = period
+			}
+		}
+
+		if marketCap, err := format.ParseMarketCap(info.MarketCap); err == nil && marketCap > 0 {
+			result.MarketCap = &marketCap
+		} else if result.Price != nil && result.SharesOutstanding != nil {
+			marketCap := *result.Price * *result.SharesOutstanding
+			result.MarketCap = &marketCap
+		}
+
+		if result.Price != nil && result.SharesOutstanding != nil && result.TotalEquity != nil && *result.TotalEquity > 0 {
+			pb := *result.Price * *result.SharesOutstanding / *result.TotalEquity
+			result.PriceToBook = &pb
+		}
+		if result.MarketCap != nil && result.Revenue != nil && *result.Revenue > 0 {
+			ps := *result.MarketCap / *result.Revenue
+			result.PriceToSales = &ps
+		}
+
+		// Like growth rates, a missing ratio only matches when it isn't bounded
+		if !matchesGrowth(result.PriceToBook, filter.MinPB, filter.MaxPB) ||
+			!matchesGrowth(result.PriceToSales, filter.MinPS, filter.MaxPS) {
+			continue
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Symbol < results[j].Symbol })
+	return results, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"screener/backend/database"
 	"screener/backend/model"
+	"screener/backend/service/caching"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,13 +14,17 @@ import (
 
 // WatchlistService contains business logic for watchlist operations
 type WatchlistService struct {
-	db *gorm.DB
+	db    *gorm.DB
+	cache *caching.CacheService
+	ttl   *caching.CacheTTLConfig
 }
 
 // NewWatchlistService creates a new instance of WatchlistService
 func NewWatchlistService() *WatchlistService {
 	return &WatchlistService{
-		db: database.GetDB(),
+		db:    database.GetDB(),
+		cache: caching.NewCacheService(),
+		ttl:   caching.GetTTLConfig(),
 	}
 }
 
@@ -119,6 +125,9 @@ func (s *WatchlistService) AddItemToWatchlist(watchlistID uuid.UUID, item *model
 	if item.Name == "" {
 		return errors.New("name is required")
 	}
+	if err := validatePosition(item); err != nil {
+		return err
+	}
 
 	// Check if watchlist exists
 	var watchlist model.Watchlist
@@ -146,6 +155,7 @@ func (s *WatchlistService) AddItemToWatchlist(watchlistID uuid.UUID, item *model
 		return fmt.Errorf("failed to add item to watchlist: %w", createResult.Error)
 	}
 
+	item.ComputeUnrealizedPnL()
 	return nil
 }
 
@@ -179,6 +189,9 @@ func (s *WatchlistService) UpdateWatchlistItem(id string, item *model.WatchlistI
 	if item == nil {
 		return errors.New("item cannot be nil")
 	}
+	if err := validatePosition(item); err != nil {
+		return err
+	}
 
 	var existing model.WatchlistItem
 	result := s.db.Where("id = ?", id).First(&existing)
@@ -208,6 +221,12 @@ func (s *WatchlistService) UpdateWatchlistItem(id string, item *model.WatchlistI
 	if item.Logo != "" {
 		existing.Logo = item.Logo
 	}
+	if item.Quantity != nil {
+		existing.Quantity = item.Quantity
+	}
+	if item.CostBasis != nil {
+		existing.CostBasis = item.CostBasis
+	}
 	existing.Starred = item.Starred
 
 	updateResult := s.db.Save(&existing)
@@ -215,10 +234,25 @@ func (s *WatchlistService) UpdateWatchlistItem(id string, item *model.WatchlistI
 		return fmt.Errorf("failed to update watchlist item: %w", updateResult.Error)
 	}
 
+	existing.ComputeUnrealizedPnL()
 	*item = existing
 	return nil
 }
 
+// ErrInvalidPosition is returned when an item's quantity or cost basis is invalid
+var ErrInvalidPosition = errors.New("invalid position")
+
+// validatePosition rejects negative position fields; both are optional
+func validatePosition(item *model.WatchlistItem) error {
+	if item.Quantity != nil && *item.Quantity < 0 {
+		return fmt.Errorf("%w: quantity cannot be negative", ErrInvalidPosition)
+	}
+	if item.CostBasis != nil && *item.CostBasis < 0 {
+		return fmt.Errorf("%w: costBasis cannot be negative", ErrInvalidPosition)
+	}
+	return nil
+}
+
 // DeleteWatchlistItem removes an item from a watchlist
 func (s *WatchlistService) DeleteWatchlistItem(id string) error {
 	result := s.db.Where("id = ?", id).Delete(&model.WatchlistItem{})
@@ -266,7 +300,11 @@ func (s *WatchlistService) GetStarredItems(userID uuid.UUID) ([]model.WatchlistI
 	return items, nil
 }
 
+// MaxWatchlistItemBatchSize caps how many items one batch update request may carry
+const MaxWatchlistItemBatchSize = 1000
+
 // BatchUpdateItems updates multiple items in a watchlist (useful for price updates)
+// Only price fields are written; user-entered quantity and cost basis are left untouched
 func (s *WatchlistService) BatchUpdateItems(items []model.WatchlistItem) error {
 	if len(items) == 0 {
 		return errors.New("items cannot be empty")
@@ -293,3 +331,163 @@ func (s *WatchlistService) BatchUpdateItems(items []model.WatchlistItem) error {
 
 	return nil
 }
+
+// Watchlist Performance
+
+// WatchlistPerformer identifies one item in a watchlist performance summary
+type WatchlistPerformer struct {
+	ID            uuid.UUID `json:"id"`
+	Symbol        string    `json:"symbol,omitempty"`
+	Name          string    `json:"name"`
+	PercentChange float64   `json:"percent_change"`
+}
+
+// WatchlistPerformance is the aggregate daily performance of a watchlist's items
+// Items without a parseable PercentChange are counted in ItemCount but excluded from the stats
+type WatchlistPerformance struct {
+	WatchlistID     uuid.UUID           `json:"watchlist_id"`
+	ItemCount       int                 `json:"item_count"`
+	PricedCount     int                 `json:"priced_count"`
+	Up              int                 `json:"up"`
+	Down            int                 `json:"down"`
+	Unchanged       int                 `json:"unchanged"`
+	AverageChange   *float64            `json:"average_change"`
+	BestPerformer   *WatchlistPerformer `json:"best_performer"`
+	WorstPerformer  *WatchlistPerformer `json:"worst_performer"`
+	LastPriceUpdate *time.Time          `json:"last_price_update"`
+
+	// Position totals; nil unless at least one item has a quantity (and price/cost basis)
+	PositionCount int      `json:"position_count"`
+	MarketValue   *float64 `json:"market_value"`
+	CostBasis     *float64 `json:"cost_basis"`
+	UnrealizedPnL *float64 `json:"unrealized_pnl"`
+}
+
+// GetWatchlistPerformance returns aggregate performance for a watchlist owned by userID
+// Returns "record not found" when the watchlist doesn't exist or belongs to another user
+func (s *WatchlistService) GetWatchlistPerformance(watchlistID, userID uuid.UUID) (*WatchlistPerformance, error) {
+	var watchlist model.Watchlist
+	if err := s.db.Where("id = ? AND user_id = ?", watchlistID, userID).First(&watchlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("record not found")
+		}
+		return nil, err
+	}
+
+	// Cheap probe for the cache key: items change whenever prices are updated
+	var probe struct {
+		Count      int64
+		LastUpdate *time.Time
+	}
+	if err := s.db.Model(&model.WatchlistItem{}).
+		Select("COUNT(*) AS count, MAX(updated_at) AS last_update").
+		Where("watchlist_id = ?", watchlistID).
+		Scan(&probe).Error; err != nil {
+		return nil, fmt.Errorf("failed to check watchlist items: %w", err)
+	}
+
+	var lastUpdate int64
+	if probe.LastUpdate != nil {
+		lastUpdate = probe.LastUpdate.UnixNano()
+	}
+	cacheKey := caching.WatchlistPerformanceKey(watchlistID.String(), lastUpdate, probe.Count)
+
+	var performance WatchlistPerformance
+	if found, err := s.cache.GetJSON(cacheKey, &performance); err == nil && found {
+		return &performance, nil
+	}
+
+	items, err := s.GetWatchlistItems(watchlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	performance = summarizeWatchlistPerformance(watchlistID, items)
+	_ = s.cache.SetJSON(cacheKey, performance, s.ttl.WatchlistPerformance)
+
+	return &performance, nil
+}
+
+// summarizeWatchlistPerformance aggregates item percent changes into a performance summary
+func summarizeWatchlistPerformance(watchlistID uuid.UUID, items []model.WatchlistItem) WatchlistPerformance {
+	performance := WatchlistPerformance{
+		WatchlistID: watchlistID,
+		ItemCount:   len(items),
+	}
+
+	var total float64
+	for _, item := range items {
+		if performance.LastPriceUpdate == nil || item.UpdatedAt.After(*performance.LastPriceUpdate) {
+			updatedAt := item.UpdatedAt
+			performance.LastPriceUpdate = &updatedAt
+		}
+
+		addPosition(&performance, item)
+
+		if item.PercentChange == "" {
+			continue
+		}
+		percent, err := parsePercentChange(item.PercentChange)
+		if err != nil {
+			continue
+		}
+
+		performance.PricedCount++
+		total += percent
+		switch {
+		case percent > 0:
+			performance.Up++
+		case percent < 0:
+			performance.Down++
+		default:
+			performance.Unchanged++
+		}
+
+		performer := &WatchlistPerformer{
+			ID:            item.ID,
+			Symbol:        item.Symbol,
+			Name:          item.Name,
+			PercentChange: percent,
+		}
+		if performance.BestPerformer == nil || percent > performance.BestPerformer.PercentChange {
+			performance.BestPerformer = performer
+		}
+		if performance.WorstPerformer == nil || percent < performance.WorstPerformer.PercentChange {
+			performance.WorstPerformer = performer
+		}
+	}
+
+	if performance.PricedCount > 0 {
+		average := total / float64(performance.PricedCount)
+		performance.AverageChange = &average
+	}
+
+	return performance
+}
+
+// addPosition adds an item's position to the performance totals
+// Market value needs a price and cost needs a cost basis; P&L only counts items with both
+func addPosition(performance *WatchlistPerformance, item model.WatchlistItem) {
+	if item.Quantity == nil {
+		return
+	}
+	performance.PositionCount++
+
+	addTo := func(total **float64, value float64) {
+		if *total == nil {
+			*total = new(float64)
+		}
+		**total += value
+	}
+
+	quantity := *item.Quantity
+	if item.Price != nil {
+		addTo(&performance.MarketValue, *item.Price*quantity)
+	}
+	if item.CostBasis != nil {
+		addTo(&performance.CostBasis, *item.CostBasis*quantity)
+	}
+	if item.Price != nil && item.CostBasis != nil {
+		addTo(&performance.UnrealizedPnL, (*item.Price-*item.CostBasis)*quantity)
+	}
+}
@@ -0,0 +1,285 @@
+package supabase
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// jwk represents a single JSON Web Key as published by Supabase Auth
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// jwkSet represents a JWKS document
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// JWKSCache caches the public signing keys used to verify asymmetric Supabase JWTs.
+// Keys are refreshed periodically; if a refresh fails the previously cached keys keep
+// being used so verification survives transient Supabase outages.
+type JWKSCache struct {
+	mu              sync.RWMutex
+	refreshGroup    singleflight.Group // Collapses concurrent refreshes into one fetch
+	url             string
+	keys            map[string]crypto.PublicKey
+	lastFetched     time.Time
+	lastAttempt     time.Time
+	refreshInterval time.Duration
+	httpClient      *http.Client
+}
+
+// minRefetchInterval limits how often a lookup (an unknown key ID or stale keys) can trigger a refetch
+const minRefetchInterval = 1 * time.Minute
+
+var (
+	jwksCache     *JWKSCache
+	jwksCacheOnce sync.Once
+)
+
+// NewJWKSCache creates a JWKS cache for the given URL and refresh interval
+func NewJWKSCache(url string, refreshInterval time.Duration) *JWKSCache {
+	return &JWKSCache{
+		url:             url,
+		keys:            make(map[string]crypto.PublicKey),
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetJWKSCache returns the global JWKS cache, initializing it from the environment if needed.
+// The JWKS URL defaults to {SUPABASE_URL}/auth/v1/.well-known/jwks.json and can be overridden
+// with SUPABASE_JWKS_URL. The refresh interval is set via SUPABASE_JWKS_REFRESH_INTERVAL (default: 1h).
+// Returns nil if no JWKS URL can be determined.
+func GetJWKSCache() *JWKSCache {
+	jwksCacheOnce.Do(func() {
+		url := os.Getenv("SUPABASE_JWKS_URL")
+		if url == "" {
+			if supabaseURL := os.Getenv("SUPABASE_URL"); supabaseURL != "" {
+				url = strings.TrimRight(supabaseURL, "/") + "/auth/v1/.well-known/jwks.json"
+			}
+		}
+		if url == "" {
+			return
+		}
+
+		refreshInterval := 1 * time.Hour
+		if val := os.Getenv("SUPABASE_JWKS_REFRESH_INTERVAL"); val != "" {
+			if d, err := time.ParseDuration(val); err == nil && d > 0 {
+				refreshInterval = d
+			} else {
+				log.Printf("Warning: invalid SUPABASE_JWKS_REFRESH_INTERVAL %q, using %v", val, refreshInterval)
+			}
+		}
+
+		jwksCache = NewJWKSCache(url, refreshInterval)
+		go jwksCache.refreshLoop()
+	})
+	return jwksCache
+}
+
+// GetKey returns the public key for the given key ID.
+// Stale keys are still served while a refresh runs in the background, so a JWKS outage never
+// blocks verification; a missing key triggers a synchronous refetch in case the keys were rotated.
+// Both kinds of refetch are rate-limited by minRefetchInterval.
+func (j *JWKSCache) GetKey(kid string) (crypto.PublicKey, error) {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	stale := time.Since(j.lastFetched) > j.refreshInterval
+	j.mu.RUnlock()
+
+	if ok {
+		if stale && j.claimRefetch() {
+			go func() {
+				if err := j.sharedRefresh(); err != nil {
+					log.Printf("Warning: failed to refresh JWKS, using cached keys: %v", err)
+				}
+			}()
+		}
+		return key, nil
+	}
+
+	if !j.claimRefetch() {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+	if err := j.sharedRefresh(); err != nil {
+		log.Printf("Warning: failed to refresh JWKS, using cached keys: %v", err)
+	}
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if key, ok := j.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// claimRefetch reports whether a lookup may refetch now, recording the attempt so requests
+// arriving before the fetch starts don't claim one too
+func (j *JWKSCache) claimRefetch() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if time.Since(j.lastAttempt) <= minRefetchInterval {
+		return false
+	}
+	j.lastAttempt = time.Now()
+	return true
+}
+
+// sharedRefresh runs Refresh, joining a refresh already in flight instead of starting another
+func (j *JWKSCache) sharedRefresh() error {
+	_, err, _ := j.refreshGroup.Do("jwks", func() (interface{}, error) {
+		return nil, j.Refresh(context.Background())
+	})
+	return err
+}
+
+// Refresh fetches the JWKS document and replaces the cached keys.
+// On failure the existing keys are left untouched.
+func (j *JWKSCache) Refresh(ctx context.Context) error {
+	j.mu.Lock()
+	j.lastAttempt = time.Now()
+	j.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: status %d", resp.StatusCode)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys, err := parseJWKSet(set)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.lastFetched = time.Now()
+	j.mu.Unlock()
+
+	return nil
+}
+
+// refreshLoop periodically refreshes the cached keys
+func (j *JWKSCache) refreshLoop() {
+	if err := j.Refresh(context.Background()); err != nil {
+		log.Printf("Warning: initial JWKS fetch failed: %v", err)
+	}
+
+	ticker := time.NewTicker(j.refreshInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := j.Refresh(context.Background()); err != nil {
+			log.Printf("Warning: failed to refresh JWKS, using cached keys: %v", err)
+		}
+	}
+}
+
+// parseJWKSet converts a JWKS document into public keys indexed by key ID
+func parseJWKSet(set jwkSet) (map[string]crypto.PublicKey, error) {
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(k)
+		if err != nil {
+			log.Printf("Warning: skipping JWK %q: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS contains no usable signing keys")
+	}
+	return keys, nil
+}
+
+// parseJWK converts a single RSA or EC JWK into a public key
+func parseJWK(k jwk) (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBase64URLInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := decodeBase64URLInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBase64URLInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+// decodeBase64URLInt decodes a base64url-encoded big-endian integer
+func decodeBase64URLInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
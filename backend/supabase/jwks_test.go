@@ -0,0 +1,241 @@
+package supabase
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jwksServer serves a JWKS document that tests can swap or break between fetches
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	set     jwkSet
+	status  int
+	fetches int
+}
+
+func newJWKSServer(t *testing.T, keys ...jwk) *jwksServer {
+	t.Helper()
+	s := &jwksServer{set: jwkSet{Keys: keys}, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.fetches++
+		if s.status != http.StatusOK {
+			w.WriteHeader(s.status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) publish(keys ...jwk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = jwkSet{Keys: keys}
+}
+
+func (s *jwksServer) fail(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+// waitForFetches waits for a background refresh to reach the server
+func waitForFetches(t *testing.T, s *jwksServer, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for s.fetchCount() < want {
+		if time.Now().After(deadline) {
+			t.Fatalf("fetches = %d, want %d", s.fetchCount(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func base64URLInt(v *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(v.Bytes())
+}
+
+func newRSAJWK(t *testing.T, kid string) (jwk, *rsa.PublicKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	pub := &key.PublicKey
+	return jwk{Kid: kid, Kty: "RSA", Alg: "RS256", Use: "sig", N: base64URLInt(pub.N), E: base64URLInt(big.NewInt(int64(pub.E)))}, pub
+}
+
+func newECJWK(t *testing.T, kid string) (jwk, *ecdsa.PublicKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	pub := &key.PublicKey
+	return jwk{Kid: kid, Kty: "EC", Alg: "ES256", Use: "sig", Crv: "P-256", X: base64URLInt(pub.X), Y: base64URLInt(pub.Y)}, pub
+}
+
+func TestJWKSCacheParsesRSAAndECKeys(t *testing.T) {
+	rsaJWK, rsaPub := newRSAJWK(t, "rsa-1")
+	ecJWK, ecPub := newECJWK(t, "ec-1")
+	encryption := jwk{Kid: "enc-1", Kty: "RSA", Use: "enc", N: rsaJWK.N, E: rsaJWK.E}
+	server := newJWKSServer(t, rsaJWK, ecJWK, encryption)
+
+	cache := NewJWKSCache(server.URL, time.Hour)
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	got, err := cache.GetKey("rsa-1")
+	if err != nil {
+		t.Fatalf("GetKey(rsa-1) returned error: %v", err)
+	}
+	if key, ok := got.(*rsa.PublicKey); !ok || !key.Equal(rsaPub) {
+		t.Errorf("GetKey(rsa-1) = %v, want the published RSA key", got)
+	}
+
+	got, err = cache.GetKey("ec-1")
+	if err != nil {
+		t.Fatalf("GetKey(ec-1) returned error: %v", err)
+	}
+	if key, ok := got.(*ecdsa.PublicKey); !ok || !key.Equal(ecPub) {
+		t.Errorf("GetKey(ec-1) = %v, want the published EC key", got)
+	}
+
+	if _, err := cache.GetKey("enc-1"); err == nil {
+		t.Error("GetKey(enc-1) returned a key, want encryption keys skipped")
+	}
+}
+
+func TestJWKSCacheRefetchesUnknownKid(t *testing.T) {
+	oldJWK, _ := newRSAJWK(t, "old")
+	server := newJWKSServer(t, oldJWK)
+
+	cache := NewJWKSCache(server.URL, time.Hour)
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	// Keys rotated upstream; a token signed with the new kid arrives within the rate limit
+	newJWK, newPub := newECJWK(t, "new")
+	server.publish(oldJWK, newJWK)
+	if _, err := cache.GetKey("new"); err == nil {
+		t.Error("GetKey(new) succeeded within minRefetchInterval, want the refetch rate-limited")
+	}
+	if got := server.fetchCount(); got != 1 {
+		t.Errorf("fetches = %d, want 1 while rate-limited", got)
+	}
+
+	cache.mu.Lock()
+	cache.lastAttempt = time.Now().Add(-2 * minRefetchInterval)
+	cache.mu.Unlock()
+
+	got, err := cache.GetKey("new")
+	if err != nil {
+		t.Fatalf("GetKey(new) returned error after the rate limit: %v", err)
+	}
+	if key, ok := got.(*ecdsa.PublicKey); !ok || !key.Equal(newPub) {
+		t.Errorf("GetKey(new) = %v, want the rotated-in EC key", got)
+	}
+	if got := server.fetchCount(); got != 2 {
+		t.Errorf("fetches = %d, want the unknown kid to trigger exactly one refetch", got)
+	}
+}
+
+func TestJWKSCacheKeepsCachedKeysWhenFetchFails(t *testing.T) {
+	rsaJWK, rsaPub := newRSAJWK(t, "rsa-1")
+	server := newJWKSServer(t, rsaJWK)
+
+	cache := NewJWKSCache(server.URL, time.Hour)
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	server.fail(http.StatusServiceUnavailable)
+	if err := cache.Refresh(context.Background()); err == nil {
+		t.Error("Refresh returned nil, want the 503 reported")
+	}
+
+	// Stale keys are served immediately while one background refresh is attempted
+	cache.mu.Lock()
+	cache.lastFetched = time.Now().Add(-2 * time.Hour)
+	cache.lastAttempt = time.Now().Add(-2 * minRefetchInterval)
+	cache.mu.Unlock()
+
+	for i := 0; i < 5; i++ {
+		got, err := cache.GetKey("rsa-1")
+		if err != nil {
+			t.Fatalf("GetKey(rsa-1) returned error with JWKS down: %v", err)
+		}
+		if key, ok := got.(*rsa.PublicKey); !ok || !key.Equal(rsaPub) {
+			t.Errorf("GetKey(rsa-1) = %v, want the cached RSA key", got)
+		}
+	}
+	waitForFetches(t, server, 3)
+	time.Sleep(50 * time.Millisecond)
+	if got := server.fetchCount(); got != 3 {
+		t.Errorf("fetches = %d, want the stale lookups to share one rate-limited refresh", got)
+	}
+
+	// A document without usable keys is a failed fetch and leaves the cached keys in place
+	server.fail(http.StatusOK)
+	server.publish()
+	if err := cache.Refresh(context.Background()); err == nil {
+		t.Error("Refresh of an empty JWKS returned nil, want an error")
+	}
+	if _, err := cache.GetKey("rsa-1"); err != nil {
+		t.Errorf("GetKey(rsa-1) after an empty JWKS returned error: %v", err)
+	}
+}
+
+func TestJWKSCacheStaleLookupDoesNotBlock(t *testing.T) {
+	rsaJWK, _ := newRSAJWK(t, "rsa-1")
+	server := newJWKSServer(t, rsaJWK)
+
+	cache := NewJWKSCache(server.URL, time.Hour)
+	if err := cache.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+
+	// A hanging JWKS endpoint must not hold up requests with a known kid
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(hung.Close)
+	t.Cleanup(func() { close(release) })
+	cache.url = hung.URL
+	cache.mu.Lock()
+	cache.lastFetched = time.Now().Add(-2 * time.Hour)
+	cache.lastAttempt = time.Now().Add(-2 * minRefetchInterval)
+	cache.mu.Unlock()
+
+	start := time.Now()
+	if _, err := cache.GetKey("rsa-1"); err != nil {
+		t.Fatalf("GetKey(rsa-1) returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetKey(rsa-1) took %v with JWKS hanging, want the stale key served at once", elapsed)
+	}
+}
@@ -8,44 +8,116 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// UserClaims holds the claims extracted from a verified Supabase JWT
+type UserClaims struct {
+	UserID  string   // "sub" claim
+	Role    string   // top-level "role" claim ("authenticated", "anon", "service_role")
+	AppRole string   // "app_metadata.role" claim, set server-side via the Supabase admin API
+	Roles   []string // "app_metadata.roles" claim, for users holding several roles
+}
+
+// IsAdmin reports whether the claims grant admin access.
+// Admin access is granted to the service_role key, or to users whose
+// app_metadata.role (or app_metadata.roles) contains the admin role
+// (ADMIN_ROLE env, default "admin").
+func (u *UserClaims) IsAdmin() bool {
+	if u.Role == "service_role" {
+		return true
+	}
+
+	adminRole := os.Getenv("ADMIN_ROLE")
+	if adminRole == "" {
+		adminRole = "admin"
+	}
+	if u.AppRole == adminRole {
+		return true
+	}
+	for _, role := range u.Roles {
+		if role == adminRole {
+			return true
+		}
+	}
+	return false
+}
+
 // VerifyJWT verifies a Supabase JWT token and extracts the user ID
 func VerifyJWT(tokenString string) (string, error) {
-	// Get JWT secret from environment
-	jwtSecret := os.Getenv("SUPABASE_JWT_SECRET")
-	if jwtSecret == "" {
-		return "", fmt.Errorf("SUPABASE_JWT_SECRET not set")
+	claims, err := VerifyJWTClaims(tokenString)
+	if err != nil {
+		return "", err
 	}
+	return claims.UserID, nil
+}
 
+// VerifyJWTClaims verifies a Supabase JWT token and extracts the user ID and roles
+func VerifyJWTClaims(tokenString string) (*UserClaims, error) {
 	// Parse the token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(jwtSecret), nil
-	})
+	token, err := jwt.Parse(tokenString, verificationKey)
 
 	if err != nil {
-		return "", fmt.Errorf("failed to parse token: %w", err)
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	if !token.Valid {
-		return "", fmt.Errorf("invalid token")
+		return nil, fmt.Errorf("invalid token")
 	}
 
 	// Extract claims
-	claims, ok := token.Claims.(jwt.MapClaims)
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", fmt.Errorf("failed to parse claims")
+		return nil, fmt.Errorf("failed to parse claims")
+	}
+
+	userClaims := &UserClaims{}
+	userClaims.Role, _ = mapClaims["role"].(string)
+	if appMetadata, ok := mapClaims["app_metadata"].(map[string]interface{}); ok {
+		userClaims.AppRole, _ = appMetadata["role"].(string)
+		if roles, ok := appMetadata["roles"].([]interface{}); ok {
+			for _, role := range roles {
+				if r, ok := role.(string); ok {
+					userClaims.Roles = append(userClaims.Roles, r)
+				}
+			}
+		}
 	}
 
 	// Extract user ID (Supabase uses "sub" claim for user ID)
-	userID, ok := claims["sub"].(string)
+	userID, ok := mapClaims["sub"].(string)
 	if !ok {
-		return "", fmt.Errorf("user ID not found in token claims")
+		// The service_role key has no subject but is still a valid admin credential
+		if userClaims.Role != "service_role" {
+			return nil, fmt.Errorf("user ID not found in token claims")
+		}
 	}
+	userClaims.UserID = userID
+
+	return userClaims, nil
+}
 
-	return userID, nil
+// verificationKey resolves the key used to verify a token's signature.
+// HMAC tokens (legacy Supabase projects) are verified with SUPABASE_JWT_SECRET;
+// RSA/ECDSA tokens are verified against the cached Supabase JWKS.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		// Get JWT secret from environment
+		jwtSecret := os.Getenv("SUPABASE_JWT_SECRET")
+		if jwtSecret == "" {
+			return nil, fmt.Errorf("SUPABASE_JWT_SECRET not set")
+		}
+		return []byte(jwtSecret), nil
+
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		jwks := GetJWKSCache()
+		if jwks == nil {
+			return nil, fmt.Errorf("SUPABASE_URL or SUPABASE_JWKS_URL must be set to verify %v tokens", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return jwks.GetKey(kid)
+
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
 }
 
 // ExtractTokenFromHeader extracts the JWT token from the Authorization header
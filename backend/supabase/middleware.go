@@ -18,7 +18,7 @@ func JWTAuthMiddleware() fiber.Handler {
 		}
 
 		// Verify JWT token
-		userID, err := VerifyJWT(tokenString)
+		claims, err := VerifyJWTClaims(tokenString)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": "Unauthorized",
@@ -26,11 +26,73 @@ func JWTAuthMiddleware() fiber.Handler {
 			})
 		}
 
-		// Attach user ID to context for use in routes
-		c.Locals("userID", userID)
+		// Attach user ID and roles to context for use in routes
+		setClaimsLocals(c, claims)
 
 		// Continue to next handler
 		return c.Next()
 	}
 }
 
+// OptionalJWT is a Fiber middleware that populates the user context when a valid
+// token is present, but never rejects the request. Anonymous requests and requests
+// with invalid tokens continue without a userID in locals.
+func OptionalJWT() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenString, err := ExtractTokenFromHeader(c.Get("Authorization"))
+		if err != nil {
+			return c.Next()
+		}
+
+		if claims, err := VerifyJWTClaims(tokenString); err == nil {
+			setClaimsLocals(c, claims)
+		}
+
+		return c.Next()
+	}
+}
+
+// AdminOnly is a Fiber middleware that restricts access to admin tokens.
+// Returns 401 when no valid token is present and 403 when the token is not an admin.
+// See UserClaims.IsAdmin for the expected claims.
+func AdminOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Reuse claims if JWTAuthMiddleware already ran for this request
+		claims, ok := c.Locals("claims").(*UserClaims)
+		if !ok {
+			tokenString, err := ExtractTokenFromHeader(c.Get("Authorization"))
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Unauthorized",
+					"message": err.Error(),
+				})
+			}
+
+			claims, err = VerifyJWTClaims(tokenString)
+			if err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Unauthorized",
+					"message": "Invalid token",
+				})
+			}
+			setClaimsLocals(c, claims)
+		}
+
+		if !claims.IsAdmin() {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Forbidden",
+				"message": "Admin access required",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// setClaimsLocals attaches verified claims to the request context
+func setClaimsLocals(c *fiber.Ctx, claims *UserClaims) {
+	c.Locals("claims", claims)
+	c.Locals("userID", claims.UserID)
+	c.Locals("userRole", claims.Role)
+	c.Locals("isAdmin", claims.IsAdmin())
+}
@@ -15,7 +15,7 @@ SELECT cron.schedule(
   $$
     SELECT net.http_post(
       url := 'https://zaned-backennd.onrender.com/api/admin/ingest/fundamental-data',
-      headers := '{"Content-Type": "application/json"}'::jsonb,
+      headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
       body := '{}'::jsonb,
       timeout_milliseconds := 1800000
     );
@@ -26,7 +26,7 @@ SELECT cron.schedule(
 SELECT net.http_post(
   url := 'https://zaned-backennd.onrender.com/api/admin/ingest/fundamental-data',
   body := '{}'::jsonb,
-  headers := '{"Content-Type": "application/json"}'::jsonb,
+  headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
   timeout_milliseconds := 1800000
 );
 
@@ -19,7 +19,7 @@ SELECT cron.schedule(
     SELECT net.http_post(
       url := 'https://zaned-backennd.onrender.com/api/admin/ingest/historicals?concurrency=8',
       body := '{}'::jsonb,
-      headers := '{"Content-Type": "application/json"}'::jsonb,
+      headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
       timeout_milliseconds := 600000
     );
   $$
@@ -29,6 +29,6 @@ SELECT cron.schedule(
 SELECT net.http_post(
   url := 'https://zaned-backennd.onrender.com/api/admin/ingest/historicals?concurrency=8',
   body := '{}'::jsonb,
-  headers := '{"Content-Type": "application/json"}'::jsonb,
+  headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
   timeout_milliseconds := 600000
 );
\ No newline at end of file
@@ -18,7 +18,7 @@ SELECT cron.schedule(
     SELECT net.http_post(
       url := 'https://zaned-backennd.onrender.com/api/admin/market-statistics/aggregate',
       body := '{}'::jsonb,
-      headers := '{"Content-Type": "application/json"}'::jsonb,
+      headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
       timeout_milliseconds := 600000
     );
   $$
@@ -28,6 +28,6 @@ SELECT cron.schedule(
 SELECT net.http_post(
   url := 'https://zaned-backennd.onrender.com/api/admin/market-statistics/aggregate',
   body := '{}'::jsonb,
-  headers := '{"Content-Type": "application/json"}'::jsonb,
+  headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
   timeout_milliseconds := 600000
 );
\ No newline at end of file
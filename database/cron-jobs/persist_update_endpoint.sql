@@ -21,7 +21,7 @@ SELECT cron.schedule(
   $$
     SELECT net.http_post(
       url := 'https://zaned-backennd.onrender.com/api/admin/cache/persist',
-      headers := '{"Content-Type": "application/json"}'::jsonb,
+      headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
       body := '{}'::jsonb
     );
   $$
@@ -34,7 +34,7 @@ SELECT cron.schedule(
   $$
     SELECT net.http_post(
       url := 'https://zaned-backennd.onrender.com/api/admin/cache/persist',
-      headers := '{"Content-Type": "application/json"}'::jsonb,
+      headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
       body := '{}'::jsonb
     );
   $$
@@ -45,7 +45,7 @@ SELECT cron.schedule(
 SELECT net.http_post(
   url := 'https://zaned-backennd.onrender.com/api/admin/cache/persist',
   body := '{}'::jsonb,
-  headers := '{"Content-Type": "application/json"}'::jsonb,
+  headers := '{"Content-Type": "application/json", "Authorization": "Bearer <SUPABASE_SERVICE_ROLE_KEY>"}'::jsonb,
   timeout_milliseconds := 60000
 );
 